@@ -3,14 +3,17 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // store time zones must resolve in images without zoneinfo
 
 	"github.com/fehepe/pet-store/backend/internal/app"
 	"github.com/fehepe/pet-store/backend/internal/config"
+	"github.com/fehepe/pet-store/backend/internal/rpc"
 	"github.com/fehepe/pet-store/backend/internal/server"
 )
 
@@ -30,6 +33,19 @@ func main() {
 	defer deps.Close()
 
 	srv := server.New(deps)
+	grpcServer := rpc.NewServer(rpc.NewService(deps.Services.Pet, deps.Services.Store, deps.Services.Order, deps.Services.Settings))
+
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if cfg.InventoryAgingInterval > 0 {
+		go deps.Services.Inventory.RunAgingJob(jobsCtx, cfg.InventoryAgingInterval)
+	}
+	if cfg.WebhookDispatchInterval > 0 {
+		go deps.Services.Webhooks.RunDispatcher(jobsCtx, cfg.WebhookDispatchInterval)
+	}
+	if cfg.OrderExpiryInterval > 0 {
+		go deps.Services.Order.RunExpiryJob(jobsCtx, cfg.OrderExpiryInterval, cfg.OrderPaymentTimeout)
+	}
 
 	go func() {
 		log.Printf("Server starting on port %s", cfg.Port)
@@ -37,17 +53,34 @@ func main() {
 		if cfg.Env == "development" {
 			log.Printf("Playground: http://localhost:%s/playground", cfg.Port)
 		}
+		if deps.Allowlist != nil {
+			log.Printf("GraphQL strict mode: %d allowed operations", deps.Allowlist.Len())
+		}
 
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
 	}()
 
+	go func() {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Fatalf("Failed to listen for gRPC: %v", err)
+		}
+
+		log.Printf("gRPC server starting on port %s", cfg.GRPCPort)
+		if err := grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopJobs()
+	grpcServer.GracefulStop()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
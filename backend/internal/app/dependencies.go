@@ -2,13 +2,18 @@ package app
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/fehepe/pet-store/backend/internal/cache"
 	"github.com/fehepe/pet-store/backend/internal/config"
 	"github.com/fehepe/pet-store/backend/internal/database"
 	"github.com/fehepe/pet-store/backend/internal/graph"
+	"github.com/fehepe/pet-store/backend/internal/notify"
+	"github.com/fehepe/pet-store/backend/internal/payment"
+	"github.com/fehepe/pet-store/backend/internal/pubsub"
 	"github.com/fehepe/pet-store/backend/internal/repository"
 	"github.com/fehepe/pet-store/backend/internal/service"
+	"github.com/fehepe/pet-store/backend/internal/webhook"
 	"github.com/fehepe/pet-store/backend/pkg/encryption"
 )
 
@@ -18,23 +23,45 @@ type Dependencies struct {
 	DB           database.Repository
 	Cache        cache.CacheInterface
 	Encryptor    encryption.EncryptorInterface
+	Payments     payment.PaymentProvider
+	PubSub       pubsub.Broker
 	Repositories *Repositories
 	Services     *Services
 	Resolver     graph.ResolverRoot
+	Allowlist    *graph.Allowlist // nil unless strict mode is enabled
 }
 
 // Repositories holds all repository instances
 type Repositories struct {
-	Pet   repository.PetRepositoryInterface
-	Store repository.StoreRepositoryInterface
-	Order repository.OrderRepositoryInterface
+	Pet       repository.PetRepositoryInterface
+	Store     repository.StoreRepositoryInterface
+	Order     repository.OrderRepositoryInterface
+	Cart      repository.CartRepositoryInterface
+	Promotion repository.PromotionRepositoryInterface
+	Pickup    repository.PickupRepositoryInterface
+	Settings  repository.StoreSettingsRepositoryInterface
+	Sales     repository.SalesRepositoryInterface
+	Inventory repository.InventoryRepositoryInterface
+	Webhook   repository.WebhookRepositoryInterface
 }
 
 // Services holds all service instances
 type Services struct {
-	Pet   *service.PetService
-	Store *service.StoreService
-	Order *service.OrderService
+	Pet        *service.PetService
+	Store      *service.StoreService
+	Order      *service.OrderService
+	Cart       *service.CartService
+	Promotion  *service.PromotionService
+	Receipt    *service.ReceiptService
+	Pickup     *service.PickupService
+	Settings   *service.StoreSettingsService
+	Sales      *service.SalesService
+	Inventory  *service.InventoryService
+	Export     *service.ExportService
+	Import     *service.PetImportService
+	Storefront *service.StorefrontService
+	PetEvents  *service.PetEventService
+	Webhooks   *service.WebhookService
 }
 
 // InitializeDependencies initializes all application dependencies
@@ -61,28 +88,75 @@ func InitializeDependencies(cfg *config.Config) (*Dependencies, error) {
 		return nil, fmt.Errorf("failed to initialize encryptor: %w", err)
 	}
 
+	payments, err := payment.NewProvider(cfg.PaymentProvider, cfg.PaymentWebhookSecret)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize payment provider: %w", err)
+	}
+
+	broker, err := pubsub.NewBroker(cfg.PubSubBackend, redisCache.Client())
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize pub/sub: %w", err)
+	}
+
 	repos := &Repositories{
-		Pet:   repository.NewPetRepository(db),
-		Store: repository.NewStoreRepository(db),
-		Order: repository.NewOrderRepository(db),
+		Pet:       repository.NewPetRepository(db),
+		Store:     repository.NewStoreRepository(db),
+		Order:     repository.NewOrderRepository(db),
+		Cart:      repository.NewCartRepository(db),
+		Promotion: repository.NewPromotionRepository(db),
+		Pickup:    repository.NewPickupRepository(db),
+		Settings:  repository.NewStoreSettingsRepository(db),
+		Sales:     repository.NewSalesRepository(db),
+		Inventory: repository.NewInventoryRepository(db),
+		Webhook:   repository.NewWebhookRepository(db),
 	}
 
+	// Local webhook receivers are only reachable in development
+	allowPrivateWebhooks := cfg.Env == "development"
+
 	services := &Services{
-		Store: service.NewStoreService(repos.Store, redisCache),
-		Pet:   service.NewPetService(repos.Pet, redisCache, encryptor),
+		Store:     service.NewStoreService(repos.Store, redisCache),
+		Settings:  service.NewStoreSettingsService(repos.Settings, repos.Store, redisCache),
+		Promotion: service.NewPromotionService(repos.Promotion),
+		Receipt:   service.NewReceiptService(repos.Order, repos.Store, repos.Promotion),
+		Sales:     service.NewSalesService(repos.Sales),
+		Export:    service.NewExportService(repos.Pet, repos.Order, repos.Store, encryptor),
+		PetEvents: service.NewPetEventService(broker),
+		Webhooks:  service.NewWebhookService(repos.Webhook, encryptor, webhook.NewClient(cfg.WebhookTimeout, allowPrivateWebhooks), allowPrivateWebhooks),
+	}
+	services.Inventory = service.NewInventoryService(repos.Inventory, services.Settings, notify.NewLogNotifier(os.Stdout))
+	services.Import = service.NewPetImportService(repos.Pet, redisCache, encryptor, services.Settings, services.PetEvents, services.Webhooks)
+	services.Storefront = service.NewStorefrontService(repos.Store, repos.Pet, redisCache, services.Settings, cfg.PublicBaseURL)
+	services.Pet = service.NewPetService(repos.Pet, redisCache, encryptor, services.Settings, services.PetEvents, services.Webhooks)
+	services.Pickup = service.NewPickupService(repos.Pickup, repos.Order, services.Settings)
+	services.Order = service.NewOrderService(repos.Order, repos.Pet, redisCache, services.Pet, payments, services.Promotion, services.Pickup, services.Settings, services.PetEvents, services.Webhooks)
+
+	services.Cart = service.NewCartService(repos.Cart, services.Pet, services.Order, services.Settings)
+
+	var allowlist *graph.Allowlist
+	if cfg.GraphQLAllowlistFile != "" {
+		allowlist, err = graph.LoadAllowlist(cfg.GraphQLAllowlistFile)
+		if err != nil {
+			db.Close()
+			return nil, err
+		}
 	}
-	services.Order = service.NewOrderService(repos.Order, repos.Pet, redisCache, services.Pet)
 
-	resolver := graph.NewResolver(services.Store, services.Pet, services.Order)
+	resolver := graph.NewResolver(services.Store, services.Pet, services.Order, services.Cart, services.Promotion, services.Receipt, services.Pickup, services.Settings, services.Sales, services.Inventory, services.Import, services.PetEvents, services.Webhooks)
 
 	return &Dependencies{
 		Config:       cfg,
 		DB:           db,
 		Cache:        redisCache,
 		Encryptor:    encryptor,
+		Payments:     payments,
+		PubSub:       broker,
 		Repositories: repos,
 		Services:     services,
 		Resolver:     resolver,
+		Allowlist:    allowlist,
 	}, nil
 }
 
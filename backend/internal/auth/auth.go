@@ -3,9 +3,7 @@ package auth
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
-	"io"
 	"net/http"
 	"strings"
 
@@ -79,6 +77,46 @@ func hashPassword(password string) string {
 	return string(hash)
 }
 
+// Authentication and authorization failures
+var (
+	ErrAuthorizationRequired = errors.New("authorization required")
+	ErrInvalidCredentials    = errors.New("invalid credentials")
+	ErrNoUser                = errors.New("user not found in context")
+	ErrNoUserType            = errors.New("user type not found in context")
+	ErrMerchantRequired      = errors.New("merchant access required")
+	ErrCustomerRequired      = errors.New("customer access required")
+)
+
+// Authenticate checks a "Basic ..." authorization value, such as the one sent in a
+// WebSocket connection_init payload, and returns ctx carrying the user
+func Authenticate(ctx context.Context, authorization string) (context.Context, error) {
+	const prefix = "Basic "
+	if !strings.HasPrefix(authorization, prefix) {
+		return nil, ErrAuthorizationRequired
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(authorization[len(prefix):])
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, exists := users[username]
+	if !exists {
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	ctx = context.WithValue(ctx, UserContextKey, username)
+	return context.WithValue(ctx, UserTypeContextKey, user.Type), nil
+}
+
 func BasicAuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -129,7 +167,7 @@ func BasicAuthMiddleware(next http.Handler) http.Handler {
 func GetUser(ctx context.Context) (string, error) {
 	user, ok := ctx.Value(UserContextKey).(string)
 	if !ok {
-		return "", errors.New("user not found in context")
+		return "", ErrNoUser
 	}
 	return user, nil
 }
@@ -137,7 +175,7 @@ func GetUser(ctx context.Context) (string, error) {
 func GetUserType(ctx context.Context) (UserType, error) {
 	userType, ok := ctx.Value(UserTypeContextKey).(UserType)
 	if !ok {
-		return "", errors.New("user type not found in context")
+		return "", ErrNoUserType
 	}
 	return userType, nil
 }
@@ -148,7 +186,7 @@ func RequireMerchant(ctx context.Context) error {
 		return err
 	}
 	if userType != UserTypeMerchant {
-		return errors.New("merchant access required")
+		return ErrMerchantRequired
 	}
 	return nil
 }
@@ -159,83 +197,34 @@ func RequireCustomer(ctx context.Context) error {
 		return err
 	}
 	if userType != UserTypeCustomer {
-		return errors.New("customer access required")
+		return ErrCustomerRequired
 	}
 	return nil
 }
 
-// ConditionalAuthMiddleware allows certain public queries without authentication
-func ConditionalAuthMiddleware(next http.Handler) http.Handler {
+// OptionalAuthMiddleware authenticates requests that send credentials and passes anonymous
+// requests through; the GraphQL server decides which operations anonymous clients may run
+func OptionalAuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Read the request body to check for public queries
-		body, err := io.ReadAll(r.Body)
-		if err != nil {
-			http.Error(w, "Failed to read request body", http.StatusBadRequest)
-			return
-		}
-		r.Body = io.NopCloser(strings.NewReader(string(body)))
-
-		// Parse the GraphQL request
-		var gqlRequest struct {
-			Query string `json:"query"`
-		}
-		if err := json.Unmarshal(body, &gqlRequest); err != nil {
-			http.Error(w, "Invalid JSON", http.StatusBadRequest)
-			return
-		}
-
-		queryLower := strings.ToLower(gqlRequest.Query)
-		isMutation := strings.Contains(queryLower, "mutation")
-		isPublicQuery := strings.Contains(queryLower, "liststores") ||
-			strings.Contains(queryLower, "availablepets")
-		if !isMutation && isPublicQuery {
+		// Subscriptions authenticate with the connection_init payload once the WebSocket is open
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
 			next.ServeHTTP(w, r)
 			return
 		}
 
-		// For all other queries, require authentication
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
-			http.Error(w, "Authorization required", http.StatusUnauthorized)
-			return
-		}
-
-		const prefix = "Basic "
-		if !strings.HasPrefix(authHeader, prefix) {
-			http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
+			next.ServeHTTP(w, r)
 			return
 		}
 
-		decoded, err := base64.StdEncoding.DecodeString(authHeader[len(prefix):])
+		ctx, err := Authenticate(r.Context(), authHeader)
 		if err != nil {
-			http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
-			return
-		}
-
-		credentials := string(decoded)
-		parts := strings.SplitN(credentials, ":", 2)
-		if len(parts) != 2 {
-			http.Error(w, "Invalid credentials format", http.StatusUnauthorized)
-			return
-		}
-
-		username, password := parts[0], parts[1]
-		user, exists := users[username]
-		if !exists {
-			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
-			return
-		}
-
-		if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
 			http.Error(w, "Invalid credentials", http.StatusUnauthorized)
 			return
 		}
 
-		// Add user info to context
-		ctx := context.WithValue(r.Context(), UserContextKey, username)
-		ctx = context.WithValue(ctx, UserTypeContextKey, user.Type)
-
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
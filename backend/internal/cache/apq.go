@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// Ensure APQCache implements the gqlgen cache used for automatic persisted queries
+var _ graphql.Cache[string] = (*APQCache)(nil)
+
+// APQCache stores automatic persisted queries in a CacheInterface, keyed by their SHA-256 hash
+type APQCache struct {
+	cache CacheInterface
+	ttl   time.Duration
+}
+
+// NewAPQCache creates a persisted query cache; queries expire ttl after they were last registered
+func NewAPQCache(cache CacheInterface, ttl time.Duration) *APQCache {
+	return &APQCache{cache: cache, ttl: ttl}
+}
+
+// Get returns the query registered for hash
+func (a *APQCache) Get(ctx context.Context, hash string) (string, bool) {
+	var query string
+	if err := a.cache.Get(ctx, APQCacheKey(hash), &query); err != nil {
+		return "", false
+	}
+	return query, true
+}
+
+// Add registers the query for hash. A failed write only means the client resends the query.
+func (a *APQCache) Add(ctx context.Context, hash string, query string) {
+	_ = a.cache.Set(ctx, APQCacheKey(hash), query, a.ttl)
+}
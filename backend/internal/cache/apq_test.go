@@ -0,0 +1,49 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/cache"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAPQCache(t *testing.T) {
+	const hash = "ecf4edb46db40b5132295c0291d62fb65d6759a9eedfa4d5d612dd5ec54a6b38"
+	ctx := context.Background()
+
+	t.Run("registers queries with the ttl", func(t *testing.T) {
+		mockCache := new(mocks.MockCache)
+		mockCache.On("Set", ctx, "apq:"+hash, "{ myStores { id } }", 24*time.Hour).Return(nil)
+
+		cache.NewAPQCache(mockCache, 24*time.Hour).Add(ctx, hash, "{ myStores { id } }")
+
+		mockCache.AssertExpectations(t)
+	})
+
+	t.Run("hit", func(t *testing.T) {
+		mockCache := new(mocks.MockCache)
+		mockCache.On("Get", ctx, "apq:"+hash, mock.Anything).Run(func(args mock.Arguments) {
+			*args[2].(*string) = "{ myStores { id } }"
+		}).Return(nil)
+
+		query, ok := cache.NewAPQCache(mockCache, time.Hour).Get(ctx, hash)
+
+		assert.True(t, ok)
+		assert.Equal(t, "{ myStores { id } }", query)
+	})
+
+	t.Run("miss", func(t *testing.T) {
+		mockCache := new(mocks.MockCache)
+		mockCache.On("Get", ctx, "apq:"+hash, mock.Anything).Return(errors.New("key not found"))
+
+		query, ok := cache.NewAPQCache(mockCache, time.Hour).Get(ctx, hash)
+
+		assert.False(t, ok)
+		assert.Empty(t, query)
+	})
+}
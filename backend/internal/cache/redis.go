@@ -108,6 +108,11 @@ func (c *Cache) InvalidatePattern(ctx context.Context, pattern string) error {
 	return nil
 }
 
+// Client returns the underlying Redis client, for features such as pub/sub that share the connection
+func (c *Cache) Client() *redis.Client {
+	return c.client
+}
+
 // Close closes the cache connection
 func (c *Cache) Close() error {
 	return c.client.Close()
@@ -128,3 +133,15 @@ func PetCacheKey(storeID, petID string) string {
 func StoreCacheKey(storeID string) string {
 	return fmt.Sprintf("store:%s", storeID)
 }
+
+func StoreSettingsCacheKey(storeID string) string {
+	return fmt.Sprintf("store_settings:%s", storeID)
+}
+
+func SitemapCacheKey() string {
+	return "sitemap"
+}
+
+func APQCacheKey(hash string) string {
+	return fmt.Sprintf("apq:%s", hash)
+}
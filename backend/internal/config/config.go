@@ -4,14 +4,17 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
 	// Server
-	Port string
-	Env  string
+	Port          string
+	Env           string
+	PublicBaseURL string // origin used in links on the public storefront pages
+	GRPCPort      string // port of the internal gRPC API
 
 	// Database
 	DBHost     string
@@ -27,11 +30,33 @@ type Config struct {
 	RedisPassword string
 	RedisDB       int
 
+	// GraphQL limits; 0 disables a limit
+	GraphQLMaxComplexity int
+	GraphQLMaxDepth      int
+
+	// Automatic persisted queries, and the manifest of the only operations allowed in strict mode
+	GraphQLAPQTTL        time.Duration
+	GraphQLAllowlistFile string // empty disables strict mode
+
+	// Pub/sub backend for GraphQL subscriptions: redis (default) or memory
+	PubSubBackend string
+
 	// Security
 	EncryptionKey string
 
 	// Storage
 	UploadDir string
+
+	// Payments
+	PaymentProvider      string
+	PaymentWebhookSecret string
+
+	// Background jobs
+	InventoryAgingInterval  time.Duration // 0 disables the inventory aging job
+	WebhookDispatchInterval time.Duration // 0 disables sending queued webhook deliveries
+	WebhookTimeout          time.Duration // bound on a single webhook delivery attempt
+	OrderExpiryInterval     time.Duration // 0 disables releasing orders stuck awaiting payment
+	OrderPaymentTimeout     time.Duration // age at which an order still awaiting payment expires
 }
 
 func Load() (*Config, error) {
@@ -40,8 +65,10 @@ func Load() (*Config, error) {
 
 	cfg := &Config{
 		// Server
-		Port: getEnv("PORT", "8080"),
-		Env:  getEnv("ENV", "development"),
+		Port:          getEnv("PORT", "8080"),
+		Env:           getEnv("ENV", "development"),
+		PublicBaseURL: getEnv("PUBLIC_BASE_URL", ""),
+		GRPCPort:      getEnv("GRPC_PORT", "9090"),
 
 		// Database
 		DBHost:     getEnv("DB_HOST", "localhost"),
@@ -57,17 +84,44 @@ func Load() (*Config, error) {
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvAsInt("REDIS_DB", 0),
 
+		// GraphQL limits
+		GraphQLMaxComplexity: getEnvAsInt("GRAPHQL_MAX_COMPLEXITY", 2000),
+		GraphQLMaxDepth:      getEnvAsInt("GRAPHQL_MAX_DEPTH", 10),
+		GraphQLAPQTTL:        getEnvAsDuration("GRAPHQL_APQ_TTL", 24*time.Hour),
+		GraphQLAllowlistFile: getEnv("GRAPHQL_ALLOWLIST_FILE", ""),
+
+		// Pub/sub
+		PubSubBackend: getEnv("PUBSUB_BACKEND", "redis"),
+
 		// Security
 		EncryptionKey: getEnv("ENCRYPTION_KEY", ""),
 
 		// Storage
 		UploadDir: getEnv("UPLOAD_DIR", "./uploads"),
+
+		// Payments
+		PaymentProvider:      getEnv("PAYMENT_PROVIDER", "fake"),
+		PaymentWebhookSecret: getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+
+		// Background jobs
+		InventoryAgingInterval:  getEnvAsDuration("INVENTORY_AGING_INTERVAL", time.Hour),
+		WebhookDispatchInterval: getEnvAsDuration("WEBHOOK_DISPATCH_INTERVAL", 10*time.Second),
+		WebhookTimeout:          getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		OrderExpiryInterval:     getEnvAsDuration("ORDER_EXPIRY_INTERVAL", time.Minute),
+		OrderPaymentTimeout:     getEnvAsDuration("ORDER_PAYMENT_TIMEOUT", 15*time.Minute),
+	}
+
+	if cfg.PublicBaseURL == "" {
+		cfg.PublicBaseURL = "http://localhost:" + cfg.Port
 	}
 
 	// Validate required fields
 	if cfg.EncryptionKey == "" {
 		return nil, fmt.Errorf("ENCRYPTION_KEY is required")
 	}
+	if cfg.PaymentWebhookSecret == "" && cfg.Env != "development" {
+		return nil, fmt.Errorf("PAYMENT_WEBHOOK_SECRET is required outside development")
+	}
 
 	return cfg, nil
 }
@@ -93,3 +147,11 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	valueStr := getEnv(key, "")
+	if value, err := time.ParseDuration(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
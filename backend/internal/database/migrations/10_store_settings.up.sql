@@ -0,0 +1,11 @@
+-- Per-store business rules; stores without a row use the defaults below
+CREATE TABLE IF NOT EXISTS store_settings (
+    store_id UUID PRIMARY KEY REFERENCES stores(id) ON DELETE CASCADE,
+    max_pets_per_order INTEGER NOT NULL DEFAULT 10 CHECK (max_pets_per_order BETWEEN 1 AND 50),
+    max_pet_age INTEGER NOT NULL DEFAULT 50 CHECK (max_pet_age BETWEEN 1 AND 100),
+    max_page_size INTEGER NOT NULL DEFAULT 100 CHECK (max_page_size BETWEEN 1 AND 500),
+    min_customer_age INTEGER NOT NULL DEFAULT 0 CHECK (min_customer_age BETWEEN 0 AND 120),
+    reservation_hold_hours INTEGER NOT NULL DEFAULT 72 CHECK (reservation_hold_hours BETWEEN 1 AND 720),
+    show_breeder_names BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
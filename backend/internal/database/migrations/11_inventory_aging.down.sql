@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pets_store_available_created;
+DROP TABLE IF EXISTS inventory_alerts;
+ALTER TABLE store_settings DROP COLUMN IF EXISTS stale_after_days;
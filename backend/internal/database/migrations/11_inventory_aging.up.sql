@@ -0,0 +1,15 @@
+-- Available pets listed for longer than this many days raise an inventory alert
+ALTER TABLE store_settings
+    ADD COLUMN IF NOT EXISTS stale_after_days INTEGER NOT NULL DEFAULT 30 CHECK (stale_after_days BETWEEN 1 AND 365);
+
+-- One alert per stale listing; the aging job skips pets that already have one
+CREATE TABLE IF NOT EXISTS inventory_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    pet_id UUID NOT NULL UNIQUE REFERENCES pets(id) ON DELETE CASCADE,
+    days_listed INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_inventory_alerts_store ON inventory_alerts(store_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pets_store_available_created ON pets(store_id, created_at) WHERE status = 'available';
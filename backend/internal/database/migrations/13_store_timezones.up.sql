@@ -0,0 +1,3 @@
+-- IANA time zone that pickup days and opening hours are expressed in
+ALTER TABLE store_settings
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
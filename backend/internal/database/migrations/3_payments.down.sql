@@ -0,0 +1,17 @@
+DELETE FROM order_items WHERE released_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_order_items_unreleased_pet_id;
+ALTER TABLE order_items ADD CONSTRAINT order_items_pet_id_key UNIQUE (pet_id);
+ALTER TABLE order_items DROP COLUMN IF EXISTS released_at;
+
+DROP INDEX IF EXISTS idx_orders_awaiting_payment;
+DROP INDEX IF EXISTS idx_orders_payment_id;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_status;
+ALTER TABLE orders DROP COLUMN IF EXISTS payment_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS total_cents;
+
+ALTER TABLE pets DROP COLUMN IF EXISTS price_cents;
+
+UPDATE pets SET status = 'sold' WHERE status = 'reserved';
+ALTER TABLE pets DROP CONSTRAINT IF EXISTS pets_status_check;
+ALTER TABLE pets ADD CONSTRAINT pets_status_check CHECK (status IN ('available', 'sold'));
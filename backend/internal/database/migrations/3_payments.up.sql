@@ -0,0 +1,21 @@
+-- Add pricing to pets
+ALTER TABLE pets ADD COLUMN IF NOT EXISTS price_cents BIGINT NOT NULL DEFAULT 0 CHECK (price_cents >= 0);
+
+-- Pets of an order awaiting payment are reserved, and only sold once the payment is captured
+ALTER TABLE pets DROP CONSTRAINT IF EXISTS pets_status_check;
+ALTER TABLE pets ADD CONSTRAINT pets_status_check CHECK (status IN ('available', 'reserved', 'sold'));
+
+-- Add monetary totals and payment tracking to orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_cents BIGINT NOT NULL DEFAULT 0 CHECK (total_cents >= 0);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_id VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_status VARCHAR(50) NOT NULL DEFAULT 'none'
+    CHECK (payment_status IN ('none', 'pending', 'authorized', 'captured', 'refunded', 'failed'));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_payment_id ON orders(payment_id) WHERE payment_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_awaiting_payment ON orders(created_at) WHERE payment_status IN ('pending', 'authorized');
+
+-- Items of orders whose payment fails or is refunded are kept and marked released,
+-- so a pet is only held by one unreleased item at a time
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS released_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE order_items DROP CONSTRAINT IF EXISTS order_items_pet_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_items_unreleased_pet_id ON order_items(pet_id) WHERE released_at IS NULL;
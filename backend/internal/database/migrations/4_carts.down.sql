@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_carts_updated_at ON carts;
+DROP TABLE IF EXISTS cart_items;
+DROP TABLE IF EXISTS carts;
@@ -0,0 +1,5 @@
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_cents;
+
+DROP TRIGGER IF EXISTS update_promotions_updated_at ON promotions;
+DROP TABLE IF EXISTS order_discounts;
+DROP TABLE IF EXISTS promotions;
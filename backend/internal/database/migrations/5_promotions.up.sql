@@ -0,0 +1,43 @@
+-- Create promotions table
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    description TEXT,
+    discount_type VARCHAR(50) NOT NULL CHECK (discount_type IN ('percentage', 'fixed_amount', 'nth_item_percentage')),
+    discount_value BIGINT NOT NULL CHECK (discount_value > 0),
+    species VARCHAR(50) CHECK (species IN ('Cat', 'Dog', 'Frog')),
+    min_age INTEGER CHECK (min_age >= 0),
+    max_age INTEGER CHECK (max_age >= 0),
+    min_quantity INTEGER NOT NULL DEFAULT 1 CHECK (min_quantity >= 1),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    usage_limit INTEGER CHECK (usage_limit > 0),
+    per_customer_limit INTEGER CHECK (per_customer_limit > 0),
+    times_used INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT promotions_store_id_code_key UNIQUE(store_id, code)
+);
+
+-- Create order_discounts table (discount lines recorded at checkout)
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    promotion_id UUID NOT NULL REFERENCES promotions(id) ON DELETE RESTRICT,
+    code VARCHAR(50) NOT NULL,
+    pet_id UUID REFERENCES pets(id) ON DELETE SET NULL,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents >= 0),
+    description TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_cents BIGINT NOT NULL DEFAULT 0 CHECK (discount_cents >= 0);
+
+CREATE INDEX idx_promotions_store_id ON promotions(store_id);
+CREATE INDEX idx_order_discounts_order_id ON order_discounts(order_id);
+CREATE INDEX idx_order_discounts_promotion_id ON order_discounts(promotion_id);
+
+CREATE TRIGGER update_promotions_updated_at BEFORE UPDATE ON promotions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_pickup_slots_updated_at ON pickup_slots;
+
+DROP TABLE IF EXISTS pickup_appointments;
+DROP TABLE IF EXISTS pickup_slots;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
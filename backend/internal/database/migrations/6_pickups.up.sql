@@ -0,0 +1,38 @@
+-- Track the fulfillment status of orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS status VARCHAR(50) NOT NULL DEFAULT 'placed'
+    CHECK (status IN ('placed', 'pickup_scheduled', 'completed'));
+
+-- Needed to combine store_id equality with range overlap in one exclusion constraint
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Create pickup_slots table (bookable time windows per store; a store's slots never overlap)
+CREATE TABLE IF NOT EXISTS pickup_slots (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    store_id UUID NOT NULL REFERENCES stores(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    booked INTEGER NOT NULL DEFAULT 0 CHECK (booked >= 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at),
+    CHECK (booked <= capacity),
+    CONSTRAINT pickup_slots_no_overlap EXCLUDE USING gist (store_id WITH =, tstzrange(starts_at, ends_at) WITH &&)
+);
+
+-- Create pickup_appointments table (one appointment per order)
+CREATE TABLE IF NOT EXISTS pickup_appointments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    slot_id UUID NOT NULL REFERENCES pickup_slots(id) ON DELETE RESTRICT,
+    customer_id VARCHAR(255) NOT NULL,
+    status VARCHAR(50) NOT NULL DEFAULT 'booked' CHECK (status IN ('booked', 'completed', 'cancelled')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_pickup_slots_store_starts_at ON pickup_slots(store_id, starts_at);
+CREATE INDEX idx_pickup_appointments_slot_id ON pickup_appointments(slot_id);
+
+CREATE TRIGGER update_pickup_slots_updated_at BEFORE UPDATE ON pickup_slots
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
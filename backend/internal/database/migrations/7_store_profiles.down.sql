@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_orders_store_status;
+DROP INDEX IF EXISTS idx_stores_open;
+DROP INDEX IF EXISTS idx_stores_slug;
+
+ALTER TABLE stores DROP COLUMN IF EXISTS closed_at;
+ALTER TABLE stores DROP COLUMN IF EXISTS opening_hours;
+ALTER TABLE stores DROP COLUMN IF EXISTS logo_url;
+ALTER TABLE stores DROP COLUMN IF EXISTS phone;
+ALTER TABLE stores DROP COLUMN IF EXISTS address;
+ALTER TABLE stores DROP COLUMN IF EXISTS description;
+ALTER TABLE stores DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,25 @@
+-- Add profile fields and soft-close support to stores
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS slug VARCHAR(120);
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS description TEXT;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS address VARCHAR(500);
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS phone VARCHAR(50);
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS logo_url VARCHAR(500);
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS opening_hours JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE;
+
+-- Derive slugs for existing stores from their names, keeping them unique
+UPDATE stores SET slug = TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(name, '[^a-zA-Z0-9]+', '-', 'g')))
+WHERE slug IS NULL;
+
+UPDATE stores SET slug = 'store' WHERE slug = '';
+
+UPDATE stores s SET slug = s.slug || '-' || LEFT(s.id::text, 8)
+WHERE EXISTS (
+    SELECT 1 FROM stores o WHERE o.slug = s.slug AND o.created_at < s.created_at
+);
+
+ALTER TABLE stores ALTER COLUMN slug SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stores_slug ON stores(slug);
+CREATE INDEX IF NOT EXISTS idx_stores_open ON stores(name) WHERE closed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_store_status ON orders(store_id, status);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_stores_owner_id;
+
+-- Fails if a merchant owns more than one store
+ALTER TABLE stores ADD CONSTRAINT stores_owner_id_key UNIQUE (owner_id);
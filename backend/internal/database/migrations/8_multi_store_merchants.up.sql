@@ -0,0 +1,4 @@
+-- Allow a merchant to own several stores
+ALTER TABLE stores DROP CONSTRAINT IF EXISTS stores_owner_id_key;
+
+CREATE INDEX IF NOT EXISTS idx_stores_owner_id ON stores(owner_id);
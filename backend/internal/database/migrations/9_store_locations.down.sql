@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_stores_location;
+
+ALTER TABLE stores DROP CONSTRAINT IF EXISTS stores_location_check;
+ALTER TABLE stores DROP COLUMN IF EXISTS longitude;
+ALTER TABLE stores DROP COLUMN IF EXISTS latitude;
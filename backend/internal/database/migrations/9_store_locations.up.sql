@@ -0,0 +1,12 @@
+-- Add coordinates to stores for proximity search
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE stores ADD CONSTRAINT stores_location_check CHECK (
+    (latitude IS NULL AND longitude IS NULL) OR
+    (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+);
+
+-- Bounding-box prefilter for stores near a point
+CREATE INDEX IF NOT EXISTS idx_stores_location ON stores(latitude, longitude)
+WHERE closed_at IS NULL AND latitude IS NOT NULL;
@@ -0,0 +1,129 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Defaults for how long a loader collects keys and how many it fetches at once
+const (
+	DefaultWait     = 2 * time.Millisecond
+	DefaultMaxBatch = 100
+)
+
+// BatchFunc fetches the values of many keys at once. Keys missing from the result are reported as not found.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader collects the keys requested within a short window and fetches them with a single BatchFunc call.
+// Values are cached for the loader's lifetime, so a loader must not outlive one operation; errors are not
+// cached, so a later Load of a failed key fetches it again.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	notFound func(K) error
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	results map[K]*result[V]
+	pending *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys       []K
+	results    []*result[V]
+	dispatched bool
+}
+
+// New creates a loader; notFound builds the error returned for keys the batch did not find
+func New[K comparable, V any](fetch BatchFunc[K, V], notFound func(K) error) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		notFound: notFound,
+		wait:     DefaultWait,
+		maxBatch: DefaultMaxBatch,
+		results:  map[K]*result[V]{},
+	}
+}
+
+// Load returns the value of key, batching it with the other keys requested meanwhile
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	res, ok := l.results[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.results[key] = res
+		l.enqueue(ctx, key, res)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue adds key to the pending batch, starting one if needed; l.mu must be held
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, res *result[V]) {
+	if l.pending == nil {
+		b := &batch[K, V]{}
+		l.pending = b
+		time.AfterFunc(l.wait, func() { l.dispatch(ctx, b) })
+	}
+
+	b := l.pending
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, res)
+	if len(b.keys) >= l.maxBatch {
+		l.pending = nil
+		go l.dispatch(ctx, b)
+	}
+}
+
+// dispatch fetches a batch once, whether its window ran out or it filled up first. The batch serves every
+// caller that joined it, so one caller giving up does not cancel the fetch for the others.
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	l.mu.Lock()
+	if b.dispatched {
+		l.mu.Unlock()
+		return
+	}
+	b.dispatched = true
+	if l.pending == b {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(context.WithoutCancel(ctx), b.keys)
+	for i, key := range b.keys {
+		res := b.results[i]
+		if err != nil {
+			res.err = err
+		} else if value, ok := values[key]; ok {
+			res.value = value
+		} else {
+			res.err = l.notFound(key)
+		}
+	}
+
+	l.mu.Lock()
+	for i, key := range b.keys {
+		if res := b.results[i]; res.err != nil && l.results[key] == res {
+			delete(l.results, key)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, res := range b.results {
+		close(res.done)
+	}
+}
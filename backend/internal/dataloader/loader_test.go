@@ -0,0 +1,260 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingFetch doubles every key it is asked for and records each batch
+type recordingFetch struct {
+	mu      sync.Mutex
+	batches [][]int
+	err     error
+}
+
+func (f *recordingFetch) fetch(_ context.Context, keys []int) (map[int]int, error) {
+	f.mu.Lock()
+	f.batches = append(f.batches, append([]int(nil), keys...))
+	f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	values := map[int]int{}
+	for _, key := range keys {
+		if key >= 0 {
+			values[key] = key * 2
+		}
+	}
+	return values, nil
+}
+
+func notFound(key int) error {
+	return fmt.Errorf("%d not found", key)
+}
+
+func loadAll(loader *Loader[int, int], keys []int) ([]int, []error) {
+	values := make([]int, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], errs[i] = loader.Load(context.Background(), key)
+		}()
+	}
+	wg.Wait()
+
+	return values, errs
+}
+
+func TestLoader_Load(t *testing.T) {
+	tests := []struct {
+		name        string
+		keys        []int
+		maxBatch    int
+		fetchErr    error
+		wantValues  []int
+		wantErrs    []string
+		wantBatches int
+	}{
+		{
+			name:        "concurrent loads share one batch",
+			keys:        []int{1, 2, 3},
+			maxBatch:    DefaultMaxBatch,
+			wantValues:  []int{2, 4, 6},
+			wantErrs:    []string{"", "", ""},
+			wantBatches: 1,
+		},
+		{
+			name:        "repeated keys are fetched once",
+			keys:        []int{1, 1, 1},
+			maxBatch:    DefaultMaxBatch,
+			wantValues:  []int{2, 2, 2},
+			wantErrs:    []string{"", "", ""},
+			wantBatches: 1,
+		},
+		{
+			name:        "missing keys are not found",
+			keys:        []int{1, -1},
+			maxBatch:    DefaultMaxBatch,
+			wantValues:  []int{2, 0},
+			wantErrs:    []string{"", "-1 not found"},
+			wantBatches: 1,
+		},
+		{
+			name:        "fetch errors reach every key",
+			keys:        []int{1, 2},
+			maxBatch:    DefaultMaxBatch,
+			fetchErr:    errors.New("connection refused"),
+			wantValues:  []int{0, 0},
+			wantErrs:    []string{"connection refused", "connection refused"},
+			wantBatches: 1,
+		},
+		{
+			name:        "full batches are fetched right away",
+			keys:        []int{1, 2, 3, 4},
+			maxBatch:    2,
+			wantValues:  []int{2, 4, 6, 8},
+			wantErrs:    []string{"", "", "", ""},
+			wantBatches: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &recordingFetch{err: tt.fetchErr}
+			loader := New(f.fetch, notFound)
+			loader.maxBatch = tt.maxBatch
+			// A longer window keeps slow test runners from splitting the batch
+			loader.wait = 50 * time.Millisecond
+
+			values, errs := loadAll(loader, tt.keys)
+
+			assert.Equal(t, tt.wantValues, values)
+			for i, wantErr := range tt.wantErrs {
+				if wantErr == "" {
+					assert.NoError(t, errs[i])
+				} else {
+					assert.EqualError(t, errs[i], wantErr)
+				}
+			}
+			assert.Len(t, f.batches, tt.wantBatches)
+		})
+	}
+}
+
+func TestLoader_CachesResults(t *testing.T) {
+	f := &recordingFetch{}
+	loader := New(f.fetch, notFound)
+
+	first, err := loader.Load(context.Background(), 21)
+	require.NoError(t, err)
+	second, err := loader.Load(context.Background(), 21)
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, first)
+	assert.Equal(t, 42, second)
+	assert.Len(t, f.batches, 1)
+}
+
+func TestLoader_DoesNotCacheErrors(t *testing.T) {
+	f := &recordingFetch{err: errors.New("connection refused")}
+	loader := New(f.fetch, notFound)
+
+	_, err := loader.Load(context.Background(), 21)
+	require.EqualError(t, err, "connection refused")
+
+	f.mu.Lock()
+	f.err = nil
+	f.mu.Unlock()
+
+	value, err := loader.Load(context.Background(), 21)
+	require.NoError(t, err)
+	assert.Equal(t, 42, value)
+	assert.Len(t, f.batches, 2)
+}
+
+func TestLoader_CanceledCallerDoesNotFailTheBatch(t *testing.T) {
+	f := &recordingFetch{}
+	loader := New(f.fetch, notFound)
+	loader.wait = 50 * time.Millisecond
+	loader.fetch = func(ctx context.Context, keys []int) (map[int]int, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return f.fetch(ctx, keys)
+	}
+
+	// The canceled caller starts the batch, so the fetch runs with its context
+	ctx, cancel := context.WithCancel(context.Background())
+	canceled := make(chan error, 1)
+	go func() {
+		_, err := loader.Load(ctx, 1)
+		canceled <- err
+	}()
+	require.Eventually(t, func() bool {
+		loader.mu.Lock()
+		defer loader.mu.Unlock()
+		return loader.pending != nil
+	}, time.Second, time.Millisecond)
+	cancel()
+
+	value, err := loader.Load(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 4, value)
+	assert.ErrorIs(t, <-canceled, context.Canceled)
+}
+
+func TestFor(t *testing.T) {
+	_, err := For(context.Background())
+	assert.ErrorIs(t, err, ErrNoLoaders)
+
+	loaders := &Loaders{}
+	got, err := For(WithLoaders(context.Background(), loaders))
+	require.NoError(t, err)
+	assert.Same(t, loaders, got)
+}
+
+func TestMiddleware_LoadersPerOperation(t *testing.T) {
+	middleware := Middleware(nil, nil, nil, nil)
+
+	var seen []*Loaders
+	next := func(ctx context.Context) graphql.ResponseHandler {
+		loaders, err := For(ctx)
+		require.NoError(t, err)
+		seen = append(seen, loaders)
+		return nil
+	}
+	middleware(context.Background(), next)
+	middleware(context.Background(), next)
+
+	require.Len(t, seen, 2)
+	assert.NotSame(t, seen[0], seen[1])
+}
+
+func TestNewLoaders_OrderPetsBatchesOrders(t *testing.T) {
+	withPets, withoutPets := uuid.New(), uuid.New()
+	pet := &models.Pet{ID: uuid.New(), Name: "Tom"}
+
+	orders := new(mocks.MockOrderRepository)
+	orders.On("GetPetsByOrderIDs", mock.Anything, mock.MatchedBy(func(ids []uuid.UUID) bool { return len(ids) == 2 })).
+		Return(map[uuid.UUID][]*models.Pet{withPets: {pet}}, nil).Once()
+
+	loaders := NewLoaders(nil, nil, orders, nil)
+
+	var wg sync.WaitGroup
+	results := make(map[uuid.UUID][]*models.Pet)
+	var mu sync.Mutex
+	for _, id := range []uuid.UUID{withPets, withoutPets} {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			pets, err := loaders.OrderPets.Load(context.Background(), id)
+			assert.NoError(t, err)
+			mu.Lock()
+			results[id] = pets
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	assert.Equal(t, []*models.Pet{pet}, results[withPets])
+	assert.NotNil(t, results[withoutPets])
+	assert.Empty(t, results[withoutPets])
+	orders.AssertExpectations(t)
+}
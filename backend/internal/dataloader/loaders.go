@@ -0,0 +1,113 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const loadersContextKey = contextKey("loaders")
+
+// ErrNoLoaders is returned by For when the context carries no loaders
+var ErrNoLoaders = errors.New("dataloader: no loaders in context")
+
+// Loaders batches the by-ID lookups of one request
+type Loaders struct {
+	Pets           *Loader[uuid.UUID, *models.Pet]
+	Stores         *Loader[uuid.UUID, *models.Store]
+	Orders         *Loader[uuid.UUID, *models.Order]
+	OrderPets      *Loader[uuid.UUID, []*models.Pet]           // keyed by order ID
+	OrderDiscounts *Loader[uuid.UUID, []*models.OrderDiscount] // keyed by order ID
+}
+
+// NewLoaders creates a fresh set of loaders backed by the repositories' batch queries
+func NewLoaders(pets repository.PetRepositoryInterface, stores repository.StoreRepositoryInterface, orders repository.OrderRepositoryInterface, promotions repository.PromotionRepositoryInterface) *Loaders {
+	return &Loaders{
+		Pets: New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Pet, error) {
+			found, err := pets.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*models.Pet, len(found))
+			for _, pet := range found {
+				byID[pet.ID] = pet
+			}
+			return byID, nil
+		}, apperrors.NewPetNotFound),
+		Stores: New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Store, error) {
+			found, err := stores.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*models.Store, len(found))
+			for _, store := range found {
+				byID[store.ID] = store
+			}
+			return byID, nil
+		}, apperrors.NewStoreNotFound),
+		Orders: New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*models.Order, error) {
+			found, err := orders.GetByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*models.Order, len(found))
+			for _, order := range found {
+				byID[order.ID] = order
+			}
+			return byID, nil
+		}, apperrors.NewOrderNotFound),
+		OrderPets: New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*models.Pet, error) {
+			byOrder, err := orders.GetPetsByOrderIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			return withEmptyLists(byOrder, ids), nil
+		}, apperrors.NewOrderNotFound),
+		OrderDiscounts: New(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]*models.OrderDiscount, error) {
+			byOrder, err := promotions.GetDiscountsByOrderIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			return withEmptyLists(byOrder, ids), nil
+		}, apperrors.NewOrderNotFound),
+	}
+}
+
+// withEmptyLists gives keys without rows an empty list, so list loaders never report them as not found
+func withEmptyLists[V any](byKey map[uuid.UUID][]V, keys []uuid.UUID) map[uuid.UUID][]V {
+	for _, key := range keys {
+		if byKey[key] == nil {
+			byKey[key] = []V{}
+		}
+	}
+	return byKey
+}
+
+// Middleware gives every GraphQL operation its own loaders, so cached results never leak between
+// operations, not even between the operations of one WebSocket connection
+func Middleware(pets repository.PetRepositoryInterface, stores repository.StoreRepositoryInterface, orders repository.OrderRepositoryInterface, promotions repository.PromotionRepositoryInterface) graphql.OperationMiddleware {
+	return func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(WithLoaders(ctx, NewLoaders(pets, stores, orders, promotions)))
+	}
+}
+
+// WithLoaders stores loaders in the context
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersContextKey, loaders)
+}
+
+// For returns the loaders of the operation, or ErrNoLoaders when Middleware did not run
+func For(ctx context.Context) (*Loaders, error) {
+	loaders, ok := ctx.Value(loadersContextKey).(*Loaders)
+	if !ok {
+		return nil, ErrNoLoaders
+	}
+	return loaders, nil
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -88,6 +89,15 @@ func (e OrderNotFoundError) Error() string {
 	return fmt.Sprintf("order with ID %s not found", e.OrderID)
 }
 
+// PetsUnavailableError lists pets that were sold or removed before they could be purchased
+type PetsUnavailableError struct {
+	PetIDs []uuid.UUID
+}
+
+func (e PetsUnavailableError) Error() string {
+	return fmt.Sprintf("the following pets are no longer available: %v", e.PetIDs)
+}
+
 func NewBusinessRuleError(message string) error {
 	return BusinessRuleError{Message: message}
 }
@@ -95,3 +105,19 @@ func NewBusinessRuleError(message string) error {
 func NewOrderNotFound(orderID uuid.UUID) error {
 	return OrderNotFoundError{OrderID: orderID}
 }
+
+func NewPetsUnavailable(petIDs []uuid.UUID) error {
+	return PetsUnavailableError{PetIDs: petIDs}
+}
+
+// IsNotFound reports whether err says a pet, store, order or other resource does not exist
+func IsNotFound(err error) bool {
+	var (
+		notFoundErr    NotFoundError
+		petNotFoundErr PetNotFoundError
+		storeNotFound  StoreNotFoundError
+		orderNotFound  OrderNotFoundError
+	)
+	return errors.As(err, &notFoundErr) || errors.As(err, &petNotFoundErr) ||
+		errors.As(err, &storeNotFound) || errors.As(err, &orderNotFound)
+}
@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -73,3 +74,34 @@ func TestNewValidationError(t *testing.T) {
 	assert.Contains(t, err.Error(), "cannot be empty")
 }
 
+func TestPetsUnavailableError(t *testing.T) {
+	petID := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+	err := NewPetsUnavailable([]uuid.UUID{petID})
+
+	assert.Equal(t, "the following pets are no longer available: [7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e]", err.Error())
+	assert.IsType(t, PetsUnavailableError{}, err)
+}
+
+func TestIsNotFound(t *testing.T) {
+	id := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "resource", err: NotFoundError{Resource: "webhook endpoint", ID: id.String()}, want: true},
+		{name: "pet", err: NewPetNotFound(id), want: true},
+		{name: "store", err: NewStoreNotFound(id), want: true},
+		{name: "order", err: NewOrderNotFound(id), want: true},
+		{name: "wrapped", err: fmt.Errorf("failed to load pet: %w", NewPetNotFound(id)), want: true},
+		{name: "validation", err: NewValidationError("id", "invalid"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsNotFound(tt.err))
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Format identifies the output format of an export
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat converts a user supplied format name into a Format
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSONL:
+		return FormatJSONL, nil
+	default:
+		return "", fmt.Errorf("unsupported export format: %s", value)
+	}
+}
+
+// ContentType returns the MIME type served for the format
+func (f Format) ContentType() string {
+	if f == FormatJSONL {
+		return "application/x-ndjson"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer writes export records one at a time
+type Writer interface {
+	// Write writes one record; values line up with the writer's columns
+	Write(values ...any) error
+
+	// Flush sends buffered records to the underlying writer
+	Flush() error
+}
+
+// NewWriter creates a writer for the given format. CSV output starts with a header row;
+// JSON Lines output has one object per record keyed by column name.
+func NewWriter(w io.Writer, format Format, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw, record: make([]string, len(columns))}, nil
+	case FormatJSONL:
+		return &jsonlWriter{w: bufio.NewWriter(w), columns: columns}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+func (c *csvWriter) Write(values ...any) error {
+	if len(values) != len(c.record) {
+		return fmt.Errorf("expected %d values, got %d", len(c.record), len(values))
+	}
+	for i, value := range values {
+		c.record[i] = formatValue(value)
+		switch value.(type) {
+		case string, *string:
+			c.record[i] = escapeFormula(c.record[i])
+		}
+	}
+	return c.w.Write(c.record)
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type jsonlWriter struct {
+	w       *bufio.Writer
+	columns []string
+}
+
+func (j *jsonlWriter) Write(values ...any) error {
+	if len(values) != len(j.columns) {
+		return fmt.Errorf("expected %d values, got %d", len(j.columns), len(values))
+	}
+
+	// Built by hand so keys keep the column order
+	j.w.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			j.w.WriteByte(',')
+		}
+		key, _ := json.Marshal(j.columns[i])
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", j.columns[i], err)
+		}
+		j.w.Write(key)
+		j.w.WriteByte(':')
+		j.w.Write(encoded)
+	}
+	j.w.WriteByte('}')
+	return j.w.WriteByte('\n')
+}
+
+func (j *jsonlWriter) Flush() error {
+	return j.w.Flush()
+}
+
+// escapeFormula prefixes text that a spreadsheet would evaluate as a formula with a quote.
+// Only text is escaped, so negative numbers stay numeric.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// formatValue renders a value as a CSV field; nil pointers become empty fields
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.UTC().Format(time.RFC3339)
+	case uuid.UUID:
+		return v.String()
+	case *uuid.UUID:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
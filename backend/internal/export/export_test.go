@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Format
+		wantErr bool
+	}{
+		{value: "csv", want: FormatCSV},
+		{value: " JSONL ", want: FormatJSONL},
+		{value: "", wantErr: true},
+		{value: "xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseFormat(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWriter_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	id := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+	createdAt := time.Date(2025, 3, 1, 9, 30, 0, 0, time.FixedZone("EST", -5*60*60))
+	description := "Likes naps, and \"treats\""
+
+	w, err := NewWriter(&buf, FormatCSV, []string{"id", "name", "description", "price_cents", "sold_at", "created_at"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write(id, "Tom", &description, int64(15000), (*time.Time)(nil), createdAt))
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t,
+		"id,name,description,price_cents,sold_at,created_at\n"+
+			"7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e,Tom,\"Likes naps, and \"\"treats\"\"\",15000,,2025-03-01T14:30:00Z\n",
+		buf.String())
+}
+
+func TestWriter_CSVEscapesFormulas(t *testing.T) {
+	var buf bytes.Buffer
+	note := "+1 555 0100"
+
+	w, err := NewWriter(&buf, FormatCSV, []string{"name", "notes", "breeder", "email", "balance_cents"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write("=HYPERLINK(\"http://x\")", &note, "-Acme", "@home", int64(-500)))
+	require.NoError(t, w.Flush())
+
+	assert.Equal(t,
+		"name,notes,breeder,email,balance_cents\n"+
+			"\"'=HYPERLINK(\"\"http://x\"\")\",'+1 555 0100,'-Acme,'@home,-500\n",
+		buf.String())
+}
+
+func TestWriter_JSONL(t *testing.T) {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, FormatJSONL, []string{"name", "price_cents", "description"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write("Tom", int64(15000), (*string)(nil)))
+	require.NoError(t, w.Write("Rex", int64(20000), "Good boy"))
+	require.NoError(t, w.Flush())
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	assert.Equal(t, `{"name":"Tom","price_cents":15000,"description":null}`, string(lines[0]))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(lines[1], &record))
+	assert.Equal(t, "Good boy", record["description"])
+}
+
+func TestWriter_ColumnMismatch(t *testing.T) {
+	for _, format := range []Format{FormatCSV, FormatJSONL} {
+		w, err := NewWriter(&bytes.Buffer{}, format, []string{"a", "b"})
+		require.NoError(t, err)
+		assert.Error(t, w.Write("only one"), format)
+	}
+}
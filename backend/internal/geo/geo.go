@@ -0,0 +1,60 @@
+package geo
+
+import "math"
+
+// EarthRadiusKm is the mean radius of the earth used for distance calculations
+const EarthRadiusKm = 6371.0
+
+// Box is a latitude/longitude rectangle enclosing a search circle
+type Box struct {
+	MinLat float64
+	MaxLat float64
+	MinLng float64
+	MaxLng float64
+}
+
+// DistanceKm returns the great-circle distance between two points using the haversine formula
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	dLat := radians(lat2 - lat1)
+	dLng := radians(lng2 - lng1)
+
+	a := math.Pow(math.Sin(dLat/2), 2) +
+		math.Cos(radians(lat1))*math.Cos(radians(lat2))*math.Pow(math.Sin(dLng/2), 2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(math.Min(1, a)))
+}
+
+// BoundingBox returns a box that contains every point within radiusKm of the center.
+// When the circle reaches a pole or crosses the antimeridian the box spans all longitudes.
+func BoundingBox(lat, lng, radiusKm float64) Box {
+	angular := radiusKm / EarthRadiusKm
+
+	box := Box{
+		MinLat: lat - degrees(angular),
+		MaxLat: lat + degrees(angular),
+		MinLng: -180,
+		MaxLng: 180,
+	}
+
+	if box.MinLat <= -90 || box.MaxLat >= 90 {
+		box.MinLat = math.Max(box.MinLat, -90)
+		box.MaxLat = math.Min(box.MaxLat, 90)
+		return box
+	}
+
+	dLng := degrees(math.Asin(math.Sin(angular) / math.Cos(radians(lat))))
+	if lng-dLng >= -180 && lng+dLng <= 180 {
+		box.MinLng = lng - dLng
+		box.MaxLng = lng + dLng
+	}
+
+	return box
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func degrees(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
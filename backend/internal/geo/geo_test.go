@@ -0,0 +1,55 @@
+package geo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want                   float64
+	}{
+		{name: "same point", lat1: 40.7128, lng1: -74.0060, lat2: 40.7128, lng2: -74.0060, want: 0},
+		{name: "new york to los angeles", lat1: 40.7128, lng1: -74.0060, lat2: 34.0522, lng2: -118.2437, want: 3936},
+		{name: "london to paris", lat1: 51.5074, lng1: -0.1278, lat2: 48.8566, lng2: 2.3522, want: 344},
+		{name: "across the antimeridian", lat1: 0, lng1: 179.5, lat2: 0, lng2: -179.5, want: 111.2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, DistanceKm(tt.lat1, tt.lng1, tt.lat2, tt.lng2), 1)
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	t.Run("contains points on the circle", func(t *testing.T) {
+		lat, lng, radius := 40.7128, -74.0060, 25.0
+		box := BoundingBox(lat, lng, radius)
+
+		assert.InDelta(t, radius, DistanceKm(lat, lng, box.MaxLat, lng), 0.01)
+		assert.InDelta(t, radius, DistanceKm(lat, lng, box.MinLat, lng), 0.01)
+		assert.Less(t, box.MinLng, lng)
+		assert.Greater(t, box.MaxLng, lng)
+		// The circle touches the east edge north of the center, so this corner lies outside it
+		assert.LessOrEqual(t, radius-0.01, DistanceKm(lat, lng, lat, box.MaxLng))
+	})
+
+	t.Run("near a pole spans all longitudes", func(t *testing.T) {
+		box := BoundingBox(89.9, 10, 50)
+
+		assert.Equal(t, 90.0, box.MaxLat)
+		assert.Equal(t, -180.0, box.MinLng)
+		assert.Equal(t, 180.0, box.MaxLng)
+	})
+
+	t.Run("across the antimeridian spans all longitudes", func(t *testing.T) {
+		box := BoundingBox(0, 179.9, 50)
+
+		assert.Equal(t, -180.0, box.MinLng)
+		assert.Equal(t, 180.0, box.MaxLng)
+	})
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errOperationNotAllowed = "OPERATION_NOT_ALLOWED"
+
+// Allowlist only runs operations registered in a manifest. Clients may send either the full
+// query or just its hash in the persistedQuery extension, as with automatic persisted queries.
+type Allowlist struct {
+	operations map[string]string // SHA-256 hash of the query -> query
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = (*Allowlist)(nil)
+
+// NewAllowlist creates an allowlist of the given queries
+func NewAllowlist(queries ...string) *Allowlist {
+	a := &Allowlist{operations: make(map[string]string, len(queries))}
+	for _, query := range queries {
+		a.operations[queryHash(query)] = query
+	}
+	return a
+}
+
+// LoadAllowlist reads a manifest: a JSON object mapping the hex SHA-256 hash of each query to the query
+func LoadAllowlist(path string) (*Allowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowlist: %w", err)
+	}
+
+	var manifest map[string]string
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse allowlist %s: %w", path, err)
+	}
+
+	a := &Allowlist{operations: make(map[string]string, len(manifest))}
+	for hash, query := range manifest {
+		if queryHash(query) != hash {
+			return nil, fmt.Errorf("allowlist %s: hash %s does not match its query", path, hash)
+		}
+		a.operations[hash] = query
+	}
+	return a, nil
+}
+
+// Len returns the number of allowed operations
+func (a *Allowlist) Len() int {
+	return len(a.operations)
+}
+
+func (a *Allowlist) ExtensionName() string {
+	return "Allowlist"
+}
+
+func (a *Allowlist) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// MutateOperationParameters fills in the query of a hash-only request and rejects unknown operations.
+// It must run before the AutomaticPersistedQuery extension, so registered hashes never miss.
+func (a *Allowlist) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if rawParams.Query == "" {
+		if query, ok := a.operations[persistedQueryHash(rawParams.Extensions)]; ok {
+			rawParams.Query = query
+			return nil
+		}
+	} else if _, ok := a.operations[queryHash(rawParams.Query)]; ok {
+		return nil
+	}
+
+	err := gqlerror.Errorf("operation is not in the allowlist")
+	errcode.Set(err, errOperationNotAllowed)
+	return err
+}
+
+// persistedQueryHash returns the hash sent in the persistedQuery extension, if any
+func persistedQueryHash(extensions map[string]any) string {
+	persistedQuery, _ := extensions["persistedQuery"].(map[string]any)
+	hash, _ := persistedQuery["sha256Hash"].(string)
+	return hash
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,124 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const allowedQuery = `{ cart { items { pet { id } } } }`
+
+func TestLoadAllowlist(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("valid manifest", func(t *testing.T) {
+		path := filepath.Join(dir, "valid.json")
+		manifest, _ := json.Marshal(map[string]string{queryHash(allowedQuery): allowedQuery})
+		require.NoError(t, os.WriteFile(path, manifest, 0o600))
+
+		allowlist, err := LoadAllowlist(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, allowlist.Len())
+	})
+
+	t.Run("hash does not match query", func(t *testing.T) {
+		path := filepath.Join(dir, "tampered.json")
+		manifest, _ := json.Marshal(map[string]string{queryHash(allowedQuery): `{ myStores { id } }`})
+		require.NoError(t, os.WriteFile(path, manifest, 0o600))
+
+		_, err := LoadAllowlist(path)
+
+		assert.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadAllowlist(filepath.Join(dir, "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestAllowlist_StrictMode(t *testing.T) {
+	apq := graphql.MapCache[string]{}
+	srv := handler.New(newLimitedSchema())
+	srv.AddTransport(transport.POST{})
+	srv.Use(NewAllowlist(allowedQuery))
+	srv.Use(extension.AutomaticPersistedQuery{Cache: apq})
+	// Stop allowed operations before they reach the resolvers
+	srv.Use(DepthLimit{MaxDepth: 1})
+
+	persisted := func(hash string) map[string]any {
+		return map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": hash}}
+	}
+
+	tests := []struct {
+		name     string
+		params   map[string]any
+		wantCode string
+	}{
+		{
+			name:     "allowed query",
+			params:   map[string]any{"query": allowedQuery},
+			wantCode: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			name:     "allowed hash without query",
+			params:   map[string]any{"extensions": persisted(queryHash(allowedQuery))},
+			wantCode: "DEPTH_LIMIT_EXCEEDED",
+		},
+		{
+			name:     "unknown query",
+			params:   map[string]any{"query": `{ myStores { id } }`},
+			wantCode: "OPERATION_NOT_ALLOWED",
+		},
+		{
+			name: "unknown query cannot be registered through APQ",
+			params: map[string]any{
+				"query":      `{ myStores { id } }`,
+				"extensions": persisted(queryHash(`{ myStores { id } }`)),
+			},
+			wantCode: "OPERATION_NOT_ALLOWED",
+		},
+		{
+			name:     "unknown hash",
+			params:   map[string]any{"extensions": persisted(queryHash(`{ myStores { id } }`))},
+			wantCode: "OPERATION_NOT_ALLOWED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.params)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			var resp struct {
+				Errors []struct {
+					Extensions map[string]any `json:"extensions"`
+				} `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.wantCode, resp.Errors[0].Extensions["code"])
+		})
+	}
+
+	_, registered := apq.Get(context.Background(), queryHash(`{ myStores { id } }`))
+	assert.False(t, registered)
+}
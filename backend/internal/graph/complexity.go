@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/graph/model"
+	"github.com/google/uuid"
+)
+
+// Page sizes the resolvers use when a query does not pass first
+const (
+	defaultPetPageSize      = 50
+	defaultStorePageSize    = 20
+	defaultAlertPageSize    = 20
+	defaultDeliveryPageSize = 20
+)
+
+// NewComplexityRoot weighs each paginated connection by the number of items it may return,
+// so a query cannot multiply the work with large first values or aliased fields
+func NewComplexityRoot() ComplexityRoot {
+	var c ComplexityRoot
+
+	c.Query.ListPets = func(childComplexity int, _ uuid.UUID, _ *model.PetFilterInput, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultPetPageSize)
+	}
+	c.Query.SoldPets = func(childComplexity int, _ uuid.UUID, _ time.Time, _ time.Time, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultPetPageSize)
+	}
+	c.Query.UnsoldPets = func(childComplexity int, _ uuid.UUID, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultPetPageSize)
+	}
+	c.Query.AvailablePets = func(childComplexity int, _ *uuid.UUID, _ *model.GeoRadiusInput, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultPetPageSize)
+	}
+	c.Query.StoresNear = func(childComplexity int, _ float64, _ float64, _ float64, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultStorePageSize)
+	}
+	c.Query.InventoryAlerts = func(childComplexity int, _ uuid.UUID, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultAlertPageSize)
+	}
+	c.Query.WebhookDeliveries = func(childComplexity int, _ uuid.UUID, _ *uuid.UUID, _ *model.WebhookDeliveryStatus, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultDeliveryPageSize)
+	}
+	c.Store.Pets = func(childComplexity int, _ *model.PetFilterInput, pagination *model.PaginationInput) int {
+		return connectionComplexity(childComplexity, pagination, defaultPetPageSize)
+	}
+
+	return c
+}
+
+// connectionComplexity is the cost of one connection field: its selection counted once per requested item
+func connectionComplexity(childComplexity int, pagination *model.PaginationInput, defaultPageSize int) int {
+	pageSize := defaultPageSize
+	if pagination != nil && pagination.First != nil && *pagination.First > 0 {
+		pageSize = int(*pagination.First)
+	}
+	return 1 + childComplexity*pageSize
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// DepthLimit rejects operations whose fields are nested deeper than MaxDepth
+type DepthLimit struct {
+	MaxDepth int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+func (d DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d DepthLimit) Validate(graphql.ExecutableSchema) error {
+	if d.MaxDepth <= 0 {
+		return fmt.Errorf("DepthLimit max depth must be positive")
+	}
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if opCtx.Operation == nil {
+		return nil
+	}
+
+	depth := selectionDepth(opCtx.Operation.SelectionSet)
+	if depth > d.MaxDepth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.MaxDepth)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+
+	return nil
+}
+
+// selectionDepth returns how many levels of fields the selection set nests.
+// Fragments add no level of their own; fragment cycles are rejected by validation beforehand.
+func selectionDepth(selections ast.SelectionSet) int {
+	maxDepth := 0
+	for _, selection := range selections {
+		var depth int
+		switch sel := selection.(type) {
+		case *ast.Field:
+			depth = 1 + selectionDepth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				depth = selectionDepth(sel.Definition.SelectionSet)
+			}
+		}
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+	}
+	return maxDepth
+}
@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// Values of extensions.code in GraphQL errors
+const (
+	CodeBadUserInput     = "BAD_USER_INPUT"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeBusinessRule     = "BUSINESS_RULE_VIOLATION"
+	CodePetUnavailable   = "PET_UNAVAILABLE"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeInternal         = "INTERNAL_SERVER_ERROR"
+	internalErrorMessage = "internal server error"
+)
+
+// ErrorPresenter adds extensions.code to resolver errors from internal/errors and the auth package.
+// Any other error is logged and replaced by a generic message, so internals never reach clients.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	// Errors raised by gqlgen itself, such as invalid arguments, are already meant for clients
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) && gqlErr.Unwrap() == nil {
+		return gqlErr
+	}
+
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	var (
+		validationErr   apperrors.ValidationError
+		conflictErr     apperrors.ConflictError
+		businessRuleErr apperrors.BusinessRuleError
+		unavailableErr  apperrors.PetsUnavailableError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		errcode.Set(presented, CodeBadUserInput)
+		presented.Extensions["field"] = validationErr.Field
+	case apperrors.IsNotFound(err):
+		errcode.Set(presented, CodeNotFound)
+	case errors.As(err, &conflictErr):
+		errcode.Set(presented, CodeConflict)
+	case errors.As(err, &unavailableErr):
+		errcode.Set(presented, CodePetUnavailable)
+		presented.Extensions["petIDs"] = unavailableErr.PetIDs
+	case errors.As(err, &businessRuleErr):
+		errcode.Set(presented, CodeBusinessRule)
+	case errors.Is(err, auth.ErrNoUser), errors.Is(err, auth.ErrNoUserType),
+		errors.Is(err, auth.ErrAuthorizationRequired), errors.Is(err, auth.ErrInvalidCredentials):
+		errcode.Set(presented, CodeUnauthenticated)
+	case errors.Is(err, auth.ErrMerchantRequired), errors.Is(err, auth.ErrCustomerRequired):
+		errcode.Set(presented, CodeForbidden)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		presented.Message = "request was cancelled or timed out"
+		presented.Extensions = map[string]any{"code": CodeInternal}
+	default:
+		log.Printf("GraphQL error at %s: %v", presented.Path, err)
+		presented.Message = internalErrorMessage
+		presented.Extensions = map[string]any{"code": CodeInternal}
+	}
+
+	return presented
+}
+
+// Recover turns a resolver panic into an internal error and logs it with its stack
+func Recover(ctx context.Context, panicValue any) error {
+	log.Printf("GraphQL panic at %s: %v\n%s", graphql.GetPath(ctx), panicValue, debug.Stack())
+
+	err := gqlerror.ErrorPathf(graphql.GetPath(ctx), internalErrorMessage)
+	errcode.Set(err, CodeInternal)
+	return err
+}
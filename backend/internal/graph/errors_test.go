@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestErrorPresenter(t *testing.T) {
+	petID := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+
+	tests := []struct {
+		name        string
+		err         error
+		wantMessage string
+		wantCode    string
+		wantExt     map[string]any
+	}{
+		{
+			name:        "validation error carries the field",
+			err:         apperrors.NewValidationError("petIDs", "no pets specified"),
+			wantMessage: "validation error for petIDs: no pets specified",
+			wantCode:    CodeBadUserInput,
+			wantExt:     map[string]any{"field": "petIDs"},
+		},
+		{
+			name:        "pet not found",
+			err:         apperrors.NewPetNotFound(petID),
+			wantMessage: apperrors.NewPetNotFound(petID).Error(),
+			wantCode:    CodeNotFound,
+		},
+		{
+			name:        "generic not found",
+			err:         apperrors.NotFoundError{Resource: "store", ID: "acme"},
+			wantMessage: apperrors.NotFoundError{Resource: "store", ID: "acme"}.Error(),
+			wantCode:    CodeNotFound,
+		},
+		{
+			name:        "conflict",
+			err:         apperrors.ConflictError{Resource: "store", Message: "slug already taken"},
+			wantMessage: apperrors.ConflictError{Resource: "store", Message: "slug already taken"}.Error(),
+			wantCode:    CodeConflict,
+		},
+		{
+			name:        "wrapped business rule keeps the outer message",
+			err:         fmt.Errorf("unable to complete the purchase: %w", apperrors.NewBusinessRuleError("payment declined")),
+			wantMessage: "unable to complete the purchase: business rule violation: payment declined",
+			wantCode:    CodeBusinessRule,
+		},
+		{
+			name:        "unavailable pets are listed",
+			err:         purchaseFailed(apperrors.NewPetsUnavailable([]uuid.UUID{petID}), "sorry, the pet is gone"),
+			wantMessage: "sorry, the pet is gone",
+			wantCode:    CodePetUnavailable,
+			wantExt:     map[string]any{"petIDs": []uuid.UUID{petID}},
+		},
+		{
+			name:        "missing user",
+			err:         auth.ErrNoUser,
+			wantMessage: auth.ErrNoUser.Error(),
+			wantCode:    CodeUnauthenticated,
+		},
+		{
+			name:        "wrong role",
+			err:         auth.ErrMerchantRequired,
+			wantMessage: auth.ErrMerchantRequired.Error(),
+			wantCode:    CodeForbidden,
+		},
+		{
+			name:        "internal errors are masked",
+			err:         fmt.Errorf("unable to complete the purchase: %w", errors.New("pq: connection refused")),
+			wantMessage: "internal server error",
+			wantCode:    CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrorPresenter(context.Background(), tt.err)
+
+			assert.Equal(t, tt.wantMessage, got.Message)
+			assert.Equal(t, tt.wantCode, got.Extensions["code"])
+			for key, value := range tt.wantExt {
+				assert.Equal(t, value, got.Extensions[key])
+			}
+			if tt.wantCode == CodeInternal {
+				assert.Len(t, got.Extensions, 1)
+			}
+		})
+	}
+}
+
+func TestErrorPresenter_KeepsGraphQLErrors(t *testing.T) {
+	err := gqlerror.Errorf("unknown argument")
+	err.Extensions = map[string]any{"code": "GRAPHQL_VALIDATION_FAILED"}
+
+	got := ErrorPresenter(context.Background(), err)
+
+	assert.Same(t, err, got)
+}
+
+func TestRecover(t *testing.T) {
+	ctx := graphql.WithFieldContext(context.Background(), &graphql.FieldContext{})
+
+	err := Recover(ctx, "boom")
+
+	var gqlErr *gqlerror.Error
+	assert.True(t, errors.As(err, &gqlErr))
+	assert.Equal(t, "internal server error", gqlErr.Message)
+	assert.Equal(t, CodeInternal, gqlErr.Extensions["code"])
+}
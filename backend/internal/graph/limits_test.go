@@ -0,0 +1,155 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+)
+
+const petFields = `id name species age status priceCents breederName createdAt`
+
+func newLimitedSchema() graphql.ExecutableSchema {
+	return NewExecutableSchema(Config{Resolvers: &Resolver{}, Complexity: NewComplexityRoot()})
+}
+
+func TestComplexity_Connections(t *testing.T) {
+	es := newLimitedSchema()
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{
+			name:  "default page size",
+			query: `{ availablePets(storeID: "7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e") { edges { node { id name } } } }`,
+			want:  1 + 4*defaultPetPageSize,
+		},
+		{
+			name:  "first sets the page size",
+			query: `{ availablePets(storeID: "7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e", pagination: {first: 10}) { edges { node { id name } } } }`,
+			want:  1 + 4*10,
+		},
+		{
+			name: "aliases add up",
+			query: `{
+				a: listPets(storeID: "7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e", pagination: {first: 100}) { edges { node { id } } }
+				b: listPets(storeID: "7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e", pagination: {first: 100}) { edges { node { id } } }
+			}`,
+			want: 2 * (1 + 3*100),
+		},
+		{
+			name:  "nested store pets are weighed like top-level connections",
+			query: `{ listStores { pets(pagination: {first: 5}) { edges { node { id } } } } }`,
+			want:  1 + 1 + 3*5,
+		},
+		{
+			name:  "unpaginated fields keep the default cost",
+			query: `{ myStores { id name } }`,
+			want:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, errs := gqlparser.LoadQuery(es.Schema(), tt.query)
+			require.Empty(t, errs)
+
+			got := complexity.Calculate(context.Background(), es, doc.Operations[0], nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectionDepth(t *testing.T) {
+	es := newLimitedSchema()
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "flat", query: `{ myStores { id } }`, want: 2},
+		{name: "nested", query: `{ cart { items { pet { sale { orderID } } } } }`, want: 5},
+		{
+			name:  "fragments do not add a level",
+			query: `query { cart { ...cartItems } } fragment cartItems on Cart { items { ... on CartItem { pet { sale { orderID } } } } }`,
+			want:  5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, errs := gqlparser.LoadQuery(es.Schema(), tt.query)
+			require.Empty(t, errs)
+			assert.Equal(t, tt.want, selectionDepth(doc.Operations[0].SelectionSet))
+		})
+	}
+}
+
+func TestLimits_RejectOperations(t *testing.T) {
+	srv := handler.New(newLimitedSchema())
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(1000))
+	srv.Use(DepthLimit{MaxDepth: 4})
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode string
+		wantMsg  string
+	}{
+		{
+			name: "aliased connections over the complexity limit",
+			query: `{
+				a: listPets(storeID: "7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e", pagination: {first: 100}) { edges { node { ` + petFields + ` } } }
+				b: listPets(storeID: "7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e", pagination: {first: 100}) { edges { node { ` + petFields + ` } } }
+			}`,
+			wantCode: "COMPLEXITY_LIMIT_EXCEEDED",
+			wantMsg:  "exceeds the limit of 1000",
+		},
+		{
+			name:     "selection nested too deeply",
+			query:    `{ cart { items { pet { sale { orderID } } } } }`,
+			wantCode: "DEPTH_LIMIT_EXCEEDED",
+			wantMsg:  "operation has depth 5, which exceeds the limit of 4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(map[string]string{"query": tt.query})
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			var resp struct {
+				Data   any `json:"data"`
+				Errors []struct {
+					Message    string         `json:"message"`
+					Extensions map[string]any `json:"extensions"`
+				} `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Len(t, resp.Errors, 1)
+			assert.Nil(t, resp.Data)
+			assert.Contains(t, resp.Errors[0].Message, tt.wantMsg)
+			assert.Equal(t, tt.wantCode, resp.Errors[0].Extensions["code"])
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+)
+
+// ToGlobalID builds the opaque Relay ID of an object from its type name and UUID
+func ToGlobalID(typeName string, id uuid.UUID) string {
+	return base64.StdEncoding.EncodeToString([]byte(typeName + ":" + id.String()))
+}
+
+// FromGlobalID splits an ID built by ToGlobalID back into its type name and UUID
+func FromGlobalID(globalID string) (string, uuid.UUID, error) {
+	decoded, err := base64.StdEncoding.DecodeString(globalID)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("invalid global ID %q", globalID)
+	}
+
+	typeName, rawID, ok := strings.Cut(string(decoded), ":")
+	if !ok || typeName == "" {
+		return "", uuid.Nil, fmt.Errorf("invalid global ID %q", globalID)
+	}
+
+	id, err := uuid.Parse(rawID)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("invalid global ID %q", globalID)
+	}
+
+	return typeName, id, nil
+}
+
+// MarshalUUID writes UUIDs the same way as gqlgen's built-in scalar
+func MarshalUUID(id uuid.UUID) graphql.ContextMarshaler {
+	return graphql.ContextWriterFunc(func(_ context.Context, w io.Writer) error {
+		graphql.MarshalUUID(id).MarshalGQL(w)
+		return nil
+	})
+}
+
+// UnmarshalUUID accepts a raw UUID, or the global ID of the object the argument refers to, so
+// clients can pass Node IDs to UUID arguments. A global ID of another type is rejected rather than
+// silently used as, say, a store ID.
+func UnmarshalUUID(ctx context.Context, v any) (uuid.UUID, error) {
+	if s, ok := v.(string); ok {
+		if id, err := uuid.Parse(s); err == nil {
+			return id, nil
+		}
+		typeName, id, err := FromGlobalID(s)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("%q is neither a UUID nor a global ID", s)
+		}
+		expected := expectedNodeType(ctx)
+		if expected == "" {
+			return uuid.Nil, fmt.Errorf("%q is a global ID; pass the raw UUID instead", s)
+		}
+		if typeName != expected {
+			return uuid.Nil, fmt.Errorf("%q is the global ID of a %s, not of a %s", s, typeName, expected)
+		}
+		return id, nil
+	}
+	return graphql.UnmarshalUUID(v)
+}
+
+// expectedNodeType names the type an argument refers to: storeID and petIDs refer to a Store and
+// a Pet, while a bare id refers to what its field acts on, so the id of deletePet is a Pet's.
+// It is empty outside of a GraphQL operation.
+func expectedNodeType(ctx context.Context) string {
+	var name string
+	for path := graphql.GetPathContext(ctx); path != nil; path = path.Parent {
+		if path.Field != nil {
+			name = *path.Field
+			break
+		}
+	}
+
+	if name == "id" {
+		field := graphql.GetFieldContext(ctx)
+		if field == nil {
+			return ""
+		}
+		name = strings.TrimLeftFunc(field.Field.Name, unicode.IsLower)
+	} else if object, ok := strings.CutSuffix(strings.TrimSuffix(name, "s"), "ID"); ok {
+		name = object
+	} else {
+		return ""
+	}
+
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
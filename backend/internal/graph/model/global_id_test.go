@@ -0,0 +1,87 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestGlobalID_RoundTrip(t *testing.T) {
+	id := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+
+	globalID := ToGlobalID("Pet", id)
+	assert.Equal(t, "UGV0OjdiN2M2ZjBlLTRhMzgtNGY0My05YTNmLTJhNmMxZjFkOWIxZQ==", globalID)
+
+	typeName, decoded, err := FromGlobalID(globalID)
+	require.NoError(t, err)
+	assert.Equal(t, "Pet", typeName)
+	assert.Equal(t, id, decoded)
+}
+
+func TestFromGlobalID_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		globalID string
+	}{
+		{name: "not base64", globalID: "not base64!"},
+		{name: "missing type", globalID: "OjdiN2M2ZjBlLTRhMzgtNGY0My05YTNmLTJhNmMxZjFkOWIxZQ=="},
+		{name: "missing separator", globalID: "UGV0"},
+		{name: "not a uuid", globalID: "UGV0OjEyMw=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := FromGlobalID(tt.globalID)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestUnmarshalUUID(t *testing.T) {
+	id := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+
+	argument := func(field, name string) context.Context {
+		ctx := graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+			Field: graphql.CollectedField{Field: &ast.Field{Name: field}},
+		})
+		return graphql.WithPathContext(ctx, graphql.NewPathWithField(name))
+	}
+	listItem := graphql.WithPathContext(argument("purchasePets", "petIDs"), graphql.NewPathWithIndex(0))
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		value   any
+		want    uuid.UUID
+		wantErr bool
+	}{
+		{name: "raw uuid", ctx: argument("createPet", "storeID"), value: id.String(), want: id},
+		{name: "raw uuid outside an operation", ctx: context.Background(), value: id.String(), want: id},
+		{name: "global id of the argument's type", ctx: argument("createPet", "storeID"), value: ToGlobalID("Store", id), want: id},
+		{name: "global id in a list argument", ctx: listItem, value: ToGlobalID("Pet", id), want: id},
+		{name: "global id of the type a field acts on", ctx: argument("deletePet", "id"), value: ToGlobalID("Pet", id), want: id},
+		{name: "global id of another type", ctx: argument("createPet", "storeID"), value: ToGlobalID("Pet", id), wantErr: true},
+		{name: "global id for another type's id", ctx: argument("deleteStore", "id"), value: ToGlobalID("Pet", id), wantErr: true},
+		{name: "global id for an argument without a node type", ctx: argument("bookPickup", "slotID"), value: ToGlobalID("Order", id), wantErr: true},
+		{name: "global id outside an operation", ctx: context.Background(), value: ToGlobalID("Store", id), wantErr: true},
+		{name: "garbage", ctx: argument("createPet", "storeID"), value: "pet-1", wantErr: true},
+		{name: "not a string", ctx: argument("createPet", "storeID"), value: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalUUID(tt.ctx, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
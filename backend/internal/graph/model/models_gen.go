@@ -12,6 +12,34 @@ import (
 	"github.com/google/uuid"
 )
 
+type Node interface {
+	IsNode()
+	GetID() string
+}
+
+type AgingBucket struct {
+	Label      string  `json:"label"`
+	MinDays    int32   `json:"minDays"`
+	MaxDays    *int32  `json:"maxDays,omitempty"`
+	PetCount   int32   `json:"petCount"`
+	ValueCents float64 `json:"valueCents"`
+}
+
+type Cart struct {
+	ID             uuid.UUID   `json:"id"`
+	Items          []*CartItem `json:"items"`
+	TotalItems     int32       `json:"totalItems"`
+	AvailableItems int32       `json:"availableItems"`
+	TotalCents     int32       `json:"totalCents"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+type CartItem struct {
+	Pet       *Pet      `json:"pet"`
+	Available bool      `json:"available"`
+	AddedAt   time.Time `json:"addedAt"`
+}
+
 type CreatePetInput struct {
 	Name         string     `json:"name"`
 	Species      PetSpecies `json:"species"`
@@ -20,23 +48,131 @@ type CreatePetInput struct {
 	Description  *string    `json:"description,omitempty"`
 	BreederName  string     `json:"breederName"`
 	BreederEmail string     `json:"breederEmail"`
+	PriceCents   *int32     `json:"priceCents,omitempty"`
+}
+
+type CreatePickupSlotsInput struct {
+	Date        time.Time `json:"date"`
+	OpensAt     string    `json:"opensAt"`
+	ClosesAt    string    `json:"closesAt"`
+	SlotMinutes int32     `json:"slotMinutes"`
+	Capacity    int32     `json:"capacity"`
+}
+
+type CreatePromotionInput struct {
+	Code             string       `json:"code"`
+	Description      *string      `json:"description,omitempty"`
+	DiscountType     DiscountType `json:"discountType"`
+	DiscountValue    int32        `json:"discountValue"`
+	Species          *PetSpecies  `json:"species,omitempty"`
+	MinAge           *int32       `json:"minAge,omitempty"`
+	MaxAge           *int32       `json:"maxAge,omitempty"`
+	MinQuantity      *int32       `json:"minQuantity,omitempty"`
+	StartsAt         *time.Time   `json:"startsAt,omitempty"`
+	EndsAt           *time.Time   `json:"endsAt,omitempty"`
+	UsageLimit       *int32       `json:"usageLimit,omitempty"`
+	PerCustomerLimit *int32       `json:"perCustomerLimit,omitempty"`
 }
 
 type CreateStoreInput struct {
 	Name string `json:"name"`
 }
 
-type Mutation struct {
+type CreateWebhookEndpointInput struct {
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
 }
 
-type Order struct {
+type DateRangeInput struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type GeoRadiusInput struct {
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+	RadiusKm float64 `json:"radiusKm"`
+}
+
+type InventoryAging struct {
+	AsOf            time.Time      `json:"asOf"`
+	StaleAfterDays  int32          `json:"staleAfterDays"`
+	Buckets         []*AgingBucket `json:"buckets"`
+	TotalPets       int32          `json:"totalPets"`
+	TotalValueCents float64        `json:"totalValueCents"`
+	StalePets       int32          `json:"stalePets"`
+}
+
+type InventoryAlert struct {
 	ID         uuid.UUID `json:"id"`
-	CustomerID string    `json:"customerID"`
-	Pets       []*Pet    `json:"pets"`
-	TotalPets  int32     `json:"totalPets"`
+	PetID      uuid.UUID `json:"petID"`
+	PetName    string    `json:"petName"`
+	DaysListed int32     `json:"daysListed"`
 	CreatedAt  time.Time `json:"createdAt"`
 }
 
+type InventoryAlertConnection struct {
+	Edges      []*InventoryAlert `json:"edges"`
+	PageInfo   *PageInfo         `json:"pageInfo"`
+	TotalCount int32             `json:"totalCount"`
+}
+
+type Mutation struct {
+}
+
+type NearbyStore struct {
+	Store      *Store  `json:"store"`
+	DistanceKm float64 `json:"distanceKm"`
+}
+
+type NearbyStoreConnection struct {
+	Edges      []*NearbyStore `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int32          `json:"totalCount"`
+}
+
+type OpeningHours struct {
+	Day    Weekday `json:"day"`
+	Opens  string  `json:"opens"`
+	Closes string  `json:"closes"`
+}
+
+type OpeningHoursInput struct {
+	Day    Weekday `json:"day"`
+	Opens  string  `json:"opens"`
+	Closes string  `json:"closes"`
+}
+
+type Order struct {
+	ID            string           `json:"id"`
+	CustomerID    string           `json:"customerID"`
+	Pets          []*Pet           `json:"pets"`
+	TotalPets     int32            `json:"totalPets"`
+	SubtotalCents int32            `json:"subtotalCents"`
+	DiscountCents int32            `json:"discountCents"`
+	TotalCents    int32            `json:"totalCents"`
+	Discounts     []*OrderDiscount `json:"discounts"`
+	PaymentStatus PaymentStatus    `json:"paymentStatus"`
+	Status        OrderStatus      `json:"status"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	StoreID       string           `json:"storeID"`
+	Store         *Store           `json:"store"`
+	// StoreUUID is the store the order was placed at
+	StoreUUID uuid.UUID `json:"-"`
+	// UUID is the order's ID without the global ID encoding
+	UUID uuid.UUID `json:"-"`
+}
+
+func (Order) IsNode()            {}
+func (this Order) GetID() string { return this.ID }
+
+type OrderDiscount struct {
+	Code        string     `json:"code"`
+	Description string     `json:"description"`
+	AmountCents int32      `json:"amountCents"`
+	PetID       *uuid.UUID `json:"petID,omitempty"`
+}
+
 type PageInfo struct {
 	HasNextPage     bool    `json:"hasNextPage"`
 	HasPreviousPage bool    `json:"hasPreviousPage"`
@@ -51,8 +187,12 @@ type PaginationInput struct {
 	Before *string `json:"before,omitempty"`
 }
 
+type PaymentInput struct {
+	CardNumber string `json:"cardNumber"`
+}
+
 type Pet struct {
-	ID           uuid.UUID  `json:"id"`
+	ID           string     `json:"id"`
 	Name         string     `json:"name"`
 	Species      PetSpecies `json:"species"`
 	Age          int32      `json:"age"`
@@ -60,14 +200,36 @@ type Pet struct {
 	Description  *string    `json:"description,omitempty"`
 	BreederName  string     `json:"breederName"`
 	BreederEmail string     `json:"breederEmail"`
+	PriceCents   int32      `json:"priceCents"`
 	Status       PetStatus  `json:"status"`
 	CreatedAt    time.Time  `json:"createdAt"`
+	Sale         *PetSale   `json:"sale,omitempty"`
+	StoreID      string     `json:"storeID"`
+	Store        *Store     `json:"store"`
+	// StoreUUID is the store the pet belongs to
+	StoreUUID uuid.UUID `json:"-"`
+}
+
+func (Pet) IsNode()            {}
+func (this Pet) GetID() string { return this.ID }
+
+type PetAvailabilityChange struct {
+	PetID     string                `json:"petID"`
+	StoreID   string                `json:"storeID"`
+	Available bool                  `json:"available"`
+	Reason    PetAvailabilityReason `json:"reason"`
+	ChangedAt time.Time             `json:"changedAt"`
 }
 
 type PetConnection struct {
-	Edges      []*Pet    `json:"edges"`
-	PageInfo   *PageInfo `json:"pageInfo"`
-	TotalCount int32     `json:"totalCount"`
+	Edges      []*PetEdge `json:"edges"`
+	PageInfo   *PageInfo  `json:"pageInfo"`
+	TotalCount int32      `json:"totalCount"`
+}
+
+type PetEdge struct {
+	Node   *Pet   `json:"node"`
+	Cursor string `json:"cursor"`
 }
 
 type PetFilterInput struct {
@@ -76,13 +238,434 @@ type PetFilterInput struct {
 	EndDate   *time.Time `json:"endDate,omitempty"`
 }
 
+type PetImportError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type PetImportReport struct {
+	DryRun        bool            `json:"dryRun"`
+	TotalRows     int32           `json:"totalRows"`
+	ImportedCount int32           `json:"importedCount"`
+	ErrorCount    int32           `json:"errorCount"`
+	Rows          []*PetImportRow `json:"rows"`
+}
+
+type PetImportRow struct {
+	Line   int32             `json:"line"`
+	Name   string            `json:"name"`
+	PetID  *uuid.UUID        `json:"petID,omitempty"`
+	Errors []*PetImportError `json:"errors"`
+}
+
+type PetSale struct {
+	OrderID     uuid.UUID `json:"orderID"`
+	PurchasedAt time.Time `json:"purchasedAt"`
+	CustomerID  string    `json:"customerID"`
+}
+
+type PickupAppointment struct {
+	ID          uuid.UUID    `json:"id"`
+	OrderID     uuid.UUID    `json:"orderID"`
+	SlotID      uuid.UUID    `json:"slotID"`
+	CustomerID  string       `json:"customerID"`
+	Status      PickupStatus `json:"status"`
+	CreatedAt   time.Time    `json:"createdAt"`
+	CompletedAt *time.Time   `json:"completedAt,omitempty"`
+}
+
+type PickupSlot struct {
+	ID           uuid.UUID            `json:"id"`
+	StartsAt     time.Time            `json:"startsAt"`
+	EndsAt       time.Time            `json:"endsAt"`
+	Capacity     int32                `json:"capacity"`
+	Booked       int32                `json:"booked"`
+	Remaining    int32                `json:"remaining"`
+	Appointments []*PickupAppointment `json:"appointments"`
+}
+
+type Promotion struct {
+	ID               uuid.UUID    `json:"id"`
+	Code             string       `json:"code"`
+	Description      *string      `json:"description,omitempty"`
+	DiscountType     DiscountType `json:"discountType"`
+	DiscountValue    int32        `json:"discountValue"`
+	Species          *PetSpecies  `json:"species,omitempty"`
+	MinAge           *int32       `json:"minAge,omitempty"`
+	MaxAge           *int32       `json:"maxAge,omitempty"`
+	MinQuantity      int32        `json:"minQuantity"`
+	StartsAt         time.Time    `json:"startsAt"`
+	EndsAt           *time.Time   `json:"endsAt,omitempty"`
+	UsageLimit       *int32       `json:"usageLimit,omitempty"`
+	PerCustomerLimit *int32       `json:"perCustomerLimit,omitempty"`
+	TimesUsed        int32        `json:"timesUsed"`
+	Active           bool         `json:"active"`
+	CreatedAt        time.Time    `json:"createdAt"`
+}
+
 type Query struct {
 }
 
+type Receipt struct {
+	OrderID     uuid.UUID     `json:"orderID"`
+	Format      ReceiptFormat `json:"format"`
+	Filename    string        `json:"filename"`
+	ContentType string        `json:"contentType"`
+	Content     string        `json:"content"`
+}
+
+type SalesBucket struct {
+	PeriodStart  *time.Time  `json:"periodStart,omitempty"`
+	Species      *PetSpecies `json:"species,omitempty"`
+	PetsSold     int32       `json:"petsSold"`
+	RevenueCents float64     `json:"revenueCents"`
+}
+
+type SalesReport struct {
+	From               time.Time      `json:"from"`
+	To                 time.Time      `json:"to"`
+	GroupBy            SalesGroupBy   `json:"groupBy"`
+	Buckets            []*SalesBucket `json:"buckets"`
+	TotalPetsSold      int32          `json:"totalPetsSold"`
+	TotalRevenueCents  float64        `json:"totalRevenueCents"`
+	AverageDaysOnShelf *float64       `json:"averageDaysOnShelf,omitempty"`
+	TopSpecies         *PetSpecies    `json:"topSpecies,omitempty"`
+	TopSpeciesPetsSold int32          `json:"topSpeciesPetsSold"`
+}
+
 type Store struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Slug         string          `json:"slug"`
+	Description  *string         `json:"description,omitempty"`
+	Address      *string         `json:"address,omitempty"`
+	Phone        *string         `json:"phone,omitempty"`
+	LogoURL      *string         `json:"logoURL,omitempty"`
+	OpeningHours []*OpeningHours `json:"openingHours"`
+	Open         bool            `json:"open"`
+	ClosedAt     *time.Time      `json:"closedAt,omitempty"`
+	Latitude     *float64        `json:"latitude,omitempty"`
+	Longitude    *float64        `json:"longitude,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	Pets         *PetConnection  `json:"pets"`
+	// UUID is the store's ID without the global ID encoding
+	UUID uuid.UUID `json:"-"`
+}
+
+func (Store) IsNode()            {}
+func (this Store) GetID() string { return this.ID }
+
+type StoreFilterInput struct {
+	Open *bool `json:"open,omitempty"`
+}
+
+type StoreSettings struct {
+	StoreID              uuid.UUID  `json:"storeID"`
+	MaxPetsPerOrder      int32      `json:"maxPetsPerOrder"`
+	MaxPetAge            int32      `json:"maxPetAge"`
+	MaxPageSize          int32      `json:"maxPageSize"`
+	MinCustomerAge       int32      `json:"minCustomerAge"`
+	ReservationHoldHours int32      `json:"reservationHoldHours"`
+	ShowBreederNames     bool       `json:"showBreederNames"`
+	StaleAfterDays       int32      `json:"staleAfterDays"`
+	Timezone             string     `json:"timezone"`
+	UpdatedAt            *time.Time `json:"updatedAt,omitempty"`
+}
+
+type Subscription struct {
+}
+
+type UpdateStoreInput struct {
+	Name         *string              `json:"name,omitempty"`
+	Slug         *string              `json:"slug,omitempty"`
+	Description  *string              `json:"description,omitempty"`
+	Address      *string              `json:"address,omitempty"`
+	Phone        *string              `json:"phone,omitempty"`
+	LogoURL      *string              `json:"logoURL,omitempty"`
+	OpeningHours []*OpeningHoursInput `json:"openingHours,omitempty"`
+	Latitude     *float64             `json:"latitude,omitempty"`
+	Longitude    *float64             `json:"longitude,omitempty"`
+}
+
+type UpdateStoreSettingsInput struct {
+	MaxPetsPerOrder      *int32  `json:"maxPetsPerOrder,omitempty"`
+	MaxPetAge            *int32  `json:"maxPetAge,omitempty"`
+	MaxPageSize          *int32  `json:"maxPageSize,omitempty"`
+	MinCustomerAge       *int32  `json:"minCustomerAge,omitempty"`
+	ReservationHoldHours *int32  `json:"reservationHoldHours,omitempty"`
+	ShowBreederNames     *bool   `json:"showBreederNames,omitempty"`
+	StaleAfterDays       *int32  `json:"staleAfterDays,omitempty"`
+	Timezone             *string `json:"timezone,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id"`
+	EndpointID     uuid.UUID             `json:"endpointID"`
+	EventID        uuid.UUID             `json:"eventID"`
+	EventType      WebhookEventType      `json:"eventType"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	ResponseStatus *int32                `json:"responseStatus,omitempty"`
+	LastError      *string               `json:"lastError,omitempty"`
+	NextAttemptAt  *time.Time            `json:"nextAttemptAt,omitempty"`
+	CreatedAt      time.Time             `json:"createdAt"`
+	DeliveredAt    *time.Time            `json:"deliveredAt,omitempty"`
+	Payload        string                `json:"payload"`
+}
+
+type WebhookDeliveryConnection struct {
+	Edges      []*WebhookDelivery `json:"edges"`
+	PageInfo   *PageInfo          `json:"pageInfo"`
+	TotalCount int32              `json:"totalCount"`
+}
+
+type WebhookEndpoint struct {
+	ID         uuid.UUID          `json:"id"`
+	URL        string             `json:"url"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	Active     bool               `json:"active"`
+	CreatedAt  time.Time          `json:"createdAt"`
+}
+
+type WebhookEndpointRegistration struct {
+	Endpoint *WebhookEndpoint `json:"endpoint"`
+	Secret   string           `json:"secret"`
+}
+
+type DiscountType string
+
+const (
+	DiscountTypePercentage        DiscountType = "percentage"
+	DiscountTypeFixedAmount       DiscountType = "fixed_amount"
+	DiscountTypeNthItemPercentage DiscountType = "nth_item_percentage"
+)
+
+var AllDiscountType = []DiscountType{
+	DiscountTypePercentage,
+	DiscountTypeFixedAmount,
+	DiscountTypeNthItemPercentage,
+}
+
+func (e DiscountType) IsValid() bool {
+	switch e {
+	case DiscountTypePercentage, DiscountTypeFixedAmount, DiscountTypeNthItemPercentage:
+		return true
+	}
+	return false
+}
+
+func (e DiscountType) String() string {
+	return string(e)
+}
+
+func (e *DiscountType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiscountType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiscountType", str)
+	}
+	return nil
+}
+
+func (e DiscountType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DiscountType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DiscountType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusPlaced          OrderStatus = "placed"
+	OrderStatusPickupScheduled OrderStatus = "pickup_scheduled"
+	OrderStatusCompleted       OrderStatus = "completed"
+)
+
+var AllOrderStatus = []OrderStatus{
+	OrderStatusPlaced,
+	OrderStatusPickupScheduled,
+	OrderStatusCompleted,
+}
+
+func (e OrderStatus) IsValid() bool {
+	switch e {
+	case OrderStatusPlaced, OrderStatusPickupScheduled, OrderStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (e OrderStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+func (e OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PaymentStatus string
+
+const (
+	PaymentStatusNone       PaymentStatus = "none"
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+var AllPaymentStatus = []PaymentStatus{
+	PaymentStatusNone,
+	PaymentStatusPending,
+	PaymentStatusAuthorized,
+	PaymentStatusCaptured,
+	PaymentStatusRefunded,
+	PaymentStatusFailed,
+}
+
+func (e PaymentStatus) IsValid() bool {
+	switch e {
+	case PaymentStatusNone, PaymentStatusPending, PaymentStatusAuthorized, PaymentStatusCaptured, PaymentStatusRefunded, PaymentStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e PaymentStatus) String() string {
+	return string(e)
+}
+
+func (e *PaymentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PaymentStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PaymentStatus", str)
+	}
+	return nil
+}
+
+func (e PaymentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PaymentStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PaymentStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PetAvailabilityReason string
+
+const (
+	PetAvailabilityReasonSold     PetAvailabilityReason = "SOLD"
+	PetAvailabilityReasonReserved PetAvailabilityReason = "RESERVED"
+	PetAvailabilityReasonDeleted  PetAvailabilityReason = "DELETED"
+	PetAvailabilityReasonReleased PetAvailabilityReason = "RELEASED"
+)
+
+var AllPetAvailabilityReason = []PetAvailabilityReason{
+	PetAvailabilityReasonSold,
+	PetAvailabilityReasonReserved,
+	PetAvailabilityReasonDeleted,
+	PetAvailabilityReasonReleased,
+}
+
+func (e PetAvailabilityReason) IsValid() bool {
+	switch e {
+	case PetAvailabilityReasonSold, PetAvailabilityReasonReserved, PetAvailabilityReasonDeleted, PetAvailabilityReasonReleased:
+		return true
+	}
+	return false
+}
+
+func (e PetAvailabilityReason) String() string {
+	return string(e)
+}
+
+func (e *PetAvailabilityReason) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PetAvailabilityReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PetAvailabilityReason", str)
+	}
+	return nil
+}
+
+func (e PetAvailabilityReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PetAvailabilityReason) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PetAvailabilityReason) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
 
 type PetSpecies string
@@ -146,17 +729,19 @@ type PetStatus string
 
 const (
 	PetStatusAvailable PetStatus = "available"
+	PetStatusReserved  PetStatus = "reserved"
 	PetStatusSold      PetStatus = "sold"
 )
 
 var AllPetStatus = []PetStatus{
 	PetStatusAvailable,
+	PetStatusReserved,
 	PetStatusSold,
 }
 
 func (e PetStatus) IsValid() bool {
 	switch e {
-	case PetStatusAvailable, PetStatusSold:
+	case PetStatusAvailable, PetStatusReserved, PetStatusSold:
 		return true
 	}
 	return false
@@ -196,3 +781,353 @@ func (e PetStatus) MarshalJSON() ([]byte, error) {
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
+
+type PickupStatus string
+
+const (
+	PickupStatusBooked    PickupStatus = "booked"
+	PickupStatusCompleted PickupStatus = "completed"
+	PickupStatusCancelled PickupStatus = "cancelled"
+)
+
+var AllPickupStatus = []PickupStatus{
+	PickupStatusBooked,
+	PickupStatusCompleted,
+	PickupStatusCancelled,
+}
+
+func (e PickupStatus) IsValid() bool {
+	switch e {
+	case PickupStatusBooked, PickupStatusCompleted, PickupStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func (e PickupStatus) String() string {
+	return string(e)
+}
+
+func (e *PickupStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PickupStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PickupStatus", str)
+	}
+	return nil
+}
+
+func (e PickupStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PickupStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PickupStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReceiptFormat string
+
+const (
+	ReceiptFormatPDF  ReceiptFormat = "PDF"
+	ReceiptFormatHTML ReceiptFormat = "HTML"
+)
+
+var AllReceiptFormat = []ReceiptFormat{
+	ReceiptFormatPDF,
+	ReceiptFormatHTML,
+}
+
+func (e ReceiptFormat) IsValid() bool {
+	switch e {
+	case ReceiptFormatPDF, ReceiptFormatHTML:
+		return true
+	}
+	return false
+}
+
+func (e ReceiptFormat) String() string {
+	return string(e)
+}
+
+func (e *ReceiptFormat) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReceiptFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReceiptFormat", str)
+	}
+	return nil
+}
+
+func (e ReceiptFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReceiptFormat) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReceiptFormat) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SalesGroupBy string
+
+const (
+	SalesGroupByDay     SalesGroupBy = "DAY"
+	SalesGroupByWeek    SalesGroupBy = "WEEK"
+	SalesGroupByMonth   SalesGroupBy = "MONTH"
+	SalesGroupBySpecies SalesGroupBy = "SPECIES"
+)
+
+var AllSalesGroupBy = []SalesGroupBy{
+	SalesGroupByDay,
+	SalesGroupByWeek,
+	SalesGroupByMonth,
+	SalesGroupBySpecies,
+}
+
+func (e SalesGroupBy) IsValid() bool {
+	switch e {
+	case SalesGroupByDay, SalesGroupByWeek, SalesGroupByMonth, SalesGroupBySpecies:
+		return true
+	}
+	return false
+}
+
+func (e SalesGroupBy) String() string {
+	return string(e)
+}
+
+func (e *SalesGroupBy) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SalesGroupBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SalesGroupBy", str)
+	}
+	return nil
+}
+
+func (e SalesGroupBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SalesGroupBy) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SalesGroupBy) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypePetCreated   WebhookEventType = "PET_CREATED"
+	WebhookEventTypePetSold      WebhookEventType = "PET_SOLD"
+	WebhookEventTypeOrderCreated WebhookEventType = "ORDER_CREATED"
+)
+
+var AllWebhookEventType = []WebhookEventType{
+	WebhookEventTypePetCreated,
+	WebhookEventTypePetSold,
+	WebhookEventTypeOrderCreated,
+}
+
+func (e WebhookEventType) IsValid() bool {
+	switch e {
+	case WebhookEventTypePetCreated, WebhookEventTypePetSold, WebhookEventTypeOrderCreated:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventType) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEventType", str)
+	}
+	return nil
+}
+
+func (e WebhookEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookEventType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookEventType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Weekday string
+
+const (
+	WeekdayMonday    Weekday = "monday"
+	WeekdayTuesday   Weekday = "tuesday"
+	WeekdayWednesday Weekday = "wednesday"
+	WeekdayThursday  Weekday = "thursday"
+	WeekdayFriday    Weekday = "friday"
+	WeekdaySaturday  Weekday = "saturday"
+	WeekdaySunday    Weekday = "sunday"
+)
+
+var AllWeekday = []Weekday{
+	WeekdayMonday,
+	WeekdayTuesday,
+	WeekdayWednesday,
+	WeekdayThursday,
+	WeekdayFriday,
+	WeekdaySaturday,
+	WeekdaySunday,
+}
+
+func (e Weekday) IsValid() bool {
+	switch e {
+	case WeekdayMonday, WeekdayTuesday, WeekdayWednesday, WeekdayThursday, WeekdayFriday, WeekdaySaturday, WeekdaySunday:
+		return true
+	}
+	return false
+}
+
+func (e Weekday) String() string {
+	return string(e)
+}
+
+func (e *Weekday) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Weekday(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Weekday", str)
+	}
+	return nil
+}
+
+func (e Weekday) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Weekday) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Weekday) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,73 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// publicQueryFields are the Query fields anonymous clients may select
+var publicQueryFields = map[string]bool{
+	"listStores":    true,
+	"availablePets": true,
+	"storesNear":    true,
+	"node":          true, // checks the viewer for each node type itself
+	"__typename":    true,
+}
+
+// PublicOperations requires an authenticated user for every operation except queries that
+// only select public fields. It runs on the parsed operation, so hash-only persisted queries
+// and GET requests are judged by what they select rather than by the request body.
+type PublicOperations struct{}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = PublicOperations{}
+
+func (p PublicOperations) ExtensionName() string {
+	return "PublicOperations"
+}
+
+func (p PublicOperations) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (p PublicOperations) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if _, err := auth.GetUser(ctx); err == nil {
+		return nil
+	}
+	if opCtx.Operation != nil && opCtx.Operation.Operation == ast.Query && selectsOnlyPublicFields(opCtx.Operation.SelectionSet) {
+		return nil
+	}
+
+	err := gqlerror.Errorf("authorization required")
+	errcode.Set(err, CodeUnauthenticated)
+	return err
+}
+
+// selectsOnlyPublicFields reports whether every root field of the selection set, including
+// those selected through fragments, is in publicQueryFields
+func selectsOnlyPublicFields(selections ast.SelectionSet) bool {
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if !publicQueryFields[sel.Name] {
+				return false
+			}
+		case *ast.InlineFragment:
+			if !selectsOnlyPublicFields(sel.SelectionSet) {
+				return false
+			}
+		case *ast.FragmentSpread:
+			if sel.Definition == nil || !selectsOnlyPublicFields(sel.Definition.SelectionSet) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublicOperations(t *testing.T) {
+	const publicQuery = `{ listStores { id } }`
+	apq := graphql.MapCache[string]{queryHash(publicQuery): publicQuery}
+	srv := handler.New(newLimitedSchema())
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.AutomaticPersistedQuery{Cache: apq})
+	srv.Use(PublicOperations{})
+	// Stop allowed operations before they reach the resolvers
+	srv.Use(DepthLimit{MaxDepth: 1})
+
+	persisted, err := json.Marshal(map[string]any{
+		"persistedQuery": map[string]any{"version": 1, "sha256Hash": queryHash(publicQuery)},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		method        string
+		query         string
+		extensions    string
+		authenticated bool
+		wantCode      string
+	}{
+		{name: "public query", method: http.MethodPost, query: publicQuery, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "public query over GET", method: http.MethodGet, query: publicQuery, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "persisted public query by hash", method: http.MethodPost, extensions: string(persisted), wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "persisted public query by hash over GET", method: http.MethodGet, extensions: string(persisted), wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "public query through a fragment", method: http.MethodPost,
+			query: `query { ...stores } fragment stores on Query { listStores { id } }`, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "node refetch", method: http.MethodPost,
+			query: `{ node(id: "U3RvcmU6N2I3YzZmMGUtNGEzOC00ZjQzLTlhM2YtMmE2YzFmMWQ5YjFl") { id } }`, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+		{name: "private query", method: http.MethodPost, query: `{ myStores { id } }`, wantCode: CodeUnauthenticated},
+		{name: "operation named after a public field", method: http.MethodPost,
+			query: `query listStores { myStores { id } }`, wantCode: CodeUnauthenticated},
+		{name: "public and private fields", method: http.MethodPost,
+			query: `{ listStores { id } myStores { id } }`, wantCode: CodeUnauthenticated},
+		{name: "private field through a fragment", method: http.MethodPost,
+			query: `query { ...stores } fragment stores on Query { myStores { id } }`, wantCode: CodeUnauthenticated},
+		{name: "mutation", method: http.MethodPost, query: `mutation { __typename }`, wantCode: CodeUnauthenticated},
+		{name: "private query with a user", method: http.MethodPost, query: `{ myStores { id } }`,
+			authenticated: true, wantCode: "DEPTH_LIMIT_EXCEEDED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req *http.Request
+			if tt.method == http.MethodGet {
+				params := url.Values{}
+				if tt.query != "" {
+					params.Set("query", tt.query)
+				}
+				if tt.extensions != "" {
+					params.Set("extensions", tt.extensions)
+				}
+				req = httptest.NewRequest(http.MethodGet, "/graphql?"+params.Encode(), nil)
+			} else {
+				params := map[string]any{}
+				if tt.query != "" {
+					params["query"] = tt.query
+				}
+				if tt.extensions != "" {
+					params["extensions"] = json.RawMessage(tt.extensions)
+				}
+				body, err := json.Marshal(params)
+				require.NoError(t, err)
+				req = httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+				req.Header.Set("Content-Type", "application/json")
+			}
+			if tt.authenticated {
+				ctx := context.WithValue(req.Context(), auth.UserContextKey, "merchant1")
+				req = req.WithContext(context.WithValue(ctx, auth.UserTypeContextKey, auth.UserTypeMerchant))
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+
+			var resp struct {
+				Errors []struct {
+					Extensions map[string]any `json:"extensions"`
+				} `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.wantCode, resp.Errors[0].Extensions["code"])
+		})
+	}
+}
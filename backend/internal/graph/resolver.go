@@ -4,29 +4,56 @@ package graph
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/fehepe/pet-store/backend/internal/auth"
+	"github.com/fehepe/pet-store/backend/internal/dataloader"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
 	"github.com/fehepe/pet-store/backend/internal/graph/model"
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/receipt"
 	"github.com/fehepe/pet-store/backend/internal/service"
 	"github.com/google/uuid"
 )
 
 type Resolver struct {
-	storeService *service.StoreService
-	petService   *service.PetService
-	orderService *service.OrderService
+	storeService     *service.StoreService
+	petService       *service.PetService
+	orderService     *service.OrderService
+	cartService      *service.CartService
+	promotionService *service.PromotionService
+	receiptService   *service.ReceiptService
+	pickupService    *service.PickupService
+	settingsService  *service.StoreSettingsService
+	salesService     *service.SalesService
+	inventoryService *service.InventoryService
+	importService    *service.PetImportService
+	petEventService  *service.PetEventService
+	webhookService   *service.WebhookService
 }
 
-func NewResolver(storeService *service.StoreService, petService *service.PetService, orderService *service.OrderService) *Resolver {
+func NewResolver(storeService *service.StoreService, petService *service.PetService, orderService *service.OrderService, cartService *service.CartService, promotionService *service.PromotionService, receiptService *service.ReceiptService, pickupService *service.PickupService, settingsService *service.StoreSettingsService, salesService *service.SalesService, inventoryService *service.InventoryService, importService *service.PetImportService, petEventService *service.PetEventService, webhookService *service.WebhookService) *Resolver {
 	return &Resolver{
-		storeService: storeService,
-		petService:   petService,
-		orderService: orderService,
+		storeService:     storeService,
+		petService:       petService,
+		orderService:     orderService,
+		cartService:      cartService,
+		promotionService: promotionService,
+		receiptService:   receiptService,
+		pickupService:    pickupService,
+		settingsService:  settingsService,
+		salesService:     salesService,
+		inventoryService: inventoryService,
+		importService:    importService,
+		petEventService:  petEventService,
+		webhookService:   webhookService,
 	}
 }
 
@@ -39,32 +66,36 @@ func (r *Resolver) Mutation() MutationResolver {
 	return r
 }
 
-func (r *Resolver) ListPets(ctx context.Context, filter *model.PetFilterInput, pagination *model.PaginationInput) (*model.PetConnection, error) {
-	store, err := r.getStoreForMerchant(ctx)
-	if err != nil {
-		return nil, err
-	}
+func (r *Resolver) Subscription() SubscriptionResolver {
+	return r
+}
 
-	// Build filter
-	petFilter := models.PetFilter{
-		StoreID: &store.ID,
-		Limit:   50, // Default limit
-		Offset:  0,
-	}
+func (r *Resolver) Pet() PetResolver {
+	return &petResolver{r}
+}
 
-	if filter != nil {
-		if filter.Status != nil {
-			status := models.PetStatus(*filter.Status)
-			petFilter.Status = &status
-		}
-		if filter.StartDate != nil {
-			petFilter.StartDate = filter.StartDate
-		}
-		if filter.EndDate != nil {
-			petFilter.EndDate = filter.EndDate
-		}
+func (r *Resolver) Store() StoreResolver {
+	return &storeResolver{r}
+}
+
+func (r *Resolver) Order() OrderResolver {
+	return &orderResolver{r}
+}
+
+// The object resolvers get their own types because Pet.store and Order.store share a method name
+type petResolver struct{ *Resolver }
+
+type storeResolver struct{ *Resolver }
+
+type orderResolver struct{ *Resolver }
+
+func (r *Resolver) ListPets(ctx context.Context, storeID uuid.UUID, filter *model.PetFilterInput, pagination *model.PaginationInput) (*model.PetConnection, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
 	}
 
+	petFilter := petFilterFromInput(store.ID, filter)
 	r.applyPagination(&petFilter, pagination)
 
 	pets, totalCount, err := r.petService.ListPets(ctx, petFilter)
@@ -73,31 +104,16 @@ func (r *Resolver) ListPets(ctx context.Context, filter *model.PetFilterInput, p
 	}
 
 	// Convert to GraphQL types
-	var edges []*model.Pet
+	var nodes []*model.Pet
 	for _, pet := range pets {
-		edges = append(edges, r.petToGraphQLModel(pet, true))
-	}
-
-	hasNextPage := len(pets) == petFilter.Limit
-	endCursor := ""
-	if hasNextPage {
-		endCursor = strconv.Itoa(petFilter.Offset + len(pets))
+		nodes = append(nodes, r.petToGraphQLModel(pet, true))
 	}
 
-	return &model.PetConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			HasNextPage:     hasNextPage,
-			HasPreviousPage: petFilter.Offset > 0,
-			EndCursor:       &endCursor,
-		},
-		TotalCount: int32(totalCount),
-	}, nil
+	return petConnection(nodes, petFilter, totalCount), nil
 }
 
 func (r *Resolver) GetPet(ctx context.Context, id uuid.UUID) (*model.Pet, error) {
-	store, err := r.getStoreForMerchant(ctx)
-	if err != nil {
+	if err := auth.RequireMerchant(ctx); err != nil {
 		return nil, err
 	}
 
@@ -107,8 +123,8 @@ func (r *Resolver) GetPet(ctx context.Context, id uuid.UUID) (*model.Pet, error)
 	}
 
 	// Verify ownership
-	if pet.StoreID != store.ID {
-		return nil, fmt.Errorf("pet not found")
+	if _, err := r.getStoreForMerchant(ctx, pet.StoreID); err != nil {
+		return nil, apperrors.NewPetNotFound(id)
 	}
 
 	// Decrypt email for merchants
@@ -118,7 +134,9 @@ func (r *Resolver) GetPet(ctx context.Context, id uuid.UUID) (*model.Pet, error)
 	}
 
 	return &model.Pet{
-		ID:           pet.ID,
+		ID:           model.ToGlobalID("Pet", pet.ID),
+		StoreID:      model.ToGlobalID("Store", pet.StoreID),
+		StoreUUID:    pet.StoreID,
 		Name:         pet.Name,
 		Species:      model.PetSpecies(pet.Species),
 		Age:          int32(pet.Age),
@@ -126,22 +144,30 @@ func (r *Resolver) GetPet(ctx context.Context, id uuid.UUID) (*model.Pet, error)
 		Description:  pet.Description,
 		BreederName:  pet.BreederName,
 		BreederEmail: decryptedEmail,
+		PriceCents:   int32(pet.PriceCents),
 		Status:       model.PetStatus(pet.Status),
 		CreatedAt:    pet.CreatedAt,
+		Sale:         petSaleToGraphQLModel(pet.Sale),
 	}, nil
 }
 
-func (r *Resolver) AvailablePets(ctx context.Context, storeID uuid.UUID, pagination *model.PaginationInput) (*model.PetConnection, error) {
+func (r *Resolver) AvailablePets(ctx context.Context, storeID *uuid.UUID, near *model.GeoRadiusInput, pagination *model.PaginationInput) (*model.PetConnection, error) {
 	// This is now a public endpoint for demo purposes
+	if storeID == nil && near == nil {
+		return nil, apperrors.NewValidationError("storeID", "either storeID or near is required")
+	}
 
 	// Build filter for available pets only
 	status := models.PetStatusAvailable
 	petFilter := models.PetFilter{
-		StoreID: &storeID,
+		StoreID: storeID,
 		Status:  &status,
-		Limit:   50, // Default limit
+		Limit:   defaultPetPageSize,
 		Offset:  0,
 	}
+	if near != nil {
+		petFilter.Near = &models.GeoRadius{Latitude: near.Lat, Longitude: near.Lng, RadiusKm: near.RadiusKm}
+	}
 
 	r.applyPagination(&petFilter, pagination)
 
@@ -150,62 +176,114 @@ func (r *Resolver) AvailablePets(ctx context.Context, storeID uuid.UUID, paginat
 		return nil, err
 	}
 
-	// Convert to GraphQL types (hide breeder email for customers)
-	var edges []*model.Pet
-	for _, pet := range pets {
-		edges = append(edges, r.petToGraphQLModel(pet, false)) // Hide email for customers
+	nodes, err := r.publicPets(ctx, pets)
+	if err != nil {
+		return nil, err
 	}
 
-	hasNextPage := len(pets) == petFilter.Limit
-	endCursor := ""
-	if hasNextPage {
-		endCursor = strconv.Itoa(petFilter.Offset + len(pets))
+	return petConnection(nodes, petFilter, totalCount), nil
+}
+
+func (r *Resolver) ListStores(ctx context.Context, filter *model.StoreFilterInput) ([]*model.Store, error) {
+	var stores []*models.Store
+	var err error
+	if filter != nil && filter.Open != nil && *filter.Open {
+		stores, err = r.storeService.ListOpenStores(ctx)
+	} else {
+		stores, err = r.storeService.ListAllStores(ctx)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return &model.PetConnection{
+	var result []*model.Store
+	for _, store := range stores {
+		if filter != nil && filter.Open != nil && store.IsOpen() != *filter.Open {
+			continue
+		}
+		result = append(result, storeToGraphQLModel(store))
+	}
+
+	return result, nil
+}
+
+func (r *Resolver) StoresNear(ctx context.Context, lat float64, lng float64, radiusKm float64, pagination *model.PaginationInput) (*model.NearbyStoreConnection, error) {
+	limit, offset := defaultStorePageSize, 0
+	if pagination != nil {
+		if pagination.First != nil {
+			limit = int(*pagination.First)
+		}
+		if pagination.After != nil {
+			offset, _ = strconv.Atoi(*pagination.After)
+		}
+	}
+
+	near := models.GeoRadius{Latitude: lat, Longitude: lng, RadiusKm: radiusKm}
+	stores, totalCount, err := r.storeService.StoresNear(ctx, near, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.NearbyStore, 0, len(stores))
+	for _, nearby := range stores {
+		edges = append(edges, &model.NearbyStore{
+			Store:      storeToGraphQLModel(nearby.Store),
+			DistanceKm: nearby.DistanceKm,
+		})
+	}
+
+	hasNextPage := offset+len(stores) < totalCount
+	endCursor := strconv.Itoa(offset + len(stores))
+
+	return &model.NearbyStoreConnection{
 		Edges: edges,
 		PageInfo: &model.PageInfo{
 			HasNextPage:     hasNextPage,
-			HasPreviousPage: petFilter.Offset > 0,
+			HasPreviousPage: offset > 0,
 			EndCursor:       &endCursor,
 		},
 		TotalCount: int32(totalCount),
 	}, nil
 }
 
-func (r *Resolver) ListStores(ctx context.Context) ([]*model.Store, error) {
-	stores, err := r.storeService.ListAllStores(ctx)
+func (r *Resolver) MyStores(ctx context.Context) ([]*model.Store, error) {
+	if err := auth.RequireMerchant(ctx); err != nil {
+		return nil, err
+	}
+
+	username, err := auth.GetUser(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var result []*model.Store
+	stores, err := r.storeService.ListStoresByOwner(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.Store, 0, len(stores))
 	for _, store := range stores {
-		result = append(result, &model.Store{
-			ID:        store.ID,
-			Name:      store.Name,
-			CreatedAt: store.CreatedAt,
-		})
+		result = append(result, storeToGraphQLModel(store))
 	}
 
 	return result, nil
 }
 
-func (r *Resolver) SoldPets(ctx context.Context, startDate time.Time, endDate time.Time, pagination *model.PaginationInput) (*model.PetConnection, error) {
-	store, err := r.getStoreForMerchant(ctx)
+func (r *Resolver) SoldPets(ctx context.Context, storeID uuid.UUID, startDate time.Time, endDate time.Time, pagination *model.PaginationInput) (*model.PetConnection, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build filter for sold pets within date range
+	// Build filter for pets sold within date range
 	status := models.PetStatusSold
 	petFilter := models.PetFilter{
-		StoreID:   &store.ID,
-		Status:    &status,
-		StartDate: &startDate,
-		EndDate:   &endDate,
-		Limit:     50, // Default limit
-		Offset:    0,
+		StoreID:  &store.ID,
+		Status:   &status,
+		SoldFrom: &startDate,
+		SoldTo:   &endDate,
+		Limit:    defaultPetPageSize,
+		Offset:   0,
 	}
 
 	r.applyPagination(&petFilter, pagination)
@@ -216,30 +294,16 @@ func (r *Resolver) SoldPets(ctx context.Context, startDate time.Time, endDate ti
 	}
 
 	// Convert to GraphQL types
-	var edges []*model.Pet
+	var nodes []*model.Pet
 	for _, pet := range pets {
-		edges = append(edges, r.petToGraphQLModel(pet, true))
-	}
-
-	hasNextPage := len(pets) == petFilter.Limit
-	endCursor := ""
-	if hasNextPage {
-		endCursor = strconv.Itoa(petFilter.Offset + len(pets))
+		nodes = append(nodes, r.petToGraphQLModel(pet, true))
 	}
 
-	return &model.PetConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			HasNextPage:     hasNextPage,
-			HasPreviousPage: petFilter.Offset > 0,
-			EndCursor:       &endCursor,
-		},
-		TotalCount: int32(totalCount),
-	}, nil
+	return petConnection(nodes, petFilter, totalCount), nil
 }
 
-func (r *Resolver) UnsoldPets(ctx context.Context, pagination *model.PaginationInput) (*model.PetConnection, error) {
-	store, err := r.getStoreForMerchant(ctx)
+func (r *Resolver) UnsoldPets(ctx context.Context, storeID uuid.UUID, pagination *model.PaginationInput) (*model.PetConnection, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +313,7 @@ func (r *Resolver) UnsoldPets(ctx context.Context, pagination *model.PaginationI
 	petFilter := models.PetFilter{
 		StoreID: &store.ID,
 		Status:  &status,
-		Limit:   50, // Default limit
+		Limit:   defaultPetPageSize,
 		Offset:  0,
 	}
 
@@ -261,36 +325,26 @@ func (r *Resolver) UnsoldPets(ctx context.Context, pagination *model.PaginationI
 	}
 
 	// Convert to GraphQL types
-	var edges []*model.Pet
+	var nodes []*model.Pet
 	for _, pet := range pets {
-		edges = append(edges, r.petToGraphQLModel(pet, true))
-	}
-
-	hasNextPage := len(pets) == petFilter.Limit
-	endCursor := ""
-	if hasNextPage {
-		endCursor = strconv.Itoa(petFilter.Offset + len(pets))
+		nodes = append(nodes, r.petToGraphQLModel(pet, true))
 	}
 
-	return &model.PetConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			HasNextPage:     hasNextPage,
-			HasPreviousPage: petFilter.Offset > 0,
-			EndCursor:       &endCursor,
-		},
-		TotalCount: int32(totalCount),
-	}, nil
+	return petConnection(nodes, petFilter, totalCount), nil
 }
 
 // Mutation resolvers
 
-func (r *Resolver) CreatePet(ctx context.Context, input model.CreatePetInput) (*model.Pet, error) {
-	store, err := r.getStoreForMerchant(ctx)
+func (r *Resolver) CreatePet(ctx context.Context, storeID uuid.UUID, input model.CreatePetInput) (*model.Pet, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
 	if err != nil {
 		return nil, err
 	}
 
+	if !store.IsOpen() {
+		return nil, apperrors.NewBusinessRuleError("pets cannot be added to a closed store")
+	}
+
 	createInput := models.CreatePetInput{
 		StoreID:      store.ID,
 		Name:         input.Name,
@@ -301,6 +355,9 @@ func (r *Resolver) CreatePet(ctx context.Context, input model.CreatePetInput) (*
 		BreederName:  input.BreederName,
 		BreederEmail: input.BreederEmail,
 	}
+	if input.PriceCents != nil {
+		createInput.PriceCents = int64(*input.PriceCents)
+	}
 
 	pet, err := r.petService.CreatePet(ctx, createInput)
 	if err != nil {
@@ -308,7 +365,9 @@ func (r *Resolver) CreatePet(ctx context.Context, input model.CreatePetInput) (*
 	}
 
 	return &model.Pet{
-		ID:           pet.ID,
+		ID:           model.ToGlobalID("Pet", pet.ID),
+		StoreID:      model.ToGlobalID("Store", pet.StoreID),
+		StoreUUID:    pet.StoreID,
 		Name:         pet.Name,
 		Species:      model.PetSpecies(pet.Species),
 		Age:          int32(pet.Age),
@@ -316,14 +375,52 @@ func (r *Resolver) CreatePet(ctx context.Context, input model.CreatePetInput) (*
 		Description:  pet.Description,
 		BreederName:  pet.BreederName,
 		BreederEmail: input.BreederEmail, // Return original email
+		PriceCents:   int32(pet.PriceCents),
 		Status:       model.PetStatus(pet.Status),
 		CreatedAt:    pet.CreatedAt,
 	}, nil
 }
 
-func (r *Resolver) DeletePet(ctx context.Context, id uuid.UUID) (bool, error) {
-	store, err := r.getStoreForMerchant(ctx)
+func (r *Resolver) ImportPets(ctx context.Context, storeID uuid.UUID, file graphql.Upload, dryRun *bool) (*model.PetImportReport, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !store.IsOpen() {
+		return nil, apperrors.NewBusinessRuleError("pets cannot be added to a closed store")
+	}
+
+	report, err := r.importService.ImportPets(ctx, store.ID, file.File, dryRun != nil && *dryRun)
 	if err != nil {
+		return nil, err
+	}
+
+	result := &model.PetImportReport{
+		DryRun:        report.DryRun,
+		TotalRows:     int32(report.TotalRows),
+		ImportedCount: int32(report.ImportedCount),
+		ErrorCount:    int32(report.ErrorCount),
+		Rows:          make([]*model.PetImportRow, 0, len(report.Rows)),
+	}
+	for _, row := range report.Rows {
+		importRow := &model.PetImportRow{
+			Line:   int32(row.Line),
+			Name:   row.Name,
+			PetID:  row.PetID,
+			Errors: make([]*model.PetImportError, 0, len(row.Errors)),
+		}
+		for _, rowErr := range row.Errors {
+			importRow.Errors = append(importRow.Errors, &model.PetImportError{Field: rowErr.Field, Message: rowErr.Message})
+		}
+		result.Rows = append(result.Rows, importRow)
+	}
+
+	return result, nil
+}
+
+func (r *Resolver) DeletePet(ctx context.Context, id uuid.UUID) (bool, error) {
+	if err := auth.RequireMerchant(ctx); err != nil {
 		return false, err
 	}
 
@@ -333,8 +430,8 @@ func (r *Resolver) DeletePet(ctx context.Context, id uuid.UUID) (bool, error) {
 		return false, err
 	}
 
-	if pet.StoreID != store.ID {
-		return false, fmt.Errorf("pet not found")
+	if _, err := r.getStoreForMerchant(ctx, pet.StoreID); err != nil {
+		return false, apperrors.NewPetNotFound(id)
 	}
 
 	err = r.petService.DeletePetByID(ctx, id)
@@ -345,7 +442,7 @@ func (r *Resolver) DeletePet(ctx context.Context, id uuid.UUID) (bool, error) {
 	return true, nil
 }
 
-func (r *Resolver) PurchasePet(ctx context.Context, petID uuid.UUID) (*model.Order, error) {
+func (r *Resolver) PurchasePet(ctx context.Context, petID uuid.UUID, payment *model.PaymentInput, discountCode *string, customerAge *int32) (*model.Order, error) {
 	if err := auth.RequireCustomer(ctx); err != nil {
 		return nil, err
 	}
@@ -362,39 +459,21 @@ func (r *Resolver) PurchasePet(ctx context.Context, petID uuid.UUID) (*model.Ord
 	}
 
 	order, err := r.orderService.CreateOrder(ctx, models.CreateOrderInput{
-		CustomerID: username,
-		StoreID:    pet.StoreID,
-		PetIDs:     []uuid.UUID{petID},
+		CustomerID:   username,
+		StoreID:      pet.StoreID,
+		PetIDs:       []uuid.UUID{petID},
+		CardNumber:   cardNumber(payment),
+		DiscountCode: optionalString(discountCode),
+		CustomerAge:  intPtr(customerAge),
 	})
 	if err != nil {
-		// Check if it's a pet availability error
-		if strings.Contains(err.Error(), "no longer available") {
-			return nil, fmt.Errorf("sorry, the pet '%s' is no longer available for purchase. It may have been purchased by another customer", pet.Name)
-		}
-		return nil, fmt.Errorf("unable to complete the purchase: %v", err)
-	}
-
-	// Get pets for the order
-	pets, err := r.orderService.GetOrderPets(ctx, order.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelPets []*model.Pet
-	for _, pet := range pets {
-		modelPets = append(modelPets, r.petToGraphQLModel(pet, false))
+		return nil, purchaseFailed(err, fmt.Sprintf("sorry, the pet '%s' is no longer available for purchase. It may have been purchased by another customer", pet.Name))
 	}
 
-	return &model.Order{
-		ID:         order.ID,
-		CustomerID: order.CustomerID,
-		Pets:       modelPets,
-		TotalPets:  int32(order.TotalPets),
-		CreatedAt:  order.CreatedAt,
-	}, nil
+	return orderToGraphQLModel(order), nil
 }
 
-func (r *Resolver) PurchasePets(ctx context.Context, petIDs []uuid.UUID) (*model.Order, error) {
+func (r *Resolver) PurchasePets(ctx context.Context, petIDs []uuid.UUID, payment *model.PaymentInput, discountCode *string, customerAge *int32) (*model.Order, error) {
 	if err := auth.RequireCustomer(ctx); err != nil {
 		return nil, err
 	}
@@ -405,7 +484,7 @@ func (r *Resolver) PurchasePets(ctx context.Context, petIDs []uuid.UUID) (*model
 	}
 
 	if len(petIDs) == 0 {
-		return nil, fmt.Errorf("no pets specified")
+		return nil, apperrors.NewValidationError("petIDs", "no pets specified")
 	}
 
 	// Get the first pet to find the store (all pets should be from same store)
@@ -415,36 +494,18 @@ func (r *Resolver) PurchasePets(ctx context.Context, petIDs []uuid.UUID) (*model
 	}
 
 	order, err := r.orderService.CreateOrder(ctx, models.CreateOrderInput{
-		CustomerID: username,
-		StoreID:    firstPet.StoreID,
-		PetIDs:     petIDs,
+		CustomerID:   username,
+		StoreID:      firstPet.StoreID,
+		PetIDs:       petIDs,
+		CardNumber:   cardNumber(payment),
+		DiscountCode: optionalString(discountCode),
+		CustomerAge:  intPtr(customerAge),
 	})
 	if err != nil {
-		// Check if it's a pet availability error
-		if strings.Contains(err.Error(), "no longer available") {
-			return nil, fmt.Errorf("some pets in your cart are no longer available for purchase. They may have been purchased by other customers. %v", err)
-		}
-		return nil, fmt.Errorf("unable to complete the purchase: %v", err)
-	}
-
-	// Get pets for the order
-	pets, err := r.orderService.GetOrderPets(ctx, order.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	var modelPets []*model.Pet
-	for _, pet := range pets {
-		modelPets = append(modelPets, r.petToGraphQLModel(pet, false))
+		return nil, purchaseFailed(err, fmt.Sprintf("some pets in your cart are no longer available for purchase. They may have been purchased by other customers. %v", err))
 	}
 
-	return &model.Order{
-		ID:         order.ID,
-		CustomerID: order.CustomerID,
-		Pets:       modelPets,
-		TotalPets:  int32(order.TotalPets),
-		CreatedAt:  order.CreatedAt,
-	}, nil
+	return orderToGraphQLModel(order), nil
 }
 
 func (r *Resolver) CreateStore(ctx context.Context, input model.CreateStoreInput) (*model.Store, error) {
@@ -467,53 +528,1308 @@ func (r *Resolver) CreateStore(ctx context.Context, input model.CreateStoreInput
 		return nil, err
 	}
 
-	return &model.Store{
-		ID:        store.ID,
-		Name:      store.Name,
-		CreatedAt: store.CreatedAt,
-	}, nil
+	return storeToGraphQLModel(store), nil
 }
 
-// Helper method to convert models.Pet to model.Pet with email handling
-func (r *Resolver) petToGraphQLModel(pet *models.Pet, showEmail bool) *model.Pet {
-	var breederEmail string
-	if showEmail {
-		// Decrypt email for merchants
-		if decrypted, err := r.petService.DecryptBreederEmail(pet.BreederEmailEncrypted); err == nil {
-			breederEmail = decrypted
-		} else {
-			breederEmail = "[Hidden]"
+func (r *Resolver) UpdateStore(ctx context.Context, id uuid.UUID, input model.UpdateStoreInput) (*model.Store, error) {
+	if err := auth.RequireMerchant(ctx); err != nil {
+		return nil, err
+	}
+
+	username, err := auth.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updateInput := models.UpdateStoreInput{
+		Name:        input.Name,
+		Slug:        input.Slug,
+		Description: input.Description,
+		Address:     input.Address,
+		Phone:       input.Phone,
+		LogoURL:     input.LogoURL,
+		Latitude:    input.Latitude,
+		Longitude:   input.Longitude,
+	}
+	if input.OpeningHours != nil {
+		updateInput.OpeningHours = []models.OpeningHours{}
+		for _, hours := range input.OpeningHours {
+			updateInput.OpeningHours = append(updateInput.OpeningHours, models.OpeningHours{
+				Day:    models.Weekday(hours.Day),
+				Opens:  hours.Opens,
+				Closes: hours.Closes,
+			})
 		}
-	} else {
-		breederEmail = "[Hidden]"
 	}
 
-	return &model.Pet{
-		ID:           pet.ID,
-		Name:         pet.Name,
-		Species:      model.PetSpecies(pet.Species),
-		Age:          int32(pet.Age),
-		PictureURL:   pet.PictureURL,
-		Description:  pet.Description,
-		BreederName:  pet.BreederName,
-		BreederEmail: breederEmail,
-		Status:       model.PetStatus(pet.Status),
-		CreatedAt:    pet.CreatedAt,
+	store, err := r.storeService.UpdateStore(ctx, username, id, updateInput)
+	if err != nil {
+		return nil, err
 	}
+
+	return storeToGraphQLModel(store), nil
 }
 
-// Helper method to get store for authenticated merchant
-func (r *Resolver) getStoreForMerchant(ctx context.Context) (*models.Store, error) {
+func (r *Resolver) DeleteStore(ctx context.Context, id uuid.UUID) (bool, error) {
 	if err := auth.RequireMerchant(ctx); err != nil {
-		return nil, err
+		return false, err
 	}
 
 	username, err := auth.GetUser(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.storeService.CloseStore(ctx, username, id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Resolver) Cart(ctx context.Context) (*model.Cart, error) {
+	username, err := r.getCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := r.cartService.GetCart(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.cartToGraphQLModel(ctx, cart)
+}
+
+func (r *Resolver) AddToCart(ctx context.Context, petID uuid.UUID) (*model.Cart, error) {
+	username, err := r.getCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := r.cartService.AddToCart(ctx, username, petID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.cartToGraphQLModel(ctx, cart)
+}
+
+func (r *Resolver) RemoveFromCart(ctx context.Context, petID uuid.UUID) (*model.Cart, error) {
+	username, err := r.getCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := r.cartService.RemoveFromCart(ctx, username, petID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.cartToGraphQLModel(ctx, cart)
+}
+
+func (r *Resolver) CheckoutCart(ctx context.Context, payment *model.PaymentInput, discountCode *string, customerAge *int32) (*model.Order, error) {
+	username, err := r.getCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := r.cartService.CheckoutCart(ctx, username, cardNumber(payment), optionalString(discountCode), intPtr(customerAge))
+	if err != nil {
+		return nil, purchaseFailed(err, fmt.Sprintf("some pets in your cart are no longer available for purchase. They may have been purchased by other customers. %v", err))
+	}
+
+	return orderToGraphQLModel(order), nil
+}
+
+func (r *Resolver) StoreSettings(ctx context.Context, storeID uuid.UUID) (*model.StoreSettings, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := r.settingsService.GetStoreSettings(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return storeSettingsToGraphQLModel(settings), nil
+}
+
+func (r *Resolver) SalesReport(ctx context.Context, storeID uuid.UUID, rangeArg model.DateRangeInput, groupBy model.SalesGroupBy) (*model.SalesReport, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
 	if err != nil {
 		return nil, err
 	}
 
-	return r.storeService.GetStoreByOwnerID(ctx, username)
+	report, err := r.salesService.SalesReport(ctx, models.SalesReportInput{
+		StoreID: store.ID,
+		From:    rangeArg.From,
+		To:      rangeArg.To,
+		GroupBy: models.SalesGroupBy(strings.ToLower(string(groupBy))),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.SalesReport{
+		From:               report.From,
+		To:                 report.To,
+		GroupBy:            groupBy,
+		Buckets:            []*model.SalesBucket{},
+		TotalPetsSold:      int32(report.PetsSold),
+		TotalRevenueCents:  float64(report.RevenueCents),
+		AverageDaysOnShelf: report.AverageDaysOnShelf,
+		TopSpeciesPetsSold: int32(report.TopSpeciesPetsSold),
+	}
+	if report.TopSpecies != nil {
+		species := model.PetSpecies(*report.TopSpecies)
+		result.TopSpecies = &species
+	}
+	for _, bucket := range report.Buckets {
+		salesBucket := &model.SalesBucket{
+			PeriodStart:  bucket.PeriodStart,
+			PetsSold:     int32(bucket.PetsSold),
+			RevenueCents: float64(bucket.RevenueCents),
+		}
+		if bucket.Species != nil {
+			species := model.PetSpecies(*bucket.Species)
+			salesBucket.Species = &species
+		}
+		result.Buckets = append(result.Buckets, salesBucket)
+	}
+
+	return result, nil
+}
+
+func (r *Resolver) InventoryAging(ctx context.Context, storeID uuid.UUID) (*model.InventoryAging, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	aging, err := r.inventoryService.InventoryAging(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.InventoryAging{
+		AsOf:            aging.AsOf,
+		StaleAfterDays:  int32(aging.StaleAfterDays),
+		Buckets:         make([]*model.AgingBucket, 0, len(aging.Buckets)),
+		TotalPets:       int32(aging.TotalPets),
+		TotalValueCents: float64(aging.TotalValueCents),
+		StalePets:       int32(aging.StalePets),
+	}
+	for _, bucket := range aging.Buckets {
+		agingBucket := &model.AgingBucket{
+			Label:      bucket.Label,
+			MinDays:    int32(bucket.MinDays),
+			PetCount:   int32(bucket.PetCount),
+			ValueCents: float64(bucket.ValueCents),
+		}
+		if bucket.MaxDays != nil {
+			maxDays := int32(*bucket.MaxDays)
+			agingBucket.MaxDays = &maxDays
+		}
+		result.Buckets = append(result.Buckets, agingBucket)
+	}
+
+	return result, nil
+}
+
+func (r *Resolver) InventoryAlerts(ctx context.Context, storeID uuid.UUID, pagination *model.PaginationInput) (*model.InventoryAlertConnection, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := defaultAlertPageSize, 0
+	if pagination != nil {
+		if pagination.First != nil {
+			limit = int(*pagination.First)
+		}
+		if pagination.After != nil {
+			offset, _ = strconv.Atoi(*pagination.After)
+		}
+	}
+
+	alerts, totalCount, err := r.inventoryService.InventoryAlerts(ctx, store.ID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.InventoryAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		edges = append(edges, &model.InventoryAlert{
+			ID:         alert.ID,
+			PetID:      alert.PetID,
+			PetName:    alert.PetName,
+			DaysListed: int32(alert.DaysListed),
+			CreatedAt:  alert.CreatedAt,
+		})
+	}
+
+	hasNextPage := offset+len(alerts) < totalCount
+	endCursor := strconv.Itoa(offset + len(alerts))
+
+	return &model.InventoryAlertConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     hasNextPage,
+			HasPreviousPage: offset > 0,
+			EndCursor:       &endCursor,
+		},
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+func (r *Resolver) UpdateStoreSettings(ctx context.Context, storeID uuid.UUID, input model.UpdateStoreSettingsInput) (*model.StoreSettings, error) {
+	if err := auth.RequireMerchant(ctx); err != nil {
+		return nil, err
+	}
+
+	username, err := auth.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := r.settingsService.UpdateStoreSettings(ctx, username, storeID, models.UpdateStoreSettingsInput{
+		MaxPetsPerOrder:      intPtr(input.MaxPetsPerOrder),
+		MaxPetAge:            intPtr(input.MaxPetAge),
+		MaxPageSize:          intPtr(input.MaxPageSize),
+		MinCustomerAge:       intPtr(input.MinCustomerAge),
+		ReservationHoldHours: intPtr(input.ReservationHoldHours),
+		ShowBreederNames:     input.ShowBreederNames,
+		StaleAfterDays:       intPtr(input.StaleAfterDays),
+		Timezone:             input.Timezone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return storeSettingsToGraphQLModel(settings), nil
+}
+
+func (r *Resolver) Promotions(ctx context.Context, storeID uuid.UUID) ([]*model.Promotion, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	promotions, err := r.promotionService.ListPromotions(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*model.Promotion
+	for _, promotion := range promotions {
+		result = append(result, promotionToGraphQLModel(promotion))
+	}
+
+	return result, nil
+}
+
+func (r *Resolver) CreatePromotion(ctx context.Context, storeID uuid.UUID, input model.CreatePromotionInput) (*model.Promotion, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	createInput := models.CreatePromotionInput{
+		StoreID:          store.ID,
+		Code:             input.Code,
+		Description:      input.Description,
+		DiscountType:     models.DiscountType(input.DiscountType),
+		DiscountValue:    int64(input.DiscountValue),
+		MinAge:           intPtr(input.MinAge),
+		MaxAge:           intPtr(input.MaxAge),
+		StartsAt:         input.StartsAt,
+		EndsAt:           input.EndsAt,
+		UsageLimit:       intPtr(input.UsageLimit),
+		PerCustomerLimit: intPtr(input.PerCustomerLimit),
+	}
+	if input.Species != nil {
+		species := models.PetSpecies(*input.Species)
+		createInput.Species = &species
+	}
+	if input.MinQuantity != nil {
+		createInput.MinQuantity = int(*input.MinQuantity)
+	}
+
+	promotion, err := r.promotionService.CreatePromotion(ctx, createInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return promotionToGraphQLModel(promotion), nil
+}
+
+func (r *Resolver) SetPromotionActive(ctx context.Context, storeID uuid.UUID, id uuid.UUID, active bool) (bool, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.promotionService.SetPromotionActive(ctx, store.ID, id, active); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Resolver) WebhookEndpoints(ctx context.Context, storeID uuid.UUID) ([]*model.WebhookEndpoint, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := r.webhookService.ListEndpoints(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.WebhookEndpoint, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result = append(result, webhookEndpointToGraphQLModel(endpoint))
+	}
+	return result, nil
+}
+
+func (r *Resolver) WebhookDeliveries(ctx context.Context, storeID uuid.UUID, endpointID *uuid.UUID, status *model.WebhookDeliveryStatus, pagination *model.PaginationInput) (*model.WebhookDeliveryConnection, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.WebhookDeliveryFilter{
+		StoreID:    store.ID,
+		EndpointID: endpointID,
+		Limit:      defaultDeliveryPageSize,
+	}
+	if status != nil {
+		deliveryStatus := models.WebhookDeliveryStatus(*status)
+		filter.Status = &deliveryStatus
+	}
+	if pagination != nil {
+		if pagination.First != nil {
+			filter.Limit = int(*pagination.First)
+		}
+		if pagination.After != nil {
+			filter.Offset, _ = strconv.Atoi(*pagination.After)
+		}
+	}
+
+	deliveries, totalCount, err := r.webhookService.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		edges = append(edges, webhookDeliveryToGraphQLModel(delivery))
+	}
+
+	hasNextPage := filter.Offset+len(deliveries) < totalCount
+	endCursor := strconv.Itoa(filter.Offset + len(deliveries))
+
+	return &model.WebhookDeliveryConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage:     hasNextPage,
+			HasPreviousPage: filter.Offset > 0,
+			EndCursor:       &endCursor,
+		},
+		TotalCount: int32(totalCount),
+	}, nil
+}
+
+func (r *Resolver) CreateWebhookEndpoint(ctx context.Context, storeID uuid.UUID, input model.CreateWebhookEndpointInput) (*model.WebhookEndpointRegistration, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	createInput := models.CreateWebhookEndpointInput{
+		StoreID: store.ID,
+		URL:     input.URL,
+	}
+	for _, eventType := range input.EventTypes {
+		createInput.EventTypes = append(createInput.EventTypes, webhookEventTypeFromGraphQL(eventType))
+	}
+
+	endpoint, secret, err := r.webhookService.CreateEndpoint(ctx, createInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.WebhookEndpointRegistration{
+		Endpoint: webhookEndpointToGraphQLModel(endpoint),
+		Secret:   secret,
+	}, nil
+}
+
+func (r *Resolver) DeleteWebhookEndpoint(ctx context.Context, storeID uuid.UUID, id uuid.UUID) (bool, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.webhookService.DeleteEndpoint(ctx, store.ID, id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Store resolves the store a pet belongs to
+func (r *petResolver) Store(ctx context.Context, obj *model.Pet) (*model.Store, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	store, err := loaders.Stores.Load(ctx, obj.StoreUUID)
+	if err != nil {
+		return nil, err
+	}
+	return storeToGraphQLModel(store), nil
+}
+
+// Pets lists a store's pets: everything for its merchant, as in listPets, and only available pets
+// for everyone else, as in availablePets
+func (r *storeResolver) Pets(ctx context.Context, obj *model.Store, filter *model.PetFilterInput, pagination *model.PaginationInput) (*model.PetConnection, error) {
+	owner, err := r.ownsStore(ctx, obj.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	petFilter := petFilterFromInput(obj.UUID, filter)
+	if !owner {
+		available := models.PetStatusAvailable
+		if petFilter.Status != nil && *petFilter.Status != available {
+			return petConnection(nil, petFilter, 0), nil
+		}
+		petFilter.Status = &available
+	}
+	r.applyPagination(&petFilter, pagination)
+
+	pets, totalCount, err := r.petService.ListPets(ctx, petFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodes []*model.Pet
+	if owner {
+		for _, pet := range pets {
+			nodes = append(nodes, r.petToGraphQLModel(pet, true))
+		}
+	} else if nodes, err = r.publicPets(ctx, pets); err != nil {
+		return nil, err
+	}
+
+	return petConnection(nodes, petFilter, totalCount), nil
+}
+
+// Store resolves the store an order was placed at
+func (r *orderResolver) Store(ctx context.Context, obj *model.Order) (*model.Store, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	store, err := loaders.Stores.Load(ctx, obj.StoreUUID)
+	if err != nil {
+		return nil, err
+	}
+	return storeToGraphQLModel(store), nil
+}
+
+// Pets are batched across all orders of an operation. Customers see breeder names only where
+// the store shows them, like in availablePets; the merchant who owns the store always does.
+func (r *orderResolver) Pets(ctx context.Context, obj *model.Order) ([]*model.Pet, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pets, err := loaders.OrderPets.Load(ctx, obj.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := r.ownsStore(ctx, obj.StoreUUID)
+	if err != nil {
+		return nil, err
+	}
+	if !owner {
+		nodes, err := r.publicPets(ctx, pets)
+		if err != nil {
+			return nil, err
+		}
+		return append([]*model.Pet{}, nodes...), nil
+	}
+
+	result := make([]*model.Pet, 0, len(pets))
+	for _, pet := range pets {
+		result = append(result, r.petToGraphQLModel(pet, false))
+	}
+	return result, nil
+}
+
+// Discounts are batched across all orders of an operation
+func (r *orderResolver) Discounts(ctx context.Context, obj *model.Order) ([]*model.OrderDiscount, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := loaders.OrderDiscounts.Load(ctx, obj.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*model.OrderDiscount, 0, len(discounts))
+	for _, discount := range discounts {
+		result = append(result, &model.OrderDiscount{
+			Code:        discount.Code,
+			Description: discount.Description,
+			AmountCents: int32(discount.AmountCents),
+			PetID:       discount.PetID,
+		})
+	}
+	return result, nil
+}
+
+// Node refetches an object by its global ID. Objects that do not exist or that the viewer
+// may not see resolve to null, as the Relay spec expects. Stores and available pets are public
+// like listStores and availablePets; orders need a signed-in viewer.
+func (r *Resolver) Node(ctx context.Context, id string) (model.Node, error) {
+	userType, _ := auth.GetUserType(ctx) // empty for anonymous viewers
+
+	typeName, objectID, err := model.FromGlobalID(id)
+	if err != nil {
+		return nil, apperrors.NewValidationError("id", err.Error())
+	}
+
+	var node model.Node
+	switch typeName {
+	case "Pet":
+		node, err = r.petNode(ctx, objectID, userType)
+	case "Store":
+		node, err = r.storeNode(ctx, objectID)
+	case "Order":
+		if userType == "" {
+			return nil, auth.ErrAuthorizationRequired
+		}
+		node, err = r.orderNode(ctx, objectID, userType)
+	default:
+		return nil, apperrors.NewValidationError("id", fmt.Sprintf("unknown node type %q", typeName))
+	}
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return node, err
+}
+
+func (r *Resolver) OrderReceipt(ctx context.Context, orderID uuid.UUID, format *model.ReceiptFormat) (*model.Receipt, error) {
+	username, err := auth.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userType, err := auth.GetUserType(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	receiptFormat := model.ReceiptFormatHTML
+	if format != nil {
+		receiptFormat = *format
+	}
+
+	parsed, err := receipt.ParseFormat(string(receiptFormat))
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := r.receiptService.GetOrderReceipt(ctx, orderID, username, userType == auth.UserTypeMerchant, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	content := string(doc.Content)
+	if parsed == receipt.FormatPDF {
+		content = base64.StdEncoding.EncodeToString(doc.Content)
+	}
+
+	return &model.Receipt{
+		OrderID:     orderID,
+		Format:      receiptFormat,
+		Filename:    doc.Filename,
+		ContentType: doc.ContentType,
+		Content:     content,
+	}, nil
+}
+
+func (r *Resolver) CreatePickupSlots(ctx context.Context, storeID uuid.UUID, input model.CreatePickupSlotsInput) ([]*model.PickupSlot, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := r.pickupService.CreatePickupSlots(ctx, models.CreatePickupSlotsInput{
+		StoreID:     store.ID,
+		Date:        input.Date,
+		OpensAt:     input.OpensAt,
+		ClosesAt:    input.ClosesAt,
+		SlotMinutes: int(input.SlotMinutes),
+		Capacity:    int(input.Capacity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pickupSlotsToGraphQLModel(slots), nil
+}
+
+func (r *Resolver) PickupSchedule(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*model.PickupSlot, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := r.pickupService.PickupSchedule(ctx, store.ID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return pickupSlotsToGraphQLModel(slots), nil
+}
+
+func (r *Resolver) CompletePickup(ctx context.Context, storeID uuid.UUID, orderID uuid.UUID) (*model.Order, error) {
+	store, err := r.getStoreForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := r.pickupService.CompletePickup(ctx, store.ID, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderToGraphQLModel(order), nil
+}
+
+func (r *Resolver) AvailablePickupSlots(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*model.PickupSlot, error) {
+	if _, err := r.getCustomer(ctx); err != nil {
+		return nil, err
+	}
+
+	slots, err := r.pickupService.AvailablePickupSlots(ctx, storeID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return pickupSlotsToGraphQLModel(slots), nil
+}
+
+func (r *Resolver) BookPickup(ctx context.Context, orderID uuid.UUID, slotID uuid.UUID) (*model.PickupAppointment, error) {
+	username, err := r.getCustomer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	appointment, err := r.pickupService.BookPickup(ctx, username, orderID, slotID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pickupAppointmentToGraphQLModel(appointment), nil
+}
+
+// Subscription resolvers
+
+func (r *Resolver) PetAvailabilityChanged(ctx context.Context, storeID uuid.UUID) (<-chan *model.PetAvailabilityChange, error) {
+	if _, err := auth.GetUser(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := r.storeService.GetStoreByID(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	changes, err := r.petEventService.SubscribeAvailabilityChanged(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.PetAvailabilityChange)
+	go func() {
+		defer close(out)
+		for change := range changes {
+			select {
+			case out <- &model.PetAvailabilityChange{
+				PetID:     model.ToGlobalID("Pet", change.PetID),
+				StoreID:   model.ToGlobalID("Store", change.StoreID),
+				Available: change.Available,
+				Reason:    model.PetAvailabilityReason(strings.ToUpper(string(change.Reason))),
+				ChangedAt: change.ChangedAt,
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (r *Resolver) PetAdded(ctx context.Context, storeID uuid.UUID) (<-chan *model.Pet, error) {
+	if _, err := auth.GetUser(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := r.storeService.GetStoreByID(ctx, storeID); err != nil {
+		return nil, err
+	}
+
+	pets, err := r.petEventService.SubscribePetAdded(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *model.Pet)
+	go func() {
+		defer close(out)
+		for pet := range pets {
+			// Same visibility as availablePets: no breeder email, breeder name per store settings
+			edge := r.petToGraphQLModel(pet, false)
+			settings, err := r.settingsService.GetStoreSettings(ctx, storeID)
+			if err != nil || !settings.ShowBreederNames {
+				edge.BreederName = "[Hidden]"
+			}
+
+			select {
+			case out <- edge:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Helper to convert models.Order to model.Order; its pets, discounts and store are field resolvers
+func orderToGraphQLModel(order *models.Order) *model.Order {
+	return &model.Order{
+		ID:            model.ToGlobalID("Order", order.ID),
+		UUID:          order.ID,
+		StoreID:       model.ToGlobalID("Store", order.StoreID),
+		StoreUUID:     order.StoreID,
+		CustomerID:    order.CustomerID,
+		TotalPets:     int32(order.TotalPets),
+		SubtotalCents: int32(order.SubtotalCents()),
+		DiscountCents: int32(order.DiscountCents),
+		TotalCents:    int32(order.TotalCents),
+		PaymentStatus: model.PaymentStatus(order.PaymentStatus),
+		Status:        model.OrderStatus(order.Status),
+		CreatedAt:     order.CreatedAt,
+	}
+}
+
+// Helper to convert models.Promotion to model.Promotion
+func promotionToGraphQLModel(promotion *models.Promotion) *model.Promotion {
+	result := &model.Promotion{
+		ID:               promotion.ID,
+		Code:             promotion.Code,
+		Description:      promotion.Description,
+		DiscountType:     model.DiscountType(promotion.DiscountType),
+		DiscountValue:    int32(promotion.DiscountValue),
+		MinAge:           int32Ptr(promotion.MinAge),
+		MaxAge:           int32Ptr(promotion.MaxAge),
+		MinQuantity:      int32(promotion.MinQuantity),
+		StartsAt:         promotion.StartsAt,
+		EndsAt:           promotion.EndsAt,
+		UsageLimit:       int32Ptr(promotion.UsageLimit),
+		PerCustomerLimit: int32Ptr(promotion.PerCustomerLimit),
+		TimesUsed:        int32(promotion.TimesUsed),
+		Active:           promotion.Active,
+		CreatedAt:        promotion.CreatedAt,
+	}
+	if promotion.Species != nil {
+		species := model.PetSpecies(*promotion.Species)
+		result.Species = &species
+	}
+	return result
+}
+
+// Helper to convert models.WebhookEndpoint to model.WebhookEndpoint; the secret is never exposed
+func webhookEndpointToGraphQLModel(endpoint *models.WebhookEndpoint) *model.WebhookEndpoint {
+	result := &model.WebhookEndpoint{
+		ID:         endpoint.ID,
+		URL:        endpoint.URL,
+		EventTypes: make([]model.WebhookEventType, 0, len(endpoint.EventTypes)),
+		Active:     endpoint.Active,
+		CreatedAt:  endpoint.CreatedAt,
+	}
+	for _, eventType := range endpoint.EventTypes {
+		result.EventTypes = append(result.EventTypes, webhookEventTypeToGraphQL(eventType))
+	}
+	return result
+}
+
+// Helper to convert models.WebhookDelivery to model.WebhookDelivery
+func webhookDeliveryToGraphQLModel(delivery *models.WebhookDelivery) *model.WebhookDelivery {
+	result := &model.WebhookDelivery{
+		ID:             delivery.ID,
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      webhookEventTypeToGraphQL(delivery.EventType),
+		Status:         model.WebhookDeliveryStatus(delivery.Status),
+		Attempts:       int32(delivery.Attempts),
+		ResponseStatus: int32Ptr(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		Payload:        string(delivery.Payload),
+	}
+	if delivery.Status == models.WebhookDeliveryPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		result.NextAttemptAt = &nextAttemptAt
+	}
+	return result
+}
+
+// webhookEventTypeToGraphQL maps an event type such as pet.created to PET_CREATED
+func webhookEventTypeToGraphQL(eventType models.WebhookEventType) model.WebhookEventType {
+	return model.WebhookEventType(strings.ToUpper(strings.Replace(string(eventType), ".", "_", 1)))
+}
+
+// webhookEventTypeFromGraphQL maps an enum value such as PET_CREATED to pet.created
+func webhookEventTypeFromGraphQL(eventType model.WebhookEventType) models.WebhookEventType {
+	return models.WebhookEventType(strings.ToLower(strings.Replace(string(eventType), "_", ".", 1)))
+}
+
+// Helper to convert pickup slots (and any loaded appointments) to GraphQL models
+func pickupSlotsToGraphQLModel(slots []*models.PickupSlot) []*model.PickupSlot {
+	result := []*model.PickupSlot{}
+	for _, slot := range slots {
+		modelSlot := &model.PickupSlot{
+			ID:           slot.ID,
+			StartsAt:     slot.StartsAt,
+			EndsAt:       slot.EndsAt,
+			Capacity:     int32(slot.Capacity),
+			Booked:       int32(slot.Booked),
+			Remaining:    int32(slot.Remaining()),
+			Appointments: []*model.PickupAppointment{},
+		}
+		for _, appointment := range slot.Appointments {
+			modelSlot.Appointments = append(modelSlot.Appointments, pickupAppointmentToGraphQLModel(appointment))
+		}
+		result = append(result, modelSlot)
+	}
+	return result
+}
+
+// Helper to convert models.PickupAppointment to model.PickupAppointment
+func pickupAppointmentToGraphQLModel(appointment *models.PickupAppointment) *model.PickupAppointment {
+	return &model.PickupAppointment{
+		ID:          appointment.ID,
+		OrderID:     appointment.OrderID,
+		SlotID:      appointment.SlotID,
+		CustomerID:  appointment.CustomerID,
+		Status:      model.PickupStatus(appointment.Status),
+		CreatedAt:   appointment.CreatedAt,
+		CompletedAt: appointment.CompletedAt,
+	}
+}
+
+// Helper to convert optional GraphQL ints to optional model ints
+func intPtr(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	v := int(*value)
+	return &v
+}
+
+// Helper to convert optional model ints to optional GraphQL ints
+func int32Ptr(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+	v := int32(*value)
+	return &v
+}
+
+// Helper to dereference an optional string argument
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+// purchaseError keeps the checkout message shown to customers while still wrapping the cause,
+// so the error presenter can report its code
+type purchaseError struct {
+	message string
+	err     error
+}
+
+func (e purchaseError) Error() string { return e.message }
+
+func (e purchaseError) Unwrap() error { return e.err }
+
+// purchaseFailed explains a failed checkout, using unavailableMessage when pets were sold in the meantime
+func purchaseFailed(err error, unavailableMessage string) error {
+	var unavailable apperrors.PetsUnavailableError
+	if errors.As(err, &unavailable) {
+		return purchaseError{message: unavailableMessage, err: err}
+	}
+	return fmt.Errorf("unable to complete the purchase: %w", err)
+}
+
+// Helper method to convert models.Cart to model.Cart with live availability; pets are
+// shown like in availablePets
+func (r *Resolver) cartToGraphQLModel(ctx context.Context, cart *models.Cart) (*model.Cart, error) {
+	pets := make([]*models.Pet, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		pets = append(pets, item.Pet)
+	}
+	nodes, err := r.publicPets(ctx, pets)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.Cart{
+		ID:        cart.ID,
+		Items:     []*model.CartItem{},
+		UpdatedAt: cart.UpdatedAt,
+	}
+
+	var totalCents int64
+	for i, item := range cart.Items {
+		available := item.Available()
+		result.Items = append(result.Items, &model.CartItem{
+			Pet:       nodes[i],
+			Available: available,
+			AddedAt:   item.AddedAt,
+		})
+		if available {
+			result.AvailableItems++
+			totalCents += item.Pet.PriceCents
+		}
+	}
+	result.TotalItems = int32(len(cart.Items))
+
+	if totalCents > math.MaxInt32 {
+		return nil, apperrors.NewBusinessRuleError("the cart total is too large to show; remove some pets")
+	}
+	result.TotalCents = int32(totalCents)
+
+	return result, nil
+}
+
+// Helper to convert models.Store to model.Store
+func storeToGraphQLModel(store *models.Store) *model.Store {
+	result := &model.Store{
+		ID:           model.ToGlobalID("Store", store.ID),
+		UUID:         store.ID,
+		Name:         store.Name,
+		Slug:         store.Slug,
+		Description:  store.Description,
+		Address:      store.Address,
+		Phone:        store.Phone,
+		LogoURL:      store.LogoURL,
+		OpeningHours: []*model.OpeningHours{},
+		Open:         store.IsOpen(),
+		ClosedAt:     store.ClosedAt,
+		Latitude:     store.Latitude,
+		Longitude:    store.Longitude,
+		CreatedAt:    store.CreatedAt,
+	}
+	for _, hours := range store.OpeningHours {
+		result.OpeningHours = append(result.OpeningHours, &model.OpeningHours{
+			Day:    model.Weekday(hours.Day),
+			Opens:  hours.Opens,
+			Closes: hours.Closes,
+		})
+	}
+	return result
+}
+
+func storeSettingsToGraphQLModel(settings *models.StoreSettings) *model.StoreSettings {
+	result := &model.StoreSettings{
+		StoreID:              settings.StoreID,
+		MaxPetsPerOrder:      int32(settings.MaxPetsPerOrder),
+		MaxPetAge:            int32(settings.MaxPetAge),
+		MaxPageSize:          int32(settings.MaxPageSize),
+		MinCustomerAge:       int32(settings.MinCustomerAge),
+		ReservationHoldHours: int32(settings.ReservationHoldHours),
+		ShowBreederNames:     settings.ShowBreederNames,
+		StaleAfterDays:       int32(settings.StaleAfterDays),
+		Timezone:             settings.Timezone,
+	}
+	if !settings.UpdatedAt.IsZero() {
+		result.UpdatedAt = &settings.UpdatedAt
+	}
+	return result
+}
+
+// Helper method to convert models.Pet to model.Pet with email handling
+func (r *Resolver) petToGraphQLModel(pet *models.Pet, showEmail bool) *model.Pet {
+	var breederEmail string
+	if showEmail {
+		// Decrypt email for merchants
+		if decrypted, err := r.petService.DecryptBreederEmail(pet.BreederEmailEncrypted); err == nil {
+			breederEmail = decrypted
+		} else {
+			breederEmail = "[Hidden]"
+		}
+	} else {
+		breederEmail = "[Hidden]"
+	}
+
+	gqlPet := &model.Pet{
+		ID:           model.ToGlobalID("Pet", pet.ID),
+		StoreID:      model.ToGlobalID("Store", pet.StoreID),
+		StoreUUID:    pet.StoreID,
+		Name:         pet.Name,
+		Species:      model.PetSpecies(pet.Species),
+		Age:          int32(pet.Age),
+		PictureURL:   pet.PictureURL,
+		Description:  pet.Description,
+		BreederName:  pet.BreederName,
+		BreederEmail: breederEmail,
+		PriceCents:   int32(pet.PriceCents),
+		Status:       model.PetStatus(pet.Status),
+		CreatedAt:    pet.CreatedAt,
+	}
+	// Sale details identify the customer, so only merchants see them
+	if showEmail {
+		gqlPet.Sale = petSaleToGraphQLModel(pet.Sale)
+	}
+
+	return gqlPet
+}
+
+// Helper method to convert a pet's sale details to the GraphQL model
+func petSaleToGraphQLModel(sale *models.PetSale) *model.PetSale {
+	if sale == nil {
+		return nil
+	}
+
+	return &model.PetSale{
+		OrderID:     sale.OrderID,
+		PurchasedAt: sale.PurchasedAt,
+		CustomerID:  sale.CustomerID,
+	}
+}
+
+// Helper method to get a store owned by the authenticated merchant
+func (r *Resolver) getStoreForMerchant(ctx context.Context, storeID uuid.UUID) (*models.Store, error) {
+	if err := auth.RequireMerchant(ctx); err != nil {
+		return nil, err
+	}
+
+	username, err := auth.GetUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.storeService.GetStoreForOwner(ctx, username, storeID)
+}
+
+// Helper to extract the card number from optional payment input
+func cardNumber(payment *model.PaymentInput) string {
+	if payment == nil {
+		return ""
+	}
+	return payment.CardNumber
+}
+
+// Helper method to get the authenticated customer's username
+func (r *Resolver) getCustomer(ctx context.Context) (string, error) {
+	if err := auth.RequireCustomer(ctx); err != nil {
+		return "", err
+	}
+
+	return auth.GetUser(ctx)
+}
+
+// Helper to load a pet for node: merchants see their own pets in full, everyone else only
+// available pets, with the details hidden as in availablePets
+func (r *Resolver) petNode(ctx context.Context, id uuid.UUID, userType auth.UserType) (model.Node, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pet, err := loaders.Pets.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if userType == auth.UserTypeMerchant {
+		_, err := r.getStoreForMerchant(ctx, pet.StoreID)
+		if err == nil {
+			return r.petToGraphQLModel(pet, true), nil
+		}
+		if !apperrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	if pet.Status != models.PetStatusAvailable {
+		return nil, apperrors.NewPetNotFound(id)
+	}
+
+	settings, err := r.settingsService.GetStoreSettings(ctx, pet.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	node := r.petToGraphQLModel(pet, false)
+	if !settings.ShowBreederNames {
+		node.BreederName = "[Hidden]"
+	}
+	return node, nil
+}
+
+// Helper to load a store for node; stores are public
+func (r *Resolver) storeNode(ctx context.Context, id uuid.UUID) (model.Node, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	store, err := loaders.Stores.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return storeToGraphQLModel(store), nil
+}
+
+// Helper to load an order for node; like receipts, only its customer and the store's merchant see it
+func (r *Resolver) orderNode(ctx context.Context, id uuid.UUID, userType auth.UserType) (model.Node, error) {
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return nil, err
+	}
+	order, err := loaders.Orders.Load(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if userType == auth.UserTypeMerchant {
+		if _, err := r.getStoreForMerchant(ctx, order.StoreID); err != nil {
+			return nil, err
+		}
+	} else {
+		username, err := auth.GetUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if order.CustomerID != username {
+			return nil, apperrors.NewOrderNotFound(id)
+		}
+	}
+
+	return orderToGraphQLModel(order), nil
+}
+
+// Helper to build the pet filter of one store from the GraphQL filter input
+func petFilterFromInput(storeID uuid.UUID, filter *model.PetFilterInput) models.PetFilter {
+	petFilter := models.PetFilter{
+		StoreID: &storeID,
+		Limit:   defaultPetPageSize,
+		Offset:  0,
+	}
+
+	if filter != nil {
+		if filter.Status != nil {
+			status := models.PetStatus(*filter.Status)
+			petFilter.Status = &status
+		}
+		if filter.StartDate != nil {
+			petFilter.StartDate = filter.StartDate
+		}
+		if filter.EndDate != nil {
+			petFilter.EndDate = filter.EndDate
+		}
+	}
+
+	return petFilter
+}
+
+// Helper to convert pets for customers: breeder emails hidden, breeder names only where the store shows them
+func (r *Resolver) publicPets(ctx context.Context, pets []*models.Pet) ([]*model.Pet, error) {
+	var nodes []*model.Pet
+	showBreederNames := map[uuid.UUID]bool{}
+	for _, pet := range pets {
+		node := r.petToGraphQLModel(pet, false) // Hide email for customers
+
+		show, ok := showBreederNames[pet.StoreID]
+		if !ok {
+			settings, err := r.settingsService.GetStoreSettings(ctx, pet.StoreID)
+			if err != nil {
+				return nil, err
+			}
+			show = settings.ShowBreederNames
+			showBreederNames[pet.StoreID] = show
+		}
+		if !show {
+			node.BreederName = "[Hidden]"
+		}
+
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// Helper to check whether the viewer is the merchant who owns a store; anonymous viewers own nothing
+func (r *Resolver) ownsStore(ctx context.Context, storeID uuid.UUID) (bool, error) {
+	userType, err := auth.GetUserType(ctx)
+	if err != nil || userType != auth.UserTypeMerchant {
+		return false, nil
+	}
+
+	username, err := auth.GetUser(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	loaders, err := dataloader.For(ctx)
+	if err != nil {
+		return false, err
+	}
+	store, err := loaders.Stores.Load(ctx, storeID)
+	if err != nil {
+		return false, err
+	}
+	return store.OwnerID == username, nil
+}
+
+// Helper to wrap a page of pets in a connection; cursors are offsets, so any of them can be passed as after
+func petConnection(nodes []*model.Pet, petFilter models.PetFilter, totalCount int) *model.PetConnection {
+	edges := make([]*model.PetEdge, 0, len(nodes))
+	for i, node := range nodes {
+		edges = append(edges, &model.PetEdge{
+			Node:   node,
+			Cursor: strconv.Itoa(petFilter.Offset + i + 1),
+		})
+	}
+
+	pageInfo := &model.PageInfo{
+		HasNextPage:     len(nodes) == petFilter.Limit,
+		HasPreviousPage: petFilter.Offset > 0,
+	}
+	if len(edges) > 0 {
+		pageInfo.StartCursor = &edges[0].Cursor
+		pageInfo.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
+	return &model.PetConnection{
+		Edges:      edges,
+		PageInfo:   pageInfo,
+		TotalCount: int32(totalCount),
+	}
 }
 
 // Helper method to apply pagination to pet filter
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCartRepository is a mock implementation of CartRepositoryInterface
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (m *MockCartRepository) Get(ctx context.Context, customerID string) (*models.Cart, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cart), args.Error(1)
+}
+
+func (m *MockCartRepository) GetOrCreate(ctx context.Context, customerID string) (*models.Cart, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Cart), args.Error(1)
+}
+
+func (m *MockCartRepository) GetItems(ctx context.Context, cartID uuid.UUID) ([]*models.CartItem, error) {
+	args := m.Called(ctx, cartID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.CartItem), args.Error(1)
+}
+
+func (m *MockCartRepository) AddItem(ctx context.Context, cartID, petID uuid.UUID) error {
+	args := m.Called(ctx, cartID, petID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) RemoveItem(ctx context.Context, cartID, petID uuid.UUID) error {
+	args := m.Called(ctx, cartID, petID)
+	return args.Error(0)
+}
@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/notify"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockInventoryRepository is a mock implementation of InventoryRepositoryInterface
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) AgingBuckets(ctx context.Context, storeID uuid.UUID, asOf time.Time, staleAfterDays int) ([]models.AgingBucket, int, error) {
+	args := m.Called(ctx, storeID, asOf, staleAfterDays)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]models.AgingBucket), args.Int(1), args.Error(2)
+}
+
+func (m *MockInventoryRepository) ListStalePets(ctx context.Context, asOf time.Time) ([]*models.StalePet, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StalePet), args.Error(1)
+}
+
+func (m *MockInventoryRepository) CreateAlerts(ctx context.Context, pets []*models.StalePet) ([]*models.InventoryAlert, error) {
+	args := m.Called(ctx, pets)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.InventoryAlert), args.Error(1)
+}
+
+func (m *MockInventoryRepository) ListAlerts(ctx context.Context, storeID uuid.UUID, limit, offset int) ([]*models.InventoryAlert, int, error) {
+	args := m.Called(ctx, storeID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.InventoryAlert), args.Int(1), args.Error(2)
+}
+
+// MockNotifier is a mock implementation of notify.Notifier
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
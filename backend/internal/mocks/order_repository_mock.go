@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/models"
 	"github.com/google/uuid"
@@ -34,8 +35,84 @@ func (m *MockOrderRepository) GetOrderPets(ctx context.Context, orderID uuid.UUI
 	return args.Get(0).([]*models.Pet), args.Error(1)
 }
 
+func (m *MockOrderRepository) GetPetsByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID][]*models.Pet, error) {
+	args := m.Called(ctx, orderIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]*models.Pet), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetItemsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) ([]*models.OrderItem, error) {
+	args := m.Called(ctx, tx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrderItem), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]*models.Order, error) {
+	args := m.Called(ctx, orderIDs)
+	return args.Get(0).([]*models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByIDForUpdateWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*models.Order, error) {
+	args := m.Called(ctx, tx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetByPaymentID(ctx context.Context, paymentID string) (*models.Order, error) {
+	args := m.Called(ctx, paymentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListAwaitingPayment(ctx context.Context, createdBefore time.Time, limit int) ([]*models.Order, error) {
+	args := m.Called(ctx, createdBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateStatusWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, status models.OrderStatus) error {
+	args := m.Called(ctx, tx, orderID, status)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) ReleaseItemsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, releasedAt time.Time) error {
+	args := m.Called(ctx, tx, orderID, releasedAt)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) EnsureStoreOpenWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) error {
+	args := m.Called(ctx, tx, storeID)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) Stream(ctx context.Context, filter models.OrderFilter, fn func(*models.Order) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
 func (m *MockOrderRepository) Transaction(fn func(*sql.Tx) error) error {
 	args := m.Called(fn)
-	return args.Error(0)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(nil)
 }
 
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderService is a mock implementation of OrderServiceInterface
+type MockOrderService struct {
+	mock.Mock
+}
+
+func (m *MockOrderService) CreateOrder(ctx context.Context, input models.CreateOrderInput) (*models.Order, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrderPets(ctx context.Context, orderID uuid.UUID) ([]*models.Pet, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Pet), args.Error(1)
+}
+
+func (m *MockOrderService) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error {
+	args := m.Called(ctx, payload, signature)
+	return args.Error(0)
+}
@@ -14,8 +14,13 @@ type MockPetRepository struct {
 	mock.Mock
 }
 
-func (m *MockPetRepository) Create(ctx context.Context, pet *models.Pet) error {
-	args := m.Called(ctx, pet)
+func (m *MockPetRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, pet *models.Pet) error {
+	args := m.Called(ctx, tx, pet)
+	return args.Error(0)
+}
+
+func (m *MockPetRepository) CreateBatchWithTx(ctx context.Context, tx *sql.Tx, pets []*models.Pet) error {
+	args := m.Called(ctx, tx, pets)
 	return args.Error(0)
 }
 
@@ -24,6 +29,11 @@ func (m *MockPetRepository) GetByID(ctx context.Context, petID uuid.UUID) (*mode
 	return args.Get(0).(*models.Pet), args.Error(1)
 }
 
+func (m *MockPetRepository) GetByIDs(ctx context.Context, petIDs []uuid.UUID) ([]*models.Pet, error) {
+	args := m.Called(ctx, petIDs)
+	return args.Get(0).([]*models.Pet), args.Error(1)
+}
+
 func (m *MockPetRepository) List(ctx context.Context, filter models.PetFilter) ([]*models.Pet, int, error) {
 	args := m.Called(ctx, filter)
 	return args.Get(0).([]*models.Pet), args.Int(1), args.Error(2)
@@ -34,11 +44,34 @@ func (m *MockPetRepository) Delete(ctx context.Context, petID uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockPetRepository) MarkAsReserved(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error {
+	args := m.Called(ctx, tx, petID)
+	return args.Error(0)
+}
+
 func (m *MockPetRepository) MarkAsSold(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error {
 	args := m.Called(ctx, tx, petID)
 	return args.Error(0)
 }
 
+func (m *MockPetRepository) MarkAsAvailable(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error {
+	args := m.Called(ctx, tx, petID)
+	return args.Error(0)
+}
+
+func (m *MockPetRepository) LockAvailableWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, petIDs []uuid.UUID) ([]*models.Pet, error) {
+	args := m.Called(ctx, tx, storeID, petIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Pet), args.Error(1)
+}
+
+func (m *MockPetRepository) Stream(ctx context.Context, filter models.PetFilter, fn func(*models.Pet) error) error {
+	args := m.Called(ctx, filter, fn)
+	return args.Error(0)
+}
+
 func (m *MockPetRepository) Transaction(fn func(*sql.Tx) error) error {
 	args := m.Called(fn)
 	return args.Error(0)
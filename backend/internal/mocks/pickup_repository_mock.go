@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPickupRepository is a mock implementation of PickupRepositoryInterface
+type MockPickupRepository struct {
+	mock.Mock
+}
+
+func (m *MockPickupRepository) CreateSlotsWithTx(ctx context.Context, tx *sql.Tx, slots []*models.PickupSlot) error {
+	args := m.Called(ctx, tx, slots)
+	return args.Error(0)
+}
+
+func (m *MockPickupRepository) ListSlots(ctx context.Context, storeID uuid.UUID, from, to time.Time) ([]*models.PickupSlot, error) {
+	args := m.Called(ctx, storeID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PickupSlot), args.Error(1)
+}
+
+func (m *MockPickupRepository) GetSlotForUpdateWithTx(ctx context.Context, tx *sql.Tx, slotID uuid.UUID) (*models.PickupSlot, error) {
+	args := m.Called(ctx, tx, slotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PickupSlot), args.Error(1)
+}
+
+func (m *MockPickupRepository) AdjustBookedWithTx(ctx context.Context, tx *sql.Tx, slotID uuid.UUID, delta int) error {
+	args := m.Called(ctx, tx, slotID, delta)
+	return args.Error(0)
+}
+
+func (m *MockPickupRepository) GetAppointmentByOrderWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*models.PickupAppointment, error) {
+	args := m.Called(ctx, tx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PickupAppointment), args.Error(1)
+}
+
+func (m *MockPickupRepository) SaveAppointmentWithTx(ctx context.Context, tx *sql.Tx, appointment *models.PickupAppointment) error {
+	args := m.Called(ctx, tx, appointment)
+	return args.Error(0)
+}
+
+func (m *MockPickupRepository) ListAppointments(ctx context.Context, storeID uuid.UUID, from, to time.Time) ([]*models.PickupAppointment, error) {
+	args := m.Called(ctx, storeID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PickupAppointment), args.Error(1)
+}
+
+// Transaction runs fn with a nil transaction so tests can exercise the callback
+func (m *MockPickupRepository) Transaction(fn func(*sql.Tx) error) error {
+	args := m.Called(fn)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(nil)
+}
@@ -0,0 +1,61 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPickupService is a mock implementation of PickupServiceInterface
+type MockPickupService struct {
+	mock.Mock
+}
+
+func (m *MockPickupService) CreatePickupSlots(ctx context.Context, input models.CreatePickupSlotsInput) ([]*models.PickupSlot, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PickupSlot), args.Error(1)
+}
+
+func (m *MockPickupService) AvailablePickupSlots(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*models.PickupSlot, error) {
+	args := m.Called(ctx, storeID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PickupSlot), args.Error(1)
+}
+
+func (m *MockPickupService) PickupSchedule(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*models.PickupSlot, error) {
+	args := m.Called(ctx, storeID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.PickupSlot), args.Error(1)
+}
+
+func (m *MockPickupService) BookPickup(ctx context.Context, customerID string, orderID, slotID uuid.UUID) (*models.PickupAppointment, error) {
+	args := m.Called(ctx, customerID, orderID, slotID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PickupAppointment), args.Error(1)
+}
+
+func (m *MockPickupService) CompletePickup(ctx context.Context, storeID, orderID uuid.UUID) (*models.Order, error) {
+	args := m.Called(ctx, storeID, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockPickupService) CancelPickup(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	args := m.Called(ctx, tx, orderID)
+	return args.Error(0)
+}
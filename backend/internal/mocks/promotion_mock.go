@@ -0,0 +1,124 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPromotionRepository is a mock implementation of PromotionRepositoryInterface
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	args := m.Called(ctx, promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) GetByCodeWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, code string) (*models.Promotion, error) {
+	args := m.Called(ctx, tx, storeID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) ListByStore(ctx context.Context, storeID uuid.UUID) ([]*models.Promotion, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) SetActive(ctx context.Context, storeID, promotionID uuid.UUID, active bool) error {
+	args := m.Called(ctx, storeID, promotionID, active)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) CountCustomerUsageWithTx(ctx context.Context, tx *sql.Tx, promotionID uuid.UUID, customerID string) (int, error) {
+	args := m.Called(ctx, tx, promotionID, customerID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPromotionRepository) IncrementUsageWithTx(ctx context.Context, tx *sql.Tx, promotionID uuid.UUID) error {
+	args := m.Called(ctx, tx, promotionID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) CreateDiscountWithTx(ctx context.Context, tx *sql.Tx, discount *models.OrderDiscount) error {
+	args := m.Called(ctx, tx, discount)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) ReleaseOrderDiscountsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	args := m.Called(ctx, tx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) GetOrderDiscounts(ctx context.Context, orderID uuid.UUID) ([]*models.OrderDiscount, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrderDiscount), args.Error(1)
+}
+
+func (m *MockPromotionRepository) GetDiscountsByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID][]*models.OrderDiscount, error) {
+	args := m.Called(ctx, orderIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]*models.OrderDiscount), args.Error(1)
+}
+
+// MockPromotionService is a mock implementation of PromotionServiceInterface
+type MockPromotionService struct {
+	mock.Mock
+}
+
+func (m *MockPromotionService) CreatePromotion(ctx context.Context, input models.CreatePromotionInput) (*models.Promotion, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionService) ListPromotions(ctx context.Context, storeID uuid.UUID) ([]*models.Promotion, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionService) SetPromotionActive(ctx context.Context, storeID, promotionID uuid.UUID, active bool) error {
+	args := m.Called(ctx, storeID, promotionID, active)
+	return args.Error(0)
+}
+
+func (m *MockPromotionService) ApplyDiscount(ctx context.Context, tx *sql.Tx, order *models.Order, pets []*models.Pet, code string) ([]*models.OrderDiscount, error) {
+	args := m.Called(ctx, tx, order, pets, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrderDiscount), args.Error(1)
+}
+
+func (m *MockPromotionService) ReleaseDiscount(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	args := m.Called(ctx, tx, orderID)
+	return args.Error(0)
+}
+
+func (m *MockPromotionService) GetOrderDiscounts(ctx context.Context, orderID uuid.UUID) ([]*models.OrderDiscount, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.OrderDiscount), args.Error(1)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSalesRepository is a mock implementation of SalesRepositoryInterface
+type MockSalesRepository struct {
+	mock.Mock
+}
+
+func (m *MockSalesRepository) SalesBuckets(ctx context.Context, input models.SalesReportInput) ([]*models.SalesBucket, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.SalesBucket), args.Error(1)
+}
+
+func (m *MockSalesRepository) SalesSummary(ctx context.Context, input models.SalesReportInput) (*models.SalesSummary, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SalesSummary), args.Error(1)
+}
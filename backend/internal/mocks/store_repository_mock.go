@@ -2,8 +2,11 @@ package mocks
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -17,11 +20,32 @@ func (m *MockStoreRepository) Create(ctx context.Context, store *models.Store) e
 	return args.Error(0)
 }
 
-func (m *MockStoreRepository) GetByOwnerID(ctx context.Context, ownerID string) (*models.Store, error) {
+func (m *MockStoreRepository) ListByOwner(ctx context.Context, ownerID string) ([]*models.Store, error) {
 	args := m.Called(ctx, ownerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).([]*models.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetByID(ctx context.Context, storeID uuid.UUID) (*models.Store, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetByIDs(ctx context.Context, storeIDs []uuid.UUID) ([]*models.Store, error) {
+	args := m.Called(ctx, storeIDs)
+	return args.Get(0).([]*models.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) GetBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*models.Store), args.Error(1)
 }
 
@@ -33,3 +57,50 @@ func (m *MockStoreRepository) ListAll(ctx context.Context) ([]*models.Store, err
 	return args.Get(0).([]*models.Store), args.Error(1)
 }
 
+func (m *MockStoreRepository) ListOpen(ctx context.Context) ([]*models.Store, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) ListNear(ctx context.Context, near models.GeoRadius, limit, offset int) ([]*models.NearbyStore, int, error) {
+	args := m.Called(ctx, near, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.NearbyStore), args.Int(1), args.Error(2)
+}
+
+func (m *MockStoreRepository) Update(ctx context.Context, store *models.Store) error {
+	args := m.Called(ctx, store)
+	return args.Error(0)
+}
+
+func (m *MockStoreRepository) GetByIDForUpdateWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) (*models.Store, error) {
+	args := m.Called(ctx, tx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Store), args.Error(1)
+}
+
+func (m *MockStoreRepository) CountOpenOrdersWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) (int, error) {
+	args := m.Called(ctx, tx, storeID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStoreRepository) CloseWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, closedAt time.Time) error {
+	args := m.Called(ctx, tx, storeID, closedAt)
+	return args.Error(0)
+}
+
+// Transaction runs fn with a nil transaction so tests can exercise the callback
+func (m *MockStoreRepository) Transaction(fn func(*sql.Tx) error) error {
+	args := m.Called(fn)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(nil)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStoreService is a mock implementation of StoreServiceInterface
+type MockStoreService struct {
+	mock.Mock
+}
+
+func (m *MockStoreService) CreateStore(ctx context.Context, input models.CreateStoreInput) (*models.Store, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) GetStoreByID(ctx context.Context, storeID uuid.UUID) (*models.Store, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) GetStoreForOwner(ctx context.Context, ownerID string, storeID uuid.UUID) (*models.Store, error) {
+	args := m.Called(ctx, ownerID, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) ListStoresByOwner(ctx context.Context, ownerID string) ([]*models.Store, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).([]*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) ListAllStores(ctx context.Context) ([]*models.Store, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) ListOpenStores(ctx context.Context) ([]*models.Store, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) StoresNear(ctx context.Context, near models.GeoRadius, limit, offset int) ([]*models.NearbyStore, int, error) {
+	args := m.Called(ctx, near, limit, offset)
+	return args.Get(0).([]*models.NearbyStore), args.Int(1), args.Error(2)
+}
+
+func (m *MockStoreService) UpdateStore(ctx context.Context, ownerID string, storeID uuid.UUID, input models.UpdateStoreInput) (*models.Store, error) {
+	args := m.Called(ctx, ownerID, storeID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Store), args.Error(1)
+}
+
+func (m *MockStoreService) CloseStore(ctx context.Context, ownerID string, storeID uuid.UUID) error {
+	args := m.Called(ctx, ownerID, storeID)
+	return args.Error(0)
+}
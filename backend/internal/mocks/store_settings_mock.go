@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStoreSettingsRepository is a mock implementation of StoreSettingsRepositoryInterface
+type MockStoreSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStoreSettingsRepository) Get(ctx context.Context, storeID uuid.UUID) (*models.StoreSettings, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoreSettings), args.Error(1)
+}
+
+func (m *MockStoreSettingsRepository) Save(ctx context.Context, settings *models.StoreSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+// MockStoreSettingsService is a mock implementation of StoreSettingsServiceInterface
+type MockStoreSettingsService struct {
+	mock.Mock
+}
+
+func (m *MockStoreSettingsService) GetStoreSettings(ctx context.Context, storeID uuid.UUID) (*models.StoreSettings, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoreSettings), args.Error(1)
+}
+
+func (m *MockStoreSettingsService) UpdateStoreSettings(ctx context.Context, ownerID string, storeID uuid.UUID, input models.UpdateStoreSettingsInput) (*models.StoreSettings, error) {
+	args := m.Called(ctx, ownerID, storeID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StoreSettings), args.Error(1)
+}
@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookRepository is a mock implementation of WebhookRepositoryInterface
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	args := m.Called(ctx, endpoint)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ListEndpoints(ctx context.Context, storeID uuid.UUID) ([]*models.WebhookEndpoint, error) {
+	args := m.Called(ctx, storeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WebhookEndpoint), args.Error(1)
+}
+
+func (m *MockWebhookRepository) DeleteEndpoint(ctx context.Context, storeID, endpointID uuid.UUID) error {
+	args := m.Called(ctx, storeID, endpointID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) EnqueueDeliveriesWithTx(ctx context.Context, tx *sql.Tx, event *models.WebhookEvent, payload []byte) (int, error) {
+	args := m.Called(ctx, tx, event, payload)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	args := m.Called(ctx, now, leaseUntil, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.WebhookDelivery), args.Int(1), args.Error(2)
+}
+
+// MockWebhookSender is a mock implementation of webhook.Sender
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, req webhook.Request) (int, error) {
+	args := m.Called(ctx, req)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Cart struct {
+	ID         uuid.UUID   `db:"id"`
+	CustomerID string      `db:"customer_id"`
+	CreatedAt  time.Time   `db:"created_at"`
+	UpdatedAt  time.Time   `db:"updated_at"`
+	Items      []*CartItem `db:"-"`
+}
+
+type CartItem struct {
+	ID      uuid.UUID `db:"id"`
+	CartID  uuid.UUID `db:"cart_id"`
+	PetID   uuid.UUID `db:"pet_id"`
+	AddedAt time.Time `db:"added_at"`
+	Pet     *Pet      `db:"-"`
+}
+
+// Available reports whether the pet in the cart can still be purchased
+func (i *CartItem) Available() bool {
+	return i.Pet != nil && i.Pet.Status == PetStatusAvailable
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AgingRange is a band of days an unsold pet has been listed; MaxDays is nil for the open-ended band
+type AgingRange struct {
+	Label   string
+	MinDays int
+	MaxDays *int
+}
+
+// InventoryAgingRanges are the bands used by the inventory aging report, youngest first
+var InventoryAgingRanges = []AgingRange{
+	{Label: "0-7", MinDays: 0, MaxDays: intRef(7)},
+	{Label: "8-30", MinDays: 8, MaxDays: intRef(30)},
+	{Label: "31-90", MinDays: 31, MaxDays: intRef(90)},
+	{Label: "90+", MinDays: 91},
+}
+
+// AgingBucket counts the unsold pets whose listing age falls in a range
+type AgingBucket struct {
+	AgingRange
+	PetCount   int
+	ValueCents int64
+}
+
+// InventoryAging is the aging report of a store's unsold pets
+type InventoryAging struct {
+	StoreID         uuid.UUID
+	AsOf            time.Time
+	StaleAfterDays  int
+	Buckets         []AgingBucket
+	TotalPets       int
+	TotalValueCents int64
+	StalePets       int // pets listed for longer than StaleAfterDays
+}
+
+// StalePet is an available pet that has been listed longer than its store allows
+type StalePet struct {
+	PetID      uuid.UUID
+	PetName    string
+	StoreID    uuid.UUID
+	StoreName  string
+	OwnerID    string
+	DaysListed int
+}
+
+// InventoryAlert records that a pet was found stale by the aging job
+type InventoryAlert struct {
+	ID         uuid.UUID `db:"id"`
+	StoreID    uuid.UUID `db:"store_id"`
+	PetID      uuid.UUID `db:"pet_id"`
+	PetName    string    `db:"pet_name"`
+	DaysListed int       `db:"days_listed"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func intRef(v int) *int {
+	return &v
+}
@@ -6,23 +6,93 @@ import (
 	"github.com/google/uuid"
 )
 
+type PaymentStatus string
+
+const (
+	PaymentStatusNone       PaymentStatus = "none"
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusCaptured   PaymentStatus = "captured"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
+// paymentTransitions lists the statuses each payment status may move to; failed and refunded are final.
+// A captured payment can only be refunded: failing it would release the order while the customer stays charged.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusPending:    {PaymentStatusAuthorized, PaymentStatusFailed},
+	PaymentStatusAuthorized: {PaymentStatusCaptured, PaymentStatusFailed},
+	PaymentStatusCaptured:   {PaymentStatusRefunded},
+}
+
+// CanTransitionTo reports whether a payment in this status may move to next
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, allowed := range paymentTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleasesOrder reports whether an order whose payment reaches this status gives up its pets
+func (s PaymentStatus) ReleasesOrder() bool {
+	return s == PaymentStatusFailed || s == PaymentStatusRefunded
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusPlaced          OrderStatus = "placed"
+	OrderStatusPickupScheduled OrderStatus = "pickup_scheduled"
+	OrderStatusCompleted       OrderStatus = "completed"
+)
+
 type Order struct {
-	ID         uuid.UUID `db:"id"`
-	CustomerID string    `db:"customer_id"`
-	StoreID    uuid.UUID `db:"store_id"`
-	TotalPets  int       `db:"total_pets"`
-	CreatedAt  time.Time `db:"created_at"`
+	ID            uuid.UUID     `db:"id"`
+	CustomerID    string        `db:"customer_id"`
+	StoreID       uuid.UUID     `db:"store_id"`
+	TotalPets     int           `db:"total_pets"`
+	CreatedAt     time.Time     `db:"created_at"`
+	TotalCents    int64         `db:"total_cents"`
+	PaymentID     *string       `db:"payment_id"`
+	PaymentStatus PaymentStatus `db:"payment_status"`
+	DiscountCents int64         `db:"discount_cents"`
+	Status        OrderStatus   `db:"status"`
+}
+
+// IsPaid reports whether the order's payment has been captured, or the order was free
+func (o *Order) IsPaid() bool {
+	return o.PaymentStatus == PaymentStatusCaptured || o.PaymentStatus == PaymentStatusNone
+}
+
+// SubtotalCents returns the order amount before discounts
+func (o *Order) SubtotalCents() int64 {
+	return o.TotalCents + o.DiscountCents
 }
 
 type OrderItem struct {
-	ID          uuid.UUID `db:"id"`
-	OrderID     uuid.UUID `db:"order_id"`
-	PetID       uuid.UUID `db:"pet_id"`
-	PurchasedAt time.Time `db:"purchased_at"`
+	ID          uuid.UUID  `db:"id"`
+	OrderID     uuid.UUID  `db:"order_id"`
+	PetID       uuid.UUID  `db:"pet_id"`
+	PurchasedAt time.Time  `db:"purchased_at"`
+	ReleasedAt  *time.Time `db:"released_at"` // set when the order gave the pet up again
+}
+
+// OrderFilter selects the orders of a store; nil fields match any order
+type OrderFilter struct {
+	StoreID       uuid.UUID
+	Status        *OrderStatus
+	PaymentStatus *PaymentStatus
+	StartDate     *time.Time // compared with the order's creation time
+	EndDate       *time.Time
 }
 
 type CreateOrderInput struct {
-	CustomerID string
-	StoreID    uuid.UUID
-	PetIDs     []uuid.UUID
+	CustomerID   string
+	StoreID      uuid.UUID
+	PetIDs       []uuid.UUID
+	CardNumber   string
+	DiscountCode string
+	CustomerAge  *int // age stated by the customer, checked against the store's minimum
 }
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -18,9 +19,14 @@ type PetStatus string
 
 const (
 	PetStatusAvailable PetStatus = "available"
+	PetStatusReserved  PetStatus = "reserved" // held by an order whose payment has not been captured yet
 	PetStatusSold      PetStatus = "sold"
 )
 
+// MaxPriceCents is the largest price of a pet, and the largest total of an order; money
+// fields in the GraphQL API are 32-bit Ints
+const MaxPriceCents = math.MaxInt32
+
 type Pet struct {
 	ID                    uuid.UUID  `db:"id"`
 	StoreID               uuid.UUID  `db:"store_id"`
@@ -34,6 +40,15 @@ type Pet struct {
 	Status                PetStatus  `db:"status"`
 	CreatedAt             time.Time  `db:"created_at"`
 	UpdatedAt             time.Time  `db:"updated_at"`
+	PriceCents            int64      `db:"price_cents"`
+	Sale                  *PetSale   `db:"-"` // nil until the pet is sold
+}
+
+// PetSale records the order a pet was sold in
+type PetSale struct {
+	OrderID     uuid.UUID `db:"order_id"`
+	PurchasedAt time.Time `db:"purchased_at"`
+	CustomerID  string    `db:"customer_id"`
 }
 
 type CreatePetInput struct {
@@ -45,6 +60,7 @@ type CreatePetInput struct {
 	Description  *string
 	BreederName  string
 	BreederEmail string
+	PriceCents   int64
 }
 
 type PetFilter struct {
@@ -52,6 +68,9 @@ type PetFilter struct {
 	Status    *PetStatus
 	StartDate *time.Time
 	EndDate   *time.Time
+	SoldFrom  *time.Time // with SoldTo, only pets purchased within the range
+	SoldTo    *time.Time
+	Near      *GeoRadius // only pets of open stores within the radius
 	Limit     int
 	Offset    int
 }
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PetAvailabilityReason is why a pet became available or unavailable
+type PetAvailabilityReason string
+
+const (
+	PetAvailabilitySold     PetAvailabilityReason = "sold"
+	PetAvailabilityReserved PetAvailabilityReason = "reserved"
+	PetAvailabilityDeleted  PetAvailabilityReason = "deleted"
+	PetAvailabilityReleased PetAvailabilityReason = "released"
+)
+
+// PetAvailabilityChange is published when a pet can no longer, or can again, be purchased
+type PetAvailabilityChange struct {
+	PetID     uuid.UUID             `json:"petId"`
+	StoreID   uuid.UUID             `json:"storeId"`
+	Available bool                  `json:"available"`
+	Reason    PetAvailabilityReason `json:"reason"`
+	ChangedAt time.Time             `json:"changedAt"`
+}
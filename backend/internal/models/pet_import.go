@@ -0,0 +1,29 @@
+package models
+
+import "github.com/google/uuid"
+
+// MaxPetImportRows is the largest number of pets accepted in one import
+const MaxPetImportRows = 1000
+
+// PetImportError is a problem found in one field of an imported row
+type PetImportError struct {
+	Field   string
+	Message string
+}
+
+// PetImportRow reports the outcome of one CSV row; PetID is set once the pet is created
+type PetImportRow struct {
+	Line   int // line number in the file, the header being line 1
+	Name   string
+	PetID  *uuid.UUID
+	Errors []PetImportError
+}
+
+// PetImportReport summarizes an import. Pets are only created when every row is valid.
+type PetImportReport struct {
+	DryRun        bool
+	TotalRows     int
+	ImportedCount int
+	ErrorCount    int // rows with at least one error
+	Rows          []*PetImportRow
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PickupStatus string
+
+const (
+	PickupStatusBooked    PickupStatus = "booked"
+	PickupStatusCompleted PickupStatus = "completed"
+	PickupStatusCancelled PickupStatus = "cancelled"
+)
+
+// PickupSlot is a time window in which customers can collect purchased pets
+type PickupSlot struct {
+	ID           uuid.UUID `db:"id"`
+	StoreID      uuid.UUID `db:"store_id"`
+	StartsAt     time.Time `db:"starts_at"`
+	EndsAt       time.Time `db:"ends_at"`
+	Capacity     int       `db:"capacity"`
+	Booked       int       `db:"booked"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+	Appointments []*PickupAppointment
+}
+
+// Remaining returns the number of appointments that can still be booked
+func (s *PickupSlot) Remaining() int {
+	if s.Booked >= s.Capacity {
+		return 0
+	}
+	return s.Capacity - s.Booked
+}
+
+// PickupAppointment books an order into a pickup slot
+type PickupAppointment struct {
+	ID          uuid.UUID    `db:"id"`
+	OrderID     uuid.UUID    `db:"order_id"`
+	SlotID      uuid.UUID    `db:"slot_id"`
+	CustomerID  string       `db:"customer_id"`
+	Status      PickupStatus `db:"status"`
+	CreatedAt   time.Time    `db:"created_at"`
+	CompletedAt *time.Time   `db:"completed_at"`
+}
+
+// CreatePickupSlotsInput describes the opening hours of a day split into slots
+type CreatePickupSlotsInput struct {
+	StoreID     uuid.UUID
+	Date        time.Time
+	OpensAt     string // HH:MM
+	ClosesAt    string // HH:MM
+	SlotMinutes int
+	Capacity    int
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DiscountType string
+
+const (
+	// DiscountTypePercentage takes a percentage off every qualifying pet
+	DiscountTypePercentage DiscountType = "percentage"
+	// DiscountTypeFixedAmount takes a fixed amount in cents off the qualifying pets
+	DiscountTypeFixedAmount DiscountType = "fixed_amount"
+	// DiscountTypeNthItemPercentage takes a percentage off every Nth qualifying pet,
+	// where N is MinQuantity (e.g. "second pet half price")
+	DiscountTypeNthItemPercentage DiscountType = "nth_item_percentage"
+)
+
+type Promotion struct {
+	ID               uuid.UUID    `db:"id"`
+	StoreID          uuid.UUID    `db:"store_id"`
+	Code             string       `db:"code"`
+	Description      *string      `db:"description"`
+	DiscountType     DiscountType `db:"discount_type"`
+	DiscountValue    int64        `db:"discount_value"`
+	Species          *PetSpecies  `db:"species"`
+	MinAge           *int         `db:"min_age"`
+	MaxAge           *int         `db:"max_age"`
+	MinQuantity      int          `db:"min_quantity"`
+	StartsAt         time.Time    `db:"starts_at"`
+	EndsAt           *time.Time   `db:"ends_at"`
+	UsageLimit       *int         `db:"usage_limit"`
+	PerCustomerLimit *int         `db:"per_customer_limit"`
+	TimesUsed        int          `db:"times_used"`
+	Active           bool         `db:"active"`
+	CreatedAt        time.Time    `db:"created_at"`
+	UpdatedAt        time.Time    `db:"updated_at"`
+}
+
+// Qualifies reports whether a pet satisfies the promotion's species and age conditions
+func (p *Promotion) Qualifies(pet *Pet) bool {
+	if p.Species != nil && pet.Species != *p.Species {
+		return false
+	}
+	if p.MinAge != nil && pet.Age < *p.MinAge {
+		return false
+	}
+	if p.MaxAge != nil && pet.Age > *p.MaxAge {
+		return false
+	}
+	return true
+}
+
+// IsRunning reports whether the promotion is active at the given time
+func (p *Promotion) IsRunning(at time.Time) bool {
+	if !p.Active || at.Before(p.StartsAt) {
+		return false
+	}
+	return p.EndsAt == nil || at.Before(*p.EndsAt)
+}
+
+type CreatePromotionInput struct {
+	StoreID          uuid.UUID
+	Code             string
+	Description      *string
+	DiscountType     DiscountType
+	DiscountValue    int64
+	Species          *PetSpecies
+	MinAge           *int
+	MaxAge           *int
+	MinQuantity      int
+	StartsAt         *time.Time
+	EndsAt           *time.Time
+	UsageLimit       *int
+	PerCustomerLimit *int
+}
+
+// OrderDiscount is a discount line recorded on an order
+type OrderDiscount struct {
+	ID          uuid.UUID  `db:"id"`
+	OrderID     uuid.UUID  `db:"order_id"`
+	PromotionID uuid.UUID  `db:"promotion_id"`
+	Code        string     `db:"code"`
+	PetID       *uuid.UUID `db:"pet_id"`
+	AmountCents int64      `db:"amount_cents"`
+	Description string     `db:"description"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SalesGroupBy is the dimension sales are bucketed by
+type SalesGroupBy string
+
+const (
+	SalesGroupByDay     SalesGroupBy = "day"
+	SalesGroupByWeek    SalesGroupBy = "week"
+	SalesGroupByMonth   SalesGroupBy = "month"
+	SalesGroupBySpecies SalesGroupBy = "species"
+)
+
+// IsTimeBucket reports whether sales are grouped by a calendar period
+func (g SalesGroupBy) IsTimeBucket() bool {
+	return g == SalesGroupByDay || g == SalesGroupByWeek || g == SalesGroupByMonth
+}
+
+// SalesReportInput selects the sales of a store within [From, To)
+type SalesReportInput struct {
+	StoreID uuid.UUID
+	From    time.Time
+	To      time.Time
+	GroupBy SalesGroupBy
+}
+
+// SalesBucket aggregates the pets sold in one period or of one species.
+// Revenue is what the pets' orders were paid after discounts, split across each order's pets by price.
+type SalesBucket struct {
+	PeriodStart  *time.Time
+	Species      *PetSpecies
+	PetsSold     int
+	RevenueCents int64
+}
+
+// SalesSummary aggregates every sale in the report range
+type SalesSummary struct {
+	PetsSold           int
+	RevenueCents       int64
+	AverageDaysOnShelf *float64 // nil when nothing sold
+	TopSpecies         *PetSpecies
+	TopSpeciesPetsSold int
+}
+
+// SalesReport is a store's sales within a date range
+type SalesReport struct {
+	SalesReportInput
+	SalesSummary
+	Buckets []*SalesBucket
+}
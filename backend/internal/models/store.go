@@ -7,15 +7,73 @@ import (
 )
 
 type Store struct {
-	ID        uuid.UUID `db:"id"`
-	Name      string    `db:"name"`
-	OwnerID   string    `db:"owner_id"` // This will be the merchant's username
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID           uuid.UUID      `db:"id"`
+	Name         string         `db:"name"`
+	OwnerID      string         `db:"owner_id"` // This will be the merchant's username
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+	Slug         string         `db:"slug"`
+	Description  *string        `db:"description"`
+	Address      *string        `db:"address"`
+	Phone        *string        `db:"phone"`
+	LogoURL      *string        `db:"logo_url"`
+	OpeningHours []OpeningHours `db:"opening_hours"`
+	ClosedAt     *time.Time     `db:"closed_at"`
+	Latitude     *float64       `db:"latitude"`
+	Longitude    *float64       `db:"longitude"`
 }
 
+// IsOpen reports whether the store has not been closed
+func (s *Store) IsOpen() bool {
+	return s.ClosedAt == nil
+}
+
+// OpeningHours is the time window a store is open on a day of the week
+type OpeningHours struct {
+	Day    Weekday `json:"day"`
+	Opens  string  `json:"opens"`  // HH:MM
+	Closes string  `json:"closes"` // HH:MM
+}
+
+type Weekday string
+
+const (
+	WeekdayMonday    Weekday = "monday"
+	WeekdayTuesday   Weekday = "tuesday"
+	WeekdayWednesday Weekday = "wednesday"
+	WeekdayThursday  Weekday = "thursday"
+	WeekdayFriday    Weekday = "friday"
+	WeekdaySaturday  Weekday = "saturday"
+	WeekdaySunday    Weekday = "sunday"
+)
+
 type CreateStoreInput struct {
 	Name    string
 	OwnerID string
 }
 
+// UpdateStoreInput holds the store profile fields to change; nil fields are left untouched
+type UpdateStoreInput struct {
+	Name         *string
+	Slug         *string
+	Description  *string
+	Address      *string
+	Phone        *string
+	LogoURL      *string
+	OpeningHours []OpeningHours
+	Latitude     *float64
+	Longitude    *float64
+}
+
+// GeoRadius is a circle around a point used for proximity searches
+type GeoRadius struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
+}
+
+// NearbyStore is a store with its distance from the search point
+type NearbyStore struct {
+	Store      *Store
+	DistanceKm float64
+}
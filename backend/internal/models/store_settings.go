@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults used for stores that have not configured their settings
+const (
+	DefaultMaxPetsPerOrder      = 10
+	DefaultMaxPetAge            = 50
+	DefaultMaxPageSize          = 100
+	DefaultMinCustomerAge       = 0
+	DefaultReservationHoldHours = 72
+	DefaultShowBreederNames     = true
+	DefaultStaleAfterDays       = 30
+	DefaultTimezone             = "UTC"
+)
+
+// StoreSettings holds the business rules a merchant configures for a store
+type StoreSettings struct {
+	StoreID              uuid.UUID `db:"store_id"`
+	MaxPetsPerOrder      int       `db:"max_pets_per_order"`
+	MaxPetAge            int       `db:"max_pet_age"`
+	MaxPageSize          int       `db:"max_page_size"`
+	MinCustomerAge       int       `db:"min_customer_age"` // 0 means no age check
+	ReservationHoldHours int       `db:"reservation_hold_hours"`
+	ShowBreederNames     bool      `db:"show_breeder_names"`
+	StaleAfterDays       int       `db:"stale_after_days"` // available pets listed longer than this raise an alert
+	Timezone             string    `db:"timezone"`         // IANA name; pickup days and opening hours are in this zone
+	UpdatedAt            time.Time `db:"updated_at"`
+}
+
+// DefaultStoreSettings returns the settings of a store that has not configured any
+func DefaultStoreSettings(storeID uuid.UUID) *StoreSettings {
+	return &StoreSettings{
+		StoreID:              storeID,
+		MaxPetsPerOrder:      DefaultMaxPetsPerOrder,
+		MaxPetAge:            DefaultMaxPetAge,
+		MaxPageSize:          DefaultMaxPageSize,
+		MinCustomerAge:       DefaultMinCustomerAge,
+		ReservationHoldHours: DefaultReservationHoldHours,
+		ShowBreederNames:     DefaultShowBreederNames,
+		StaleAfterDays:       DefaultStaleAfterDays,
+		Timezone:             DefaultTimezone,
+	}
+}
+
+// ReservationHold is how long sold pets are held for pickup after the order
+func (s *StoreSettings) ReservationHold() time.Duration {
+	return time.Duration(s.ReservationHoldHours) * time.Hour
+}
+
+// Location is the store's time zone, falling back to UTC when it is unknown
+func (s *StoreSettings) Location() *time.Location {
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// UpdateStoreSettingsInput holds the settings to change; nil fields are left untouched
+type UpdateStoreSettingsInput struct {
+	MaxPetsPerOrder      *int
+	MaxPetAge            *int
+	MaxPageSize          *int
+	MinCustomerAge       *int
+	ReservationHoldHours *int
+	ShowBreederNames     *bool
+	StaleAfterDays       *int
+	Timezone             *string
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookEventType is the kind of store event a webhook endpoint can subscribe to
+type WebhookEventType string
+
+const (
+	WebhookEventPetCreated   WebhookEventType = "pet.created"
+	WebhookEventPetSold      WebhookEventType = "pet.sold"
+	WebhookEventOrderCreated WebhookEventType = "order.created"
+)
+
+// IsValid reports whether t is a known event type
+func (t WebhookEventType) IsValid() bool {
+	switch t {
+	case WebhookEventPetCreated, WebhookEventPetSold, WebhookEventOrderCreated:
+		return true
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is where a delivery stands in the retry queue
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed" // retries exhausted
+)
+
+// WebhookEndpoint is a URL a merchant registered to receive a store's events
+type WebhookEndpoint struct {
+	ID              uuid.UUID          `db:"id"`
+	StoreID         uuid.UUID          `db:"store_id"`
+	URL             string             `db:"url"`
+	SecretEncrypted string             `db:"secret_encrypted"`
+	EventTypes      []WebhookEventType `db:"event_types"`
+	Active          bool               `db:"active"`
+	CreatedAt       time.Time          `db:"created_at"`
+}
+
+// Subscribes reports whether the endpoint receives events of type t
+func (e *WebhookEndpoint) Subscribes(t WebhookEventType) bool {
+	for _, eventType := range e.EventTypes {
+		if eventType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateWebhookEndpointInput represents the input for registering a webhook endpoint
+type CreateWebhookEndpointInput struct {
+	StoreID    uuid.UUID
+	URL        string
+	EventTypes []WebhookEventType
+}
+
+// WebhookDelivery is one event sent, or still to be sent, to one endpoint
+type WebhookDelivery struct {
+	ID             uuid.UUID             `db:"id"`
+	EndpointID     uuid.UUID             `db:"endpoint_id"`
+	EventID        uuid.UUID             `db:"event_id"`
+	EventType      WebhookEventType      `db:"event_type"`
+	Payload        []byte                `db:"payload"`
+	Status         WebhookDeliveryStatus `db:"status"`
+	Attempts       int                   `db:"attempts"`
+	NextAttemptAt  time.Time             `db:"next_attempt_at"`
+	LastAttemptAt  *time.Time            `db:"last_attempt_at"`
+	ResponseStatus *int                  `db:"response_status"`
+	LastError      *string               `db:"last_error"`
+	CreatedAt      time.Time             `db:"created_at"`
+	DeliveredAt    *time.Time            `db:"delivered_at"`
+
+	// Set when the delivery is claimed for sending
+	URL             string `db:"url"`
+	SecretEncrypted string `db:"secret_encrypted"`
+}
+
+// WebhookDeliveryFilter narrows the delivery log of a store
+type WebhookDeliveryFilter struct {
+	StoreID    uuid.UUID
+	EndpointID *uuid.UUID
+	Status     *WebhookDeliveryStatus
+	Limit      int
+	Offset     int
+}
+
+// WebhookEvent is the JSON body of every delivery
+type WebhookEvent struct {
+	ID        uuid.UUID        `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	StoreID   uuid.UUID        `json:"storeId"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Data      json.RawMessage  `json:"data"`
+}
+
+// WebhookPetData is the data of a pet.created event; the breeder email is never sent
+type WebhookPetData struct {
+	ID          uuid.UUID  `json:"id"`
+	StoreID     uuid.UUID  `json:"storeId"`
+	Name        string     `json:"name"`
+	Species     PetSpecies `json:"species"`
+	Age         int        `json:"age"`
+	PictureURL  *string    `json:"pictureUrl"`
+	Description *string    `json:"description"`
+	BreederName string     `json:"breederName"`
+	PriceCents  int64      `json:"priceCents"`
+	Status      PetStatus  `json:"status"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// WebhookPetSoldData is the data of a pet.sold event
+type WebhookPetSoldData struct {
+	PetID   uuid.UUID `json:"petId"`
+	StoreID uuid.UUID `json:"storeId"`
+	OrderID uuid.UUID `json:"orderId"`
+	SoldAt  time.Time `json:"soldAt"`
+}
+
+// WebhookOrderData is the data of an order.created event
+type WebhookOrderData struct {
+	ID            uuid.UUID     `json:"id"`
+	StoreID       uuid.UUID     `json:"storeId"`
+	CustomerID    string        `json:"customerId"`
+	PetIDs        []uuid.UUID   `json:"petIds"`
+	TotalPets     int           `json:"totalPets"`
+	SubtotalCents int64         `json:"subtotalCents"`
+	DiscountCents int64         `json:"discountCents"`
+	TotalCents    int64         `json:"totalCents"`
+	PaymentStatus PaymentStatus `json:"paymentStatus"`
+	CreatedAt     time.Time     `json:"createdAt"`
+}
@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// Notifier delivers messages to merchants
+type Notifier interface {
+	// Notify sends a message to its recipient
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Message is a notification addressed to a single recipient
+type Message struct {
+	Recipient string // merchant username
+	Subject   string
+	Body      string
+}
+
+// Ensure LogNotifier implements Notifier interface
+var _ Notifier = (*LogNotifier)(nil)
+
+// LogNotifier writes notifications to a log instead of sending them
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier creates a notifier that writes messages to w
+func NewLogNotifier(w io.Writer) *LogNotifier {
+	return &LogNotifier{logger: log.New(w, "", log.LstdFlags)}
+}
+
+// Notify writes the message to the log
+func (n *LogNotifier) Notify(ctx context.Context, msg Message) error {
+	if msg.Recipient == "" {
+		return fmt.Errorf("notification has no recipient")
+	}
+
+	n.logger.Printf("notify %s: %s\n%s", msg.Recipient, msg.Subject, strings.TrimRight(msg.Body, "\n"))
+	return nil
+}
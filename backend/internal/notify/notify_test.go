@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogNotifier_Notify(t *testing.T) {
+	var buf bytes.Buffer
+	notifier := NewLogNotifier(&buf)
+
+	err := notifier.Notify(context.Background(), Message{
+		Recipient: "merchant1",
+		Subject:   "2 pets need attention",
+		Body:      "Rex has been listed for 45 days\n",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "notify merchant1: 2 pets need attention")
+	assert.Contains(t, buf.String(), "Rex has been listed for 45 days")
+
+	err = notifier.Notify(context.Background(), Message{Subject: "no one"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,228 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Magic card numbers understood by FakeProvider
+const (
+	CardApproved          = "4242424242424242"
+	CardDeclined          = "4000000000000002"
+	CardInsufficientFunds = "4000000000009995"
+	CardTimeout           = "4000000000000119"
+	CardCaptureFails      = "4000000000000341"
+)
+
+// Ensure FakeProvider implements PaymentProvider interface
+var _ PaymentProvider = (*FakeProvider)(nil)
+
+// FakeProvider is a deterministic in-process payment provider for development and tests
+type FakeProvider struct {
+	mu             sync.Mutex
+	secret         string
+	authorizations map[string]*fakeAuthorization
+	sequence       int
+}
+
+type fakeAuthorization struct {
+	Authorization
+	cardNumber    string
+	refundedCents int64
+}
+
+// NewFakeProvider creates a new fake payment provider
+func NewFakeProvider(webhookSecret string) *FakeProvider {
+	return &FakeProvider{
+		secret:         webhookSecret,
+		authorizations: make(map[string]*fakeAuthorization),
+	}
+}
+
+// Authorize approves or declines a payment based on the card number
+func (p *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (*Authorization, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch req.CardNumber {
+	case CardDeclined:
+		return nil, DeclinedError{Code: "card_declined", Message: "the card was declined"}
+	case CardInsufficientFunds:
+		return nil, DeclinedError{Code: "insufficient_funds", Message: "the card has insufficient funds"}
+	case CardTimeout:
+		return nil, ErrTimeout
+	case CardApproved, CardCaptureFails:
+	default:
+		return nil, DeclinedError{Code: "invalid_card", Message: "the card number is not recognized"}
+	}
+
+	if req.AmountCents <= 0 {
+		return nil, DeclinedError{Code: "invalid_amount", Message: "amount must be greater than zero"}
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sequence++
+	auth := &fakeAuthorization{
+		Authorization: Authorization{
+			ID:          fmt.Sprintf("fake_auth_%06d", p.sequence),
+			OrderID:     req.OrderID,
+			AmountCents: req.AmountCents,
+			Currency:    currency,
+			Status:      StatusAuthorized,
+			CreatedAt:   time.Now(),
+		},
+		cardNumber: req.CardNumber,
+	}
+	p.authorizations[auth.ID] = auth
+
+	result := auth.Authorization
+	return &result, nil
+}
+
+// Capture settles an authorized payment
+func (p *FakeProvider) Capture(ctx context.Context, authorizationID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+
+	if auth.Status == StatusCaptured {
+		return nil
+	}
+	if auth.Status != StatusAuthorized {
+		return fmt.Errorf("cannot capture authorization in status %s", auth.Status)
+	}
+
+	if auth.cardNumber == CardCaptureFails {
+		return DeclinedError{Code: "capture_failed", Message: "the issuer rejected the capture"}
+	}
+
+	auth.Status = StatusCaptured
+	return nil
+}
+
+// Refund refunds a captured payment
+func (p *FakeProvider) Refund(ctx context.Context, authorizationID string, amountCents int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+
+	switch auth.Status {
+	case StatusCaptured:
+		if amountCents <= 0 || auth.refundedCents+amountCents > auth.AmountCents {
+			return fmt.Errorf("refund amount %d exceeds captured amount", amountCents)
+		}
+		auth.refundedCents += amountCents
+		if auth.refundedCents == auth.AmountCents {
+			auth.Status = StatusRefunded
+		}
+		return nil
+	case StatusRefunded:
+		return nil
+	default:
+		return fmt.Errorf("cannot refund authorization in status %s", auth.Status)
+	}
+}
+
+// Void releases an uncaptured authorization
+func (p *FakeProvider) Void(ctx context.Context, authorizationID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok {
+		return ErrAuthorizationNotFound
+	}
+
+	switch auth.Status {
+	case StatusAuthorized:
+		auth.Status = StatusVoided
+		return nil
+	case StatusVoided:
+		return nil
+	default:
+		return fmt.Errorf("cannot void authorization in status %s", auth.Status)
+	}
+}
+
+// VerifyWebhook validates the signature of a webhook payload and decodes the event
+func (p *FakeProvider) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if !VerifySignature(p.secret, payload, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+
+	if event.Type == "" || event.AuthorizationID == "" {
+		return nil, fmt.Errorf("webhook payload is missing type or authorization ID")
+	}
+
+	return &event, nil
+}
+
+// NewWebhookEvent builds a signed webhook payload as the provider would send it
+func (p *FakeProvider) NewWebhookEvent(eventType, authorizationID string) ([]byte, string, error) {
+	p.mu.Lock()
+	p.sequence++
+	eventID := fmt.Sprintf("fake_evt_%06d", p.sequence)
+	p.mu.Unlock()
+
+	payload, err := json.Marshal(WebhookEvent{
+		ID:              eventID,
+		Type:            eventType,
+		AuthorizationID: authorizationID,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, Sign(p.secret, payload), nil
+}
+
+// GetAuthorization returns a copy of a stored authorization
+func (p *FakeProvider) GetAuthorization(authorizationID string) (*Authorization, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	auth, ok := p.authorizations[authorizationID]
+	if !ok {
+		return nil, ErrAuthorizationNotFound
+	}
+
+	result := auth.Authorization
+	return &result, nil
+}
@@ -0,0 +1,141 @@
+package payment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeProvider_Authorize(t *testing.T) {
+	tests := []struct {
+		name        string
+		cardNumber  string
+		amount      int64
+		wantErr     bool
+		wantDecline string
+		wantTimeout bool
+	}{
+		{name: "approved card", cardNumber: CardApproved, amount: 1500},
+		{name: "declined card", cardNumber: CardDeclined, amount: 1500, wantErr: true, wantDecline: "card_declined"},
+		{name: "insufficient funds", cardNumber: CardInsufficientFunds, amount: 1500, wantErr: true, wantDecline: "insufficient_funds"},
+		{name: "timeout", cardNumber: CardTimeout, amount: 1500, wantErr: true, wantTimeout: true},
+		{name: "unknown card", cardNumber: "1234", amount: 1500, wantErr: true, wantDecline: "invalid_card"},
+		{name: "zero amount", cardNumber: CardApproved, amount: 0, wantErr: true, wantDecline: "invalid_amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewFakeProvider("secret")
+
+			auth, err := provider.Authorize(context.Background(), AuthorizeRequest{
+				OrderID:     uuid.New(),
+				AmountCents: tt.amount,
+				CardNumber:  tt.cardNumber,
+			})
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+				assert.Equal(t, StatusAuthorized, auth.Status)
+				assert.Equal(t, DefaultCurrency, auth.Currency)
+				return
+			}
+
+			assert.Error(t, err)
+			assert.Nil(t, auth)
+			if tt.wantTimeout {
+				assert.ErrorIs(t, err, ErrTimeout)
+			}
+			if tt.wantDecline != "" {
+				var declined DeclinedError
+				require.ErrorAs(t, err, &declined)
+				assert.Equal(t, tt.wantDecline, declined.Code)
+			}
+		})
+	}
+}
+
+func TestFakeProvider_CaptureAndRefund(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider("secret")
+
+	auth, err := provider.Authorize(ctx, AuthorizeRequest{OrderID: uuid.New(), AmountCents: 2000, CardNumber: CardApproved})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Capture(ctx, auth.ID))
+	stored, err := provider.GetAuthorization(auth.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCaptured, stored.Status)
+
+	assert.Error(t, provider.Refund(ctx, auth.ID, 5000))
+	require.NoError(t, provider.Refund(ctx, auth.ID, 2000))
+	stored, err = provider.GetAuthorization(auth.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusRefunded, stored.Status)
+}
+
+func TestFakeProvider_Void(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider("secret")
+
+	auth, err := provider.Authorize(ctx, AuthorizeRequest{OrderID: uuid.New(), AmountCents: 2000, CardNumber: CardApproved})
+	require.NoError(t, err)
+
+	assert.Error(t, provider.Refund(ctx, auth.ID, auth.AmountCents))
+	require.NoError(t, provider.Void(ctx, auth.ID))
+	require.NoError(t, provider.Void(ctx, auth.ID))
+	stored, err := provider.GetAuthorization(auth.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusVoided, stored.Status)
+	assert.Error(t, provider.Capture(ctx, auth.ID))
+
+	captured, err := provider.Authorize(ctx, AuthorizeRequest{OrderID: uuid.New(), AmountCents: 2000, CardNumber: CardApproved})
+	require.NoError(t, err)
+	require.NoError(t, provider.Capture(ctx, captured.ID))
+	assert.Error(t, provider.Void(ctx, captured.ID))
+	assert.ErrorIs(t, provider.Void(ctx, "unknown"), ErrAuthorizationNotFound)
+}
+
+func TestFakeProvider_CaptureFails(t *testing.T) {
+	ctx := context.Background()
+	provider := NewFakeProvider("secret")
+
+	auth, err := provider.Authorize(ctx, AuthorizeRequest{OrderID: uuid.New(), AmountCents: 2000, CardNumber: CardCaptureFails})
+	require.NoError(t, err)
+
+	err = provider.Capture(ctx, auth.ID)
+	var declined DeclinedError
+	require.ErrorAs(t, err, &declined)
+	assert.Equal(t, "capture_failed", declined.Code)
+
+	assert.ErrorIs(t, provider.Capture(ctx, "unknown"), ErrAuthorizationNotFound)
+}
+
+func TestFakeProvider_VerifyWebhook(t *testing.T) {
+	provider := NewFakeProvider("secret")
+
+	payload, signature, err := provider.NewWebhookEvent(EventPaymentCaptured, "fake_auth_000001")
+	require.NoError(t, err)
+
+	event, err := provider.VerifyWebhook(payload, signature)
+	require.NoError(t, err)
+	assert.Equal(t, EventPaymentCaptured, event.Type)
+	assert.Equal(t, "fake_auth_000001", event.AuthorizationID)
+
+	_, err = provider.VerifyWebhook(payload, Sign("other-secret", payload))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	_, err = provider.VerifyWebhook(payload, "not-hex")
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider("fake", "secret")
+	require.NoError(t, err)
+	assert.IsType(t, &FakeProvider{}, provider)
+
+	_, err = NewProvider("unknown", "secret")
+	assert.Error(t, err)
+}
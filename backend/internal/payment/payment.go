@@ -0,0 +1,126 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCurrency is the currency used for all store charges
+const DefaultCurrency = "USD"
+
+// PaymentProvider defines the interface for payment processing operations
+type PaymentProvider interface {
+	// Authorize places a hold for the given amount without moving funds
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Authorization, error)
+
+	// Capture settles a previously authorized hold
+	Capture(ctx context.Context, authorizationID string) error
+
+	// Refund returns funds for a captured payment
+	Refund(ctx context.Context, authorizationID string, amountCents int64) error
+
+	// Void releases an uncaptured hold so the customer is never charged
+	Void(ctx context.Context, authorizationID string) error
+
+	// VerifyWebhook checks the signature of a provider callback and decodes it
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+// AuthorizeRequest holds the data needed to authorize a payment
+type AuthorizeRequest struct {
+	OrderID     uuid.UUID
+	AmountCents int64
+	Currency    string
+	CardNumber  string
+}
+
+// Status represents the lifecycle state of an authorization at the provider
+type Status string
+
+const (
+	StatusAuthorized Status = "authorized"
+	StatusCaptured   Status = "captured"
+	StatusRefunded   Status = "refunded"
+	StatusVoided     Status = "voided"
+)
+
+// Authorization is the provider's record of an authorized payment
+type Authorization struct {
+	ID          string
+	OrderID     uuid.UUID
+	AmountCents int64
+	Currency    string
+	Status      Status
+	CreatedAt   time.Time
+}
+
+// Webhook event types sent by providers
+const (
+	EventPaymentCaptured = "payment.captured"
+	EventPaymentRefunded = "payment.refunded"
+	EventPaymentFailed   = "payment.failed"
+)
+
+// WebhookEvent is a verified notification received from a provider
+type WebhookEvent struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`
+	AuthorizationID string    `json:"authorizationId"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+var (
+	// ErrTimeout is returned when the provider does not answer in time
+	ErrTimeout = errors.New("payment provider timed out")
+
+	// ErrInvalidSignature is returned when a webhook signature does not match
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+
+	// ErrAuthorizationNotFound is returned for unknown authorization IDs
+	ErrAuthorizationNotFound = errors.New("authorization not found")
+)
+
+// DeclinedError represents a card declined by the provider
+type DeclinedError struct {
+	Code    string
+	Message string
+}
+
+func (e DeclinedError) Error() string {
+	return fmt.Sprintf("payment declined (%s): %s", e.Code, e.Message)
+}
+
+// NewProvider creates the payment provider selected in configuration
+func NewProvider(name, webhookSecret string) (PaymentProvider, error) {
+	switch name {
+	case "", "fake":
+		return NewFakeProvider(webhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unsupported payment provider: %s", name)
+	}
+}
+
+// Sign computes the hex encoded HMAC-SHA256 signature of a webhook payload
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature matches the payload for the given secret
+func VerifySignature(secret string, payload []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
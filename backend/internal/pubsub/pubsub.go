@@ -0,0 +1,91 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// subscriberBuffer is how many undelivered messages a subscriber may hold before new ones are dropped
+const subscriberBuffer = 16
+
+// Broker delivers messages published on a channel to every current subscriber of that channel
+type Broker interface {
+	Publish(ctx context.Context, channel string, message []byte) error
+	// Subscribe delivers messages until ctx is done, then closes the returned channel
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
+
+// Ensure the brokers implement Broker
+var (
+	_ Broker = (*MemoryBroker)(nil)
+	_ Broker = (*RedisBroker)(nil)
+)
+
+// NewBroker creates the broker selected in configuration. Redis is required when
+// more than one replica serves subscriptions.
+func NewBroker(name string, client *redis.Client) (Broker, error) {
+	switch name {
+	case "", "redis":
+		return NewRedisBroker(client), nil
+	case "memory":
+		return NewMemoryBroker(), nil
+	default:
+		return nil, fmt.Errorf("unsupported pub/sub backend: %s", name)
+	}
+}
+
+// MemoryBroker is a Broker for a single process
+type MemoryBroker struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+// NewMemoryBroker creates an in-memory broker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{subscribers: map[string]map[chan []byte]struct{}{}}
+}
+
+// Publish sends the message to the channel's subscribers. A subscriber that is not
+// keeping up misses the message rather than blocking the publisher.
+func (b *MemoryBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for sub := range b.subscribers[channel] {
+		select {
+		case sub <- message:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber on the channel until ctx is done
+func (b *MemoryBroker) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := make(chan []byte, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[channel] == nil {
+		b.subscribers[channel] = map[chan []byte]struct{}{}
+	}
+	b.subscribers[channel][sub] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers[channel], sub)
+		if len(b.subscribers[channel]) == 0 {
+			delete(b.subscribers, channel)
+		}
+		b.mu.Unlock()
+
+		close(sub)
+	}()
+
+	return sub, nil
+}
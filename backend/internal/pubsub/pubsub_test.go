@@ -0,0 +1,81 @@
+package pubsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, messages <-chan []byte) []byte {
+	t.Helper()
+	select {
+	case msg := <-messages:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+		return nil
+	}
+}
+
+func TestMemoryBroker_PublishSubscribe(t *testing.T) {
+	broker := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, err := broker.Subscribe(ctx, "pets")
+	require.NoError(t, err)
+	second, err := broker.Subscribe(ctx, "pets")
+	require.NoError(t, err)
+	other, err := broker.Subscribe(ctx, "orders")
+	require.NoError(t, err)
+
+	require.NoError(t, broker.Publish(ctx, "pets", []byte("sold")))
+
+	assert.Equal(t, []byte("sold"), receive(t, first))
+	assert.Equal(t, []byte("sold"), receive(t, second))
+	select {
+	case msg := <-other:
+		t.Fatalf("unexpected message on other channel: %s", msg)
+	default:
+	}
+}
+
+func TestMemoryBroker_UnsubscribeOnCancel(t *testing.T) {
+	broker := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	messages, err := broker.Subscribe(ctx, "pets")
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case _, ok := <-messages:
+		assert.False(t, ok, "channel should be closed")
+	case <-time.After(time.Second):
+		t.Fatal("channel was not closed")
+	}
+
+	assert.Eventually(t, func() bool {
+		broker.mu.RLock()
+		defer broker.mu.RUnlock()
+		return len(broker.subscribers) == 0
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, broker.Publish(context.Background(), "pets", []byte("sold")))
+}
+
+func TestMemoryBroker_SlowSubscriberDoesNotBlock(t *testing.T) {
+	broker := NewMemoryBroker()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	messages, err := broker.Subscribe(ctx, "pets")
+	require.NoError(t, err)
+
+	for i := 0; i < subscriberBuffer*2; i++ {
+		require.NoError(t, broker.Publish(ctx, "pets", []byte("event")))
+	}
+	assert.Len(t, messages, subscriberBuffer)
+}
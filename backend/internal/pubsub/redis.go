@@ -0,0 +1,60 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RedisBroker is a Broker backed by Redis pub/sub, so messages reach subscribers on every replica
+type RedisBroker struct {
+	client *redis.Client
+}
+
+// NewRedisBroker creates a broker on an existing Redis client
+func NewRedisBroker(client *redis.Client) *RedisBroker {
+	return &RedisBroker{client: client}
+}
+
+// Publish sends the message to the channel's subscribers on all replicas
+func (b *RedisBroker) Publish(ctx context.Context, channel string, message []byte) error {
+	if err := b.client.Publish(ctx, channel, message).Err(); err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Subscribe listens on the channel until ctx is done. It returns once Redis has
+// confirmed the subscription, so no message published afterwards is missed.
+func (b *RedisBroker) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := b.client.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		sub.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+
+	messages := make(chan []byte, subscriberBuffer)
+	go func() {
+		defer close(messages)
+		defer sub.Close()
+
+		incoming := sub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-incoming:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				default:
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
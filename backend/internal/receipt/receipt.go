@@ -0,0 +1,139 @@
+package receipt
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+// Format identifies the output format of a rendered receipt
+type Format string
+
+const (
+	FormatHTML Format = "html"
+	FormatPDF  Format = "pdf"
+)
+
+// ParseFormat converts a user supplied format name into a Format
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatHTML:
+		return FormatHTML, nil
+	case FormatPDF:
+		return FormatPDF, nil
+	default:
+		return "", fmt.Errorf("unsupported receipt format: %s", value)
+	}
+}
+
+// Item is a purchased pet line on a receipt
+type Item struct {
+	Name       string
+	Species    string
+	Age        int
+	PriceCents int64
+}
+
+// Discount is a discount line on a receipt
+type Discount struct {
+	Code        string
+	Description string
+	AmountCents int64
+}
+
+// Receipt holds everything printed on an order receipt
+type Receipt struct {
+	OrderID       uuid.UUID
+	StoreName     string
+	CustomerID    string
+	PaymentStatus string
+	OrderedAt     time.Time
+	IssuedAt      time.Time
+	Items         []Item
+	Discounts     []Discount
+	SubtotalCents int64
+	DiscountCents int64
+	TotalCents    int64
+}
+
+// Document is a rendered receipt ready to be served
+type Document struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+var funcs = map[string]any{
+	"money": FormatCents,
+	"datetime": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 MST")
+	},
+	"pad": func(width int, value string) string {
+		return fmt.Sprintf("%-*s", width, truncate(value, width))
+	},
+	"lpad": func(width int, value string) string {
+		return fmt.Sprintf("%*s", width, truncate(value, width))
+	},
+}
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("receipt.html.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/receipt.html.tmpl"))
+	textTemplate = texttemplate.Must(texttemplate.New("receipt.txt.tmpl").Funcs(funcs).ParseFS(templateFS, "templates/receipt.txt.tmpl"))
+)
+
+// Render renders a receipt in the requested format
+func Render(receipt *Receipt, format Format) (*Document, error) {
+	var buf bytes.Buffer
+	filename := "receipt-" + receipt.OrderID.String()
+
+	switch format {
+	case FormatHTML:
+		if err := htmlTemplate.Execute(&buf, receipt); err != nil {
+			return nil, fmt.Errorf("failed to render receipt: %w", err)
+		}
+		return &Document{
+			Filename:    filename + ".html",
+			ContentType: "text/html; charset=utf-8",
+			Content:     buf.Bytes(),
+		}, nil
+	case FormatPDF:
+		if err := textTemplate.Execute(&buf, receipt); err != nil {
+			return nil, fmt.Errorf("failed to render receipt: %w", err)
+		}
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		return &Document{
+			Filename:    filename + ".pdf",
+			ContentType: "application/pdf",
+			Content:     writePDF("Receipt "+receipt.OrderID.String(), lines),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported receipt format: %s", format)
+	}
+}
+
+// FormatCents formats an amount in cents as dollars
+func FormatCents(cents int64) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
+}
+
+func truncate(value string, width int) string {
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	return string(runes[:width-1]) + "~"
+}
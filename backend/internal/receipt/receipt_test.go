@@ -0,0 +1,133 @@
+package receipt
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleReceipt() *Receipt {
+	return &Receipt{
+		OrderID:       uuid.MustParse("6f1c2a9e-3b7d-4c55-9a11-2f0e8d4b7c01"),
+		StoreName:     "Paws & Claws",
+		CustomerID:    "customer1",
+		PaymentStatus: "captured",
+		OrderedAt:     time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC),
+		IssuedAt:      time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC),
+		Items: []Item{
+			{Name: "Luna", Species: "Cat", Age: 2, PriceCents: 15000},
+			{Name: "<script>alert(1)</script>", Species: "Dog", Age: 4, PriceCents: 25050},
+		},
+		Discounts:     []Discount{{Code: "SPRING10", Description: "10% off Luna", AmountCents: 1500}},
+		SubtotalCents: 40050,
+		DiscountCents: 1500,
+		TotalCents:    38550,
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "", want: FormatHTML},
+		{input: "html", want: FormatHTML},
+		{input: "PDF", want: FormatPDF},
+		{input: "docx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRender_HTML(t *testing.T) {
+	doc, err := Render(sampleReceipt(), FormatHTML)
+	require.NoError(t, err)
+
+	html := string(doc.Content)
+	assert.Equal(t, "text/html; charset=utf-8", doc.ContentType)
+	assert.Equal(t, "receipt-6f1c2a9e-3b7d-4c55-9a11-2f0e8d4b7c01.html", doc.Filename)
+	assert.Contains(t, html, "Paws &amp; Claws")
+	assert.Contains(t, html, "customer1")
+	assert.Contains(t, html, "Luna")
+	assert.Contains(t, html, "$400.50")
+	assert.Contains(t, html, "-$15.00")
+	assert.Contains(t, html, "$385.50")
+	assert.Contains(t, html, "2025-03-01 10:30 UTC")
+	assert.NotContains(t, html, "<script>")
+}
+
+func TestRender_PDF(t *testing.T) {
+	doc, err := Render(sampleReceipt(), FormatPDF)
+	require.NoError(t, err)
+
+	pdf := doc.Content
+	assert.Equal(t, "application/pdf", doc.ContentType)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "(Paws & Claws) '")
+	assert.Contains(t, string(pdf), "$385.50")
+	assertValidXref(t, pdf)
+}
+
+func TestRender_PDFPaginates(t *testing.T) {
+	r := sampleReceipt()
+	for i := 0; i < 2*linesPerPage; i++ {
+		r.Items = append(r.Items, Item{Name: fmt.Sprintf("Pet %d", i), Species: "Fish", PriceCents: 100})
+	}
+
+	doc, err := Render(r, FormatPDF)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(doc.Content), "/Count 3")
+	assertValidXref(t, doc.Content)
+}
+
+func TestEscapePDFString(t *testing.T) {
+	assert.Equal(t, `a\(b\)\\c`, escapePDFString(`a(b)\c`))
+	assert.Equal(t, `caf\351`, escapePDFString("café"))
+	assert.Equal(t, "dog ?", escapePDFString("dog 🐶"))
+}
+
+func TestFormatCents(t *testing.T) {
+	assert.Equal(t, "$0.00", FormatCents(0))
+	assert.Equal(t, "$1.05", FormatCents(105))
+	assert.Equal(t, "-$12.30", FormatCents(-1230))
+}
+
+// assertValidXref checks that every xref entry points at its object header
+func assertValidXref(t *testing.T, pdf []byte) {
+	t.Helper()
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, startxref)
+	xrefOffset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(pdf[xrefOffset:], []byte("xref\n")))
+
+	lines := strings.Split(string(pdf[xrefOffset:]), "\n")
+	var count int
+	fmt.Sscanf(lines[1], "0 %d", &count)
+	for i := 1; i < count; i++ {
+		offset, _ := strconv.Atoi(lines[2+i][:10])
+		header := fmt.Sprintf("%d 0 obj\n", i)
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(header)), "object %d offset", i)
+	}
+}
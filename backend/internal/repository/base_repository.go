@@ -3,8 +3,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"github.com/fehepe/pet-store/backend/internal/database"
+	"github.com/lib/pq"
+)
+
+// Postgres error codes of the constraint violations callers map to conflicts
+const (
+	uniqueViolation    pq.ErrorCode = "23505"
+	exclusionViolation pq.ErrorCode = "23P01"
 )
 
 // BaseRepository provides common repository functionality
@@ -43,3 +51,18 @@ func (r *BaseRepository) QueryInsertWithTx(ctx context.Context, tx *sql.Tx, quer
 func (r *BaseRepository) QueryInsert(ctx context.Context, query string, args ...any) *sql.Row {
 	return r.db.QueryRowContext(ctx, query, args...)
 }
+
+// IsUniqueViolation reports whether err was caused by a row violating the named unique constraint or index
+func IsUniqueViolation(err error, constraint string) bool {
+	return isConstraintViolation(err, uniqueViolation, constraint)
+}
+
+// IsExclusionViolation reports whether err was caused by a row violating the named exclusion constraint
+func IsExclusionViolation(err error, constraint string) bool {
+	return isConstraintViolation(err, exclusionViolation, constraint)
+}
+
+func isConstraintViolation(err error, code pq.ErrorCode, constraint string) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == code && pqErr.Constraint == constraint
+}
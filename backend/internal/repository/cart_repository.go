@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// CartRepositoryInterface defines the interface for cart data operations
+type CartRepositoryInterface interface {
+	Get(ctx context.Context, customerID string) (*models.Cart, error)
+	GetOrCreate(ctx context.Context, customerID string) (*models.Cart, error)
+	GetItems(ctx context.Context, cartID uuid.UUID) ([]*models.CartItem, error)
+	AddItem(ctx context.Context, cartID, petID uuid.UUID) error
+	RemoveItem(ctx context.Context, cartID, petID uuid.UUID) error
+}
+
+// CartRepository implements CartRepositoryInterface
+type CartRepository struct {
+	BaseRepository
+}
+
+// NewCartRepository creates a new cart repository
+func NewCartRepository(db database.Repository) CartRepositoryInterface {
+	return &CartRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Get retrieves the customer's cart, or a NotFoundError when they never added a pet
+func (r *CartRepository) Get(ctx context.Context, customerID string) (*models.Cart, error) {
+	query := `
+		SELECT id, customer_id, created_at, updated_at
+		FROM carts
+		WHERE customer_id = $1`
+
+	var cart models.Cart
+	err := r.DB().QueryRowContext(ctx, query, customerID).Scan(&cart.ID, &cart.CustomerID, &cart.CreatedAt, &cart.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NotFoundError{Resource: "cart", ID: customerID}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	return &cart, nil
+}
+
+// GetOrCreate retrieves the customer's cart, creating an empty one if needed
+func (r *CartRepository) GetOrCreate(ctx context.Context, customerID string) (*models.Cart, error) {
+	query := `
+		INSERT INTO carts (id, customer_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $3)
+		ON CONFLICT (customer_id) DO UPDATE SET customer_id = EXCLUDED.customer_id
+		RETURNING id, customer_id, created_at, updated_at`
+
+	var cart models.Cart
+	row := r.QueryInsert(ctx, query, uuid.New(), customerID, time.Now())
+	err := row.Scan(&cart.ID, &cart.CustomerID, &cart.CreatedAt, &cart.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cart: %w", err)
+	}
+
+	return &cart, nil
+}
+
+// GetItems retrieves the items of a cart together with their current pet state
+func (r *CartRepository) GetItems(ctx context.Context, cartID uuid.UUID) ([]*models.CartItem, error) {
+	query := `
+		SELECT ci.id, ci.cart_id, ci.pet_id, ci.added_at,
+			   p.id, p.store_id, p.name, p.species, p.age, p.picture_url, p.description,
+			   p.breeder_name, p.breeder_email_encrypted, p.status, p.created_at, p.updated_at, p.price_cents
+		FROM cart_items ci
+		JOIN pets p ON p.id = ci.pet_id
+		WHERE ci.cart_id = $1
+		ORDER BY ci.added_at`
+
+	rows, err := r.DB().QueryContext(ctx, query, cartID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cart items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.CartItem{}
+	for rows.Next() {
+		var item models.CartItem
+		var pet models.Pet
+		err := rows.Scan(
+			&item.ID, &item.CartID, &item.PetID, &item.AddedAt,
+			&pet.ID, &pet.StoreID, &pet.Name, &pet.Species, &pet.Age,
+			&pet.PictureURL, &pet.Description, &pet.BreederName,
+			&pet.BreederEmailEncrypted, &pet.Status, &pet.CreatedAt, &pet.UpdatedAt, &pet.PriceCents,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan cart item: %w", err)
+		}
+		item.Pet = &pet
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cart item rows: %w", err)
+	}
+
+	return items, nil
+}
+
+// AddItem adds a pet to a cart, ignoring pets that are already in it
+func (r *CartRepository) AddItem(ctx context.Context, cartID, petID uuid.UUID) error {
+	query := `
+		INSERT INTO cart_items (id, cart_id, pet_id, added_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (cart_id, pet_id) DO NOTHING`
+
+	if _, err := r.DB().ExecContext(ctx, query, uuid.New(), cartID, petID, time.Now()); err != nil {
+		return fmt.Errorf("failed to add cart item: %w", err)
+	}
+
+	return r.touch(ctx, cartID)
+}
+
+// RemoveItem removes a pet from a cart
+func (r *CartRepository) RemoveItem(ctx context.Context, cartID, petID uuid.UUID) error {
+	query := `DELETE FROM cart_items WHERE cart_id = $1 AND pet_id = $2`
+	if _, err := r.DB().ExecContext(ctx, query, cartID, petID); err != nil {
+		return fmt.Errorf("failed to remove cart item: %w", err)
+	}
+
+	return r.touch(ctx, cartID)
+}
+
+// touch bumps the cart's updated_at timestamp
+func (r *CartRepository) touch(ctx context.Context, cartID uuid.UUID) error {
+	query := `UPDATE carts SET updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	if _, err := r.DB().ExecContext(ctx, query, cartID); err != nil {
+		return fmt.Errorf("failed to update cart: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// daysListed is the number of whole days a pet has been listed as of $2
+const daysListed = `FLOOR(EXTRACT(EPOCH FROM ($2 - p.created_at)) / 86400)::int`
+
+// InventoryRepositoryInterface defines the interface for inventory aging data operations
+type InventoryRepositoryInterface interface {
+	AgingBuckets(ctx context.Context, storeID uuid.UUID, asOf time.Time, staleAfterDays int) ([]models.AgingBucket, int, error)
+	ListStalePets(ctx context.Context, asOf time.Time) ([]*models.StalePet, error)
+	CreateAlerts(ctx context.Context, pets []*models.StalePet) ([]*models.InventoryAlert, error)
+	ListAlerts(ctx context.Context, storeID uuid.UUID, limit, offset int) ([]*models.InventoryAlert, int, error)
+}
+
+// InventoryRepository implements InventoryRepositoryInterface
+type InventoryRepository struct {
+	BaseRepository
+}
+
+// NewInventoryRepository creates a new inventory repository
+func NewInventoryRepository(db database.Repository) InventoryRepositoryInterface {
+	return &InventoryRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// AgingBuckets counts a store's available pets per models.InventoryAgingRanges band,
+// along with how many have been listed for longer than staleAfterDays
+func (r *InventoryRepository) AgingBuckets(ctx context.Context, storeID uuid.UUID, asOf time.Time, staleAfterDays int) ([]models.AgingBucket, int, error) {
+	query := `
+		SELECT ` + agingRangeCase("days") + ` AS bucket, COUNT(*), COALESCE(SUM(price_cents), 0),
+			COUNT(*) FILTER (WHERE days > $3)
+		FROM (
+			SELECT ` + daysListed + ` AS days, p.price_cents
+			FROM pets p
+			WHERE p.store_id = $1 AND p.status = $4
+		) listed
+		GROUP BY bucket`
+
+	rows, err := r.DB().QueryContext(ctx, query, storeID, asOf, staleAfterDays, models.PetStatusAvailable)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query inventory aging: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := make([]models.AgingBucket, len(models.InventoryAgingRanges))
+	for i, agingRange := range models.InventoryAgingRanges {
+		buckets[i].AgingRange = agingRange
+	}
+
+	stale := 0
+	for rows.Next() {
+		var index, count, staleCount int
+		var valueCents int64
+		if err := rows.Scan(&index, &count, &valueCents, &staleCount); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan aging bucket: %w", err)
+		}
+		buckets[index].PetCount = count
+		buckets[index].ValueCents = valueCents
+		stale += staleCount
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating aging rows: %w", err)
+	}
+
+	return buckets, stale, nil
+}
+
+// ListStalePets returns available pets in every store that have been listed longer than
+// the store's threshold and have not been alerted on yet
+func (r *InventoryRepository) ListStalePets(ctx context.Context, asOf time.Time) ([]*models.StalePet, error) {
+	query := `
+		SELECT p.id, p.name, s.id, s.name, s.owner_id, ` + daysListed + `
+		FROM pets p
+		JOIN stores s ON s.id = p.store_id
+		LEFT JOIN store_settings ss ON ss.store_id = s.id
+		LEFT JOIN inventory_alerts a ON a.pet_id = p.id
+		WHERE p.status = $1
+		  AND a.id IS NULL
+		  AND ` + daysListed + ` > COALESCE(ss.stale_after_days, $3)
+		ORDER BY s.id, p.created_at ASC`
+
+	rows, err := r.DB().QueryContext(ctx, query, models.PetStatusAvailable, asOf, models.DefaultStaleAfterDays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale pets: %w", err)
+	}
+	defer rows.Close()
+
+	var pets []*models.StalePet
+	for rows.Next() {
+		var pet models.StalePet
+		if err := rows.Scan(&pet.PetID, &pet.PetName, &pet.StoreID, &pet.StoreName, &pet.OwnerID, &pet.DaysListed); err != nil {
+			return nil, fmt.Errorf("failed to scan stale pet: %w", err)
+		}
+		pets = append(pets, &pet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating stale pets: %w", err)
+	}
+
+	return pets, nil
+}
+
+// CreateAlerts records an alert per stale pet and returns the ones that were new
+func (r *InventoryRepository) CreateAlerts(ctx context.Context, pets []*models.StalePet) ([]*models.InventoryAlert, error) {
+	query := `
+		INSERT INTO inventory_alerts (store_id, pet_id, days_listed)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pet_id) DO NOTHING
+		RETURNING id, created_at`
+
+	var alerts []*models.InventoryAlert
+	err := r.Transaction(func(tx *sql.Tx) error {
+		for _, pet := range pets {
+			alert := models.InventoryAlert{
+				StoreID:    pet.StoreID,
+				PetID:      pet.PetID,
+				PetName:    pet.PetName,
+				DaysListed: pet.DaysListed,
+			}
+			err := r.QueryInsertWithTx(ctx, tx, query, pet.StoreID, pet.PetID, pet.DaysListed).Scan(&alert.ID, &alert.CreatedAt)
+			if err == sql.ErrNoRows {
+				continue // Already alerted by a concurrent run
+			} else if err != nil {
+				return err
+			}
+			alerts = append(alerts, &alert)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inventory alerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// ListAlerts returns the alerts of a store whose pets are still unsold, newest first
+func (r *InventoryRepository) ListAlerts(ctx context.Context, storeID uuid.UUID, limit, offset int) ([]*models.InventoryAlert, int, error) {
+	from := `
+		FROM inventory_alerts a
+		JOIN pets p ON p.id = a.pet_id
+		WHERE a.store_id = $1 AND p.status = $2`
+
+	var totalCount int
+	if err := r.DB().QueryRowContext(ctx, "SELECT COUNT(*)"+from, storeID, models.PetStatusAvailable).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count inventory alerts: %w", err)
+	}
+
+	query := `SELECT a.id, a.store_id, a.pet_id, p.name, a.days_listed, a.created_at` + from + `
+		ORDER BY a.created_at DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.DB().QueryContext(ctx, query, storeID, models.PetStatusAvailable, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list inventory alerts: %w", err)
+	}
+	defer rows.Close()
+
+	alerts := []*models.InventoryAlert{}
+	for rows.Next() {
+		var alert models.InventoryAlert
+		if err := rows.Scan(&alert.ID, &alert.StoreID, &alert.PetID, &alert.PetName, &alert.DaysListed, &alert.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan inventory alert: %w", err)
+		}
+		alerts = append(alerts, &alert)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating inventory alerts: %w", err)
+	}
+
+	return alerts, totalCount, nil
+}
+
+// agingRangeCase builds a CASE expression mapping a day count to its index in
+// models.InventoryAgingRanges. The bounds are constants, never user input.
+func agingRangeCase(days string) string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	last := len(models.InventoryAgingRanges) - 1
+	for i, agingRange := range models.InventoryAgingRanges[:last] {
+		fmt.Fprintf(&b, " WHEN %s <= %d THEN %d", days, *agingRange.MaxDays, i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", last)
+	return b.String()
+}
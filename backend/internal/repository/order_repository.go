@@ -4,18 +4,36 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/database"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
 	"github.com/fehepe/pet-store/backend/internal/models"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// orderColumns lists the order columns in the order expected by scanOrderInto
+const orderColumns = `id, customer_id, store_id, total_pets, created_at, total_cents, payment_id, payment_status, discount_cents, status`
+
 // OrderRepositoryInterface defines the interface for order data operations
 type OrderRepositoryInterface interface {
 	CreateWithTx(ctx context.Context, tx *sql.Tx, order *models.Order) error
 	CreateItem(ctx context.Context, tx *sql.Tx, item *models.OrderItem) error
+	GetByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
+	GetByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]*models.Order, error)
+	GetByIDForUpdateWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*models.Order, error)
+	GetByPaymentID(ctx context.Context, paymentID string) (*models.Order, error)
+	ListAwaitingPayment(ctx context.Context, createdBefore time.Time, limit int) ([]*models.Order, error)
 	GetOrderPets(ctx context.Context, orderID uuid.UUID) ([]*models.Pet, error)
+	GetPetsByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID][]*models.Pet, error)
+	GetItemsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) ([]*models.OrderItem, error)
 	UpdateWithTx(ctx context.Context, tx *sql.Tx, order *models.Order) error
+	UpdateStatusWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, status models.OrderStatus) error
+	ReleaseItemsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, releasedAt time.Time) error
+	EnsureStoreOpenWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) error
+	Stream(ctx context.Context, filter models.OrderFilter, fn func(*models.Order) error) error
 	Transaction(fn func(*sql.Tx) error) error
 }
 
@@ -34,17 +52,23 @@ func NewOrderRepository(db database.Repository) OrderRepositoryInterface {
 // CreateWithTx inserts a new order within a transaction
 func (r *OrderRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, order *models.Order) error {
 	query := `
-		INSERT INTO orders (id, customer_id, store_id, total_pets, created_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING *`
+		INSERT INTO orders (id, customer_id, store_id, total_pets, created_at, total_cents, payment_id, payment_status, discount_cents, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + orderColumns
+
+	if order.PaymentStatus == "" {
+		order.PaymentStatus = models.PaymentStatusNone
+	}
+	if order.Status == "" {
+		order.Status = models.OrderStatusPlaced
+	}
 
 	row := r.QueryInsertWithTx(ctx, tx, query,
 		order.ID, order.CustomerID, order.StoreID, order.TotalPets, order.CreatedAt,
+		order.TotalCents, order.PaymentID, order.PaymentStatus, order.DiscountCents, order.Status,
 	)
 
-	return row.Scan(
-		&order.ID, &order.CustomerID, &order.StoreID, &order.TotalPets, &order.CreatedAt,
-	)
+	return scanOrderInto(row, order)
 }
 
 // CreateItem inserts a new order item within a transaction
@@ -62,7 +86,7 @@ func (r *OrderRepository) CreateItem(ctx context.Context, tx *sql.Tx, item *mode
 func (r *OrderRepository) GetOrderPets(ctx context.Context, orderID uuid.UUID) ([]*models.Pet, error) {
 	query := `
 		SELECT p.id, p.store_id, p.name, p.species, p.age, p.picture_url, p.description,
-			   p.breeder_name, p.breeder_email_encrypted, p.status, p.created_at, p.updated_at
+			   p.breeder_name, p.breeder_email_encrypted, p.status, p.created_at, p.updated_at, p.price_cents
 		FROM pets p
 		JOIN order_items oi ON p.id = oi.pet_id
 		WHERE oi.order_id = $1
@@ -77,12 +101,7 @@ func (r *OrderRepository) GetOrderPets(ctx context.Context, orderID uuid.UUID) (
 	pets := []*models.Pet{}
 	for rows.Next() {
 		var pet models.Pet
-		err := rows.Scan(
-			&pet.ID, &pet.StoreID, &pet.Name, &pet.Species, &pet.Age,
-			&pet.PictureURL, &pet.Description, &pet.BreederName,
-			&pet.BreederEmailEncrypted, &pet.Status, &pet.CreatedAt, &pet.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanPetInto(rows, &pet); err != nil {
 			return nil, fmt.Errorf("failed to scan pet: %w", err)
 		}
 		pets = append(pets, &pet)
@@ -91,18 +110,277 @@ func (r *OrderRepository) GetOrderPets(ctx context.Context, orderID uuid.UUID) (
 	return pets, nil
 }
 
+// GetPetsByOrderIDs retrieves the pets of many orders at once, keyed by order ID
+func (r *OrderRepository) GetPetsByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID][]*models.Pet, error) {
+	// The item columns do not clash with pet columns, so petColumns can stay unqualified
+	query := `
+		SELECT ` + petColumns + `, items.order_id
+		FROM pets
+		JOIN (
+			SELECT order_id, pet_id, purchased_at
+			FROM order_items
+			WHERE order_id = ANY($1)
+		) items ON items.pet_id = pets.id
+		ORDER BY items.purchased_at`
+
+	rows, err := r.DB().QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order pets: %w", err)
+	}
+	defer rows.Close()
+
+	byOrder := make(map[uuid.UUID][]*models.Pet, len(orderIDs))
+	for rows.Next() {
+		var pet models.Pet
+		var orderID uuid.UUID
+		if err := scanPetInto(extraColumnsScanner{rows, []any{&orderID}}, &pet); err != nil {
+			return nil, fmt.Errorf("failed to scan pet: %w", err)
+		}
+		byOrder[orderID] = append(byOrder[orderID], &pet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order pet rows: %w", err)
+	}
+
+	return byOrder, nil
+}
+
+// GetItemsWithTx retrieves the items of an order within a transaction
+func (r *OrderRepository) GetItemsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) ([]*models.OrderItem, error) {
+	query := `
+		SELECT id, order_id, pet_id, purchased_at, released_at
+		FROM order_items
+		WHERE order_id = $1
+		ORDER BY purchased_at`
+
+	rows, err := tx.QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order items: %w", err)
+	}
+	defer rows.Close()
+
+	items := []*models.OrderItem{}
+	for rows.Next() {
+		var item models.OrderItem
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.PetID, &item.PurchasedAt, &item.ReleasedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order items: %w", err)
+	}
+
+	return items, nil
+}
+
 // UpdateWithTx updates an existing order within a transaction
 func (r *OrderRepository) UpdateWithTx(ctx context.Context, tx *sql.Tx, order *models.Order) error {
 	query := `
 		UPDATE orders 
-		SET total_pets = $2
+		SET total_pets = $2, total_cents = $3, payment_id = $4, payment_status = $5, discount_cents = $6
 		WHERE id = $1
-		RETURNING *`
+		RETURNING ` + orderColumns
+
+	row := tx.QueryRowContext(ctx, query,
+		order.ID, order.TotalPets, order.TotalCents, order.PaymentID, order.PaymentStatus, order.DiscountCents,
+	)
 
-	row := tx.QueryRowContext(ctx, query, order.ID, order.TotalPets)
+	return scanOrderInto(row, order)
+}
+
+// GetByID retrieves an order by its ID
+func (r *OrderRepository) GetByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
+
+	var order models.Order
+	err := scanOrderInto(r.DB().QueryRowContext(ctx, query, orderID), &order)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewOrderNotFound(orderID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
 
+	return &order, nil
+}
+
+// GetByIDs retrieves the orders with the given IDs in one query; missing orders are left out
+func (r *OrderRepository) GetByIDs(ctx context.Context, orderIDs []uuid.UUID) ([]*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = ANY($1)`
+
+	rows, err := r.DB().QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.Order{}
+	for rows.Next() {
+		var order models.Order
+		if err := scanOrderInto(rows, &order); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, &order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order rows: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetByIDForUpdateWithTx retrieves and locks an order by its ID within a transaction
+func (r *OrderRepository) GetByIDForUpdateWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1 FOR UPDATE`
+
+	var order models.Order
+	err := scanOrderInto(tx.QueryRowContext(ctx, query, orderID), &order)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewOrderNotFound(orderID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return &order, nil
+}
+
+// GetByPaymentID retrieves an order by the payment provider authorization ID
+func (r *OrderRepository) GetByPaymentID(ctx context.Context, paymentID string) (*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE payment_id = $1`
+
+	var order models.Order
+	err := scanOrderInto(r.DB().QueryRowContext(ctx, query, paymentID), &order)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NotFoundError{Resource: "order", ID: paymentID}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get order by payment: %w", err)
+	}
+
+	return &order, nil
+}
+
+// ListAwaitingPayment retrieves up to limit orders created before createdBefore whose payment
+// is still pending or authorized, oldest first
+func (r *OrderRepository) ListAwaitingPayment(ctx context.Context, createdBefore time.Time, limit int) ([]*models.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders
+		WHERE payment_status IN ($1, $2) AND created_at < $3
+		ORDER BY created_at
+		LIMIT $4`
+
+	rows, err := r.DB().QueryContext(ctx, query, models.PaymentStatusPending, models.PaymentStatusAuthorized, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders awaiting payment: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.Order{}
+	for rows.Next() {
+		var order models.Order
+		if err := scanOrderInto(rows, &order); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, &order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order rows: %w", err)
+	}
+
+	return orders, nil
+}
+
+// UpdateStatusWithTx updates the fulfillment status of an order within a transaction
+func (r *OrderRepository) UpdateStatusWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, status models.OrderStatus) error {
+	query := `UPDATE orders SET status = $2 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, orderID, status); err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+	return nil
+}
+
+// ReleaseItemsWithTx marks the unreleased items of an order as released within a transaction.
+// The rows are kept as the order's history, but no longer hold their pets.
+func (r *OrderRepository) ReleaseItemsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID, releasedAt time.Time) error {
+	query := `UPDATE order_items SET released_at = $2 WHERE order_id = $1 AND released_at IS NULL`
+	if _, err := tx.ExecContext(ctx, query, orderID, releasedAt); err != nil {
+		return fmt.Errorf("failed to release order items: %w", err)
+	}
+	return nil
+}
+
+// EnsureStoreOpenWithTx share-locks a store for the rest of the transaction and
+// fails if it has been closed, so orders cannot race a store being closed.
+func (r *OrderRepository) EnsureStoreOpenWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) error {
+	query := `SELECT closed_at FROM stores WHERE id = $1 FOR SHARE`
+
+	var closedAt sql.NullTime
+	err := tx.QueryRowContext(ctx, query, storeID).Scan(&closedAt)
+	if err == sql.ErrNoRows {
+		return apperrors.NewStoreNotFound(storeID)
+	} else if err != nil {
+		return fmt.Errorf("failed to check store: %w", err)
+	}
+
+	if closedAt.Valid {
+		return apperrors.NewBusinessRuleError("store is closed and no longer accepts orders")
+	}
+
+	return nil
+}
+
+// Stream calls fn for every order matching the filter, newest first, one row at a time.
+// Iteration stops at the first error returned by fn.
+func (r *OrderRepository) Stream(ctx context.Context, filter models.OrderFilter, fn func(*models.Order) error) error {
+	whereConditions := []string{"store_id = $1"}
+	args := []any{filter.StoreID}
+
+	if filter.Status != nil {
+		args = append(args, *filter.Status)
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.PaymentStatus != nil {
+		args = append(args, *filter.PaymentStatus)
+		whereConditions = append(whereConditions, fmt.Sprintf("payment_status = $%d", len(args)))
+	}
+	if filter.StartDate != nil && filter.EndDate != nil {
+		args = append(args, *filter.StartDate, *filter.EndDate)
+		whereConditions = append(whereConditions, fmt.Sprintf("created_at BETWEEN $%d AND $%d", len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders
+		WHERE ` + strings.Join(whereConditions, " AND ") + `
+		ORDER BY created_at DESC`
+
+	rows, err := r.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order models.Order
+		if err := scanOrderInto(rows, &order); err != nil {
+			return fmt.Errorf("failed to scan order: %w", err)
+		}
+		if err := fn(&order); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	return nil
+}
+
+// scanOrderInto scans a row selected with orderColumns into order
+func scanOrderInto(row rowScanner, order *models.Order) error {
 	return row.Scan(
 		&order.ID, &order.CustomerID, &order.StoreID, &order.TotalPets, &order.CreatedAt,
+		&order.TotalCents, &order.PaymentID, &order.PaymentStatus, &order.DiscountCents, &order.Status,
 	)
 }
-
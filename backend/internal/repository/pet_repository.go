@@ -5,20 +5,54 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/database"
 	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/geo"
 	"github.com/fehepe/pet-store/backend/internal/models"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// petColumns lists the pet columns in the order expected by scanPetInto
+const petColumns = `id, store_id, name, species, age, picture_url, description,
+			   breeder_name, breeder_email_encrypted, status, created_at, updated_at, price_cents`
+
+// petInsertBatchSize is how many pets CreateBatchWithTx inserts per statement
+const petInsertBatchSize = 100
+
+// petsWithSale joins each pet to the order it was sold in, if any. Like salesFrom, only orders
+// that were free or whose payment was captured are sales: pets held by an unpaid order are
+// reserved, and items released by a failed or refunded order are not sales. The sale columns do
+// not clash with pet columns, so petColumns and filters can stay unqualified.
+const petsWithSale = `pets
+		LEFT JOIN LATERAL (
+			SELECT oi.order_id, oi.purchased_at, o.customer_id
+			FROM order_items oi
+			JOIN orders o ON o.id = oi.order_id
+			WHERE oi.pet_id = pets.id AND oi.released_at IS NULL
+			  AND o.payment_status IN ('none', 'captured')
+		) sale ON TRUE`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 // PetRepositoryInterface defines the interface for pet data operations
 type PetRepositoryInterface interface {
-	Create(ctx context.Context, pet *models.Pet) error
+	CreateWithTx(ctx context.Context, tx *sql.Tx, pet *models.Pet) error
+	CreateBatchWithTx(ctx context.Context, tx *sql.Tx, pets []*models.Pet) error
 	GetByID(ctx context.Context, petID uuid.UUID) (*models.Pet, error)
+	GetByIDs(ctx context.Context, petIDs []uuid.UUID) ([]*models.Pet, error)
 	List(ctx context.Context, filter models.PetFilter) ([]*models.Pet, int, error)
+	Stream(ctx context.Context, filter models.PetFilter, fn func(*models.Pet) error) error
 	Delete(ctx context.Context, petID uuid.UUID) error
+	MarkAsReserved(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error
 	MarkAsSold(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error
+	MarkAsAvailable(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error
+	LockAvailableWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, petIDs []uuid.UUID) ([]*models.Pet, error)
 	Transaction(fn func(*sql.Tx) error) error
 }
 
@@ -34,42 +68,69 @@ func NewPetRepository(db database.Repository) PetRepositoryInterface {
 	}
 }
 
-// Create inserts a new pet into the database
-func (r *PetRepository) Create(ctx context.Context, pet *models.Pet) error {
+// CreateWithTx inserts a new pet within a transaction
+func (r *PetRepository) CreateWithTx(ctx context.Context, tx *sql.Tx, pet *models.Pet) error {
 	query := `
 		INSERT INTO pets (id, store_id, name, species, age, picture_url, description, 
-			breeder_name, breeder_email_encrypted, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-		RETURNING *`
+			breeder_name, breeder_email_encrypted, status, created_at, updated_at, price_cents)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING ` + petColumns
 
-	row := r.QueryInsert(ctx, query,
+	row := r.QueryInsertWithTx(ctx, tx, query,
 		pet.ID, pet.StoreID, pet.Name, pet.Species, pet.Age,
 		pet.PictureURL, pet.Description, pet.BreederName,
-		pet.BreederEmailEncrypted, pet.Status, pet.CreatedAt, pet.UpdatedAt,
+		pet.BreederEmailEncrypted, pet.Status, pet.CreatedAt, pet.UpdatedAt, pet.PriceCents,
 	)
 
-	return row.Scan(
-		&pet.ID, &pet.StoreID, &pet.Name, &pet.Species, &pet.Age,
-		&pet.PictureURL, &pet.Description, &pet.BreederName,
-		&pet.BreederEmailEncrypted, &pet.Status, &pet.CreatedAt, &pet.UpdatedAt,
-	)
+	return scanPetInto(row, pet)
+}
+
+// CreateBatchWithTx inserts pets in multi-row batches within a transaction,
+// so either every pet is created or none is
+func (r *PetRepository) CreateBatchWithTx(ctx context.Context, tx *sql.Tx, pets []*models.Pet) error {
+	const columnsPerPet = 13
+
+	for start := 0; start < len(pets); start += petInsertBatchSize {
+		batch := pets[start:min(start+petInsertBatchSize, len(pets))]
+
+		placeholders := make([]string, 0, len(batch))
+		args := make([]any, 0, len(batch)*columnsPerPet)
+		for i, pet := range batch {
+			values := make([]string, columnsPerPet)
+			for j := range values {
+				values[j] = fmt.Sprintf("$%d", i*columnsPerPet+j+1)
+			}
+			placeholders = append(placeholders, "("+strings.Join(values, ", ")+")")
+			args = append(args,
+				pet.ID, pet.StoreID, pet.Name, pet.Species, pet.Age,
+				pet.PictureURL, pet.Description, pet.BreederName,
+				pet.BreederEmailEncrypted, pet.Status, pet.CreatedAt, pet.UpdatedAt, pet.PriceCents,
+			)
+		}
+
+		query := `
+			INSERT INTO pets (id, store_id, name, species, age, picture_url, description,
+				breeder_name, breeder_email_encrypted, status, created_at, updated_at, price_cents)
+			VALUES ` + strings.Join(placeholders, ", ")
+
+		if err := r.ExecInsertWithTx(ctx, tx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert pets %d-%d: %w", start+1, start+len(batch), err)
+		}
+	}
+
+	return nil
 }
 
 // GetByID retrieves a pet by its ID
 func (r *PetRepository) GetByID(ctx context.Context, petID uuid.UUID) (*models.Pet, error) {
 	query := `
-		SELECT id, store_id, name, species, age, picture_url, description,
-			   breeder_name, breeder_email_encrypted, status, created_at, updated_at
-		FROM pets
+		SELECT ` + petColumns + `, sale.order_id, sale.purchased_at, sale.customer_id
+		FROM ` + petsWithSale + `
 		WHERE id = $1`
 
 	var pet models.Pet
 	row := r.DB().QueryRowContext(ctx, query, petID)
-	err := row.Scan(
-		&pet.ID, &pet.StoreID, &pet.Name, &pet.Species, &pet.Age,
-		&pet.PictureURL, &pet.Description, &pet.BreederName,
-		&pet.BreederEmailEncrypted, &pet.Status, &pet.CreatedAt, &pet.UpdatedAt,
-	)
+	err := scanPetWithSaleInto(row, &pet)
 
 	if err == sql.ErrNoRows {
 		return nil, apperrors.NewPetNotFound(petID)
@@ -80,34 +141,41 @@ func (r *PetRepository) GetByID(ctx context.Context, petID uuid.UUID) (*models.P
 	return &pet, nil
 }
 
-// List retrieves pets with filtering and pagination
-func (r *PetRepository) List(ctx context.Context, filter models.PetFilter) ([]*models.Pet, int, error) {
-	var whereConditions []string
-	var args []any
-	argIndex := 1
+// GetByIDs retrieves the pets with the given IDs in one query; missing pets are left out
+func (r *PetRepository) GetByIDs(ctx context.Context, petIDs []uuid.UUID) ([]*models.Pet, error) {
+	query := `
+		SELECT ` + petColumns + `, sale.order_id, sale.purchased_at, sale.customer_id
+		FROM ` + petsWithSale + `
+		WHERE id = ANY($1)`
 
-	if filter.StoreID != nil && *filter.StoreID != uuid.Nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("store_id = $%d", argIndex))
-		args = append(args, *filter.StoreID)
-		argIndex++
-	}
-	if filter.Status != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, *filter.Status)
-		argIndex++
+	rows, err := r.DB().QueryContext(ctx, query, pq.Array(petIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pets: %w", err)
 	}
-	if filter.StartDate != nil && filter.EndDate != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("created_at BETWEEN $%d AND $%d", argIndex, argIndex+1))
-		args = append(args, *filter.StartDate, *filter.EndDate)
-		argIndex += 2
+	defer rows.Close()
+
+	pets := []*models.Pet{}
+	for rows.Next() {
+		var pet models.Pet
+		if err := scanPetWithSaleInto(rows, &pet); err != nil {
+			return nil, fmt.Errorf("failed to scan pet: %w", err)
+		}
+		pets = append(pets, &pet)
 	}
 
-	whereClause := ""
-	if len(whereConditions) > 0 {
-		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pet rows: %w", err)
 	}
 
-	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM pets %s", whereClause)
+	return pets, nil
+}
+
+// List retrieves pets with filtering and pagination
+func (r *PetRepository) List(ctx context.Context, filter models.PetFilter) ([]*models.Pet, int, error) {
+	whereClause, args := petFilterWhere(filter)
+	argIndex := len(args) + 1
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM %s %s", petsWithSale, whereClause)
 	var total int
 	err := r.DB().QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
@@ -129,12 +197,11 @@ func (r *PetRepository) List(ctx context.Context, filter models.PetFilter) ([]*m
 	args = append(args, limit, offset)
 
 	query := fmt.Sprintf(`
-		SELECT id, store_id, name, species, age, picture_url, description,
-			   breeder_name, breeder_email_encrypted, status, created_at, updated_at
-		FROM pets
+		SELECT %s, sale.order_id, sale.purchased_at, sale.customer_id
+		FROM %s
 		%s
-		ORDER BY created_at DESC
-		LIMIT $%d OFFSET $%d`, whereClause, limitIndex, offsetIndex)
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, petColumns, petsWithSale, whereClause, petListOrder(filter), limitIndex, offsetIndex)
 
 	rows, err := r.DB().QueryContext(ctx, query, args...)
 	if err != nil {
@@ -145,12 +212,7 @@ func (r *PetRepository) List(ctx context.Context, filter models.PetFilter) ([]*m
 	var pets []*models.Pet
 	for rows.Next() {
 		var pet models.Pet
-		err := rows.Scan(
-			&pet.ID, &pet.StoreID, &pet.Name, &pet.Species, &pet.Age,
-			&pet.PictureURL, &pet.Description, &pet.BreederName,
-			&pet.BreederEmailEncrypted, &pet.Status, &pet.CreatedAt, &pet.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanPetWithSaleInto(rows, &pet); err != nil {
 			return nil, 0, fmt.Errorf("failed to scan pet: %w", err)
 		}
 		pets = append(pets, &pet)
@@ -159,6 +221,40 @@ func (r *PetRepository) List(ctx context.Context, filter models.PetFilter) ([]*m
 	return pets, total, nil
 }
 
+// Stream calls fn for every pet matching the filter, one row at a time. Limit and
+// Offset are ignored. Iteration stops at the first error returned by fn.
+func (r *PetRepository) Stream(ctx context.Context, filter models.PetFilter, fn func(*models.Pet) error) error {
+	whereClause, args := petFilterWhere(filter)
+
+	query := fmt.Sprintf(`
+		SELECT %s, sale.order_id, sale.purchased_at, sale.customer_id
+		FROM %s
+		%s
+		ORDER BY %s`, petColumns, petsWithSale, whereClause, petListOrder(filter))
+
+	rows, err := r.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query pets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var pet models.Pet
+		if err := scanPetWithSaleInto(rows, &pet); err != nil {
+			return fmt.Errorf("failed to scan pet: %w", err)
+		}
+		if err := fn(&pet); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating pets: %w", err)
+	}
+
+	return nil
+}
+
 // Delete removes a pet from the database
 func (r *PetRepository) Delete(ctx context.Context, petID uuid.UUID) error {
 	query := `DELETE FROM pets WHERE id = $1`
@@ -179,6 +275,26 @@ func (r *PetRepository) Delete(ctx context.Context, petID uuid.UUID) error {
 	return nil
 }
 
+// MarkAsReserved holds a pet for an order awaiting payment within a transaction
+func (r *PetRepository) MarkAsReserved(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error {
+	query := `UPDATE pets SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	result, err := tx.ExecContext(ctx, query, models.PetStatusReserved, petID)
+	if err != nil {
+		return fmt.Errorf("failed to mark pet as reserved: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewPetNotFound(petID)
+	}
+
+	return nil
+}
+
 // MarkAsSold marks a pet as sold within a transaction
 func (r *PetRepository) MarkAsSold(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error {
 	query := `UPDATE pets SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
@@ -198,3 +314,143 @@ func (r *PetRepository) MarkAsSold(ctx context.Context, tx *sql.Tx, petID uuid.U
 
 	return nil
 }
+
+// MarkAsAvailable returns a reserved or sold pet to the available inventory within a transaction
+func (r *PetRepository) MarkAsAvailable(ctx context.Context, tx *sql.Tx, petID uuid.UUID) error {
+	query := `UPDATE pets SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+	result, err := tx.ExecContext(ctx, query, models.PetStatusAvailable, petID)
+	if err != nil {
+		return fmt.Errorf("failed to mark pet as available: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewPetNotFound(petID)
+	}
+
+	return nil
+}
+
+// LockAvailableWithTx locks the pets of petIDs that are still available at the store, in ID
+// order so concurrent orders for overlapping pets cannot deadlock. Other pets are left out.
+func (r *PetRepository) LockAvailableWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, petIDs []uuid.UUID) ([]*models.Pet, error) {
+	query := `
+		SELECT ` + petColumns + `
+		FROM pets
+		WHERE id = ANY($1) AND store_id = $2 AND status = $3
+		ORDER BY id
+		FOR UPDATE`
+
+	rows, err := tx.QueryContext(ctx, query, pq.Array(petIDs), storeID, models.PetStatusAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check pet availability: %w", err)
+	}
+	defer rows.Close()
+
+	pets := []*models.Pet{}
+	for rows.Next() {
+		var pet models.Pet
+		if err := scanPetInto(rows, &pet); err != nil {
+			return nil, fmt.Errorf("failed to scan pet: %w", err)
+		}
+		pets = append(pets, &pet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pet rows: %w", err)
+	}
+
+	return pets, nil
+}
+
+// scanPetInto scans a row selected with petColumns into pet
+func scanPetInto(row rowScanner, pet *models.Pet) error {
+	return row.Scan(
+		&pet.ID, &pet.StoreID, &pet.Name, &pet.Species, &pet.Age,
+		&pet.PictureURL, &pet.Description, &pet.BreederName,
+		&pet.BreederEmailEncrypted, &pet.Status, &pet.CreatedAt, &pet.UpdatedAt, &pet.PriceCents,
+	)
+}
+
+// scanPetWithSaleInto scans a row selected with petColumns followed by the sale columns
+func scanPetWithSaleInto(row rowScanner, pet *models.Pet) error {
+	var orderID *uuid.UUID
+	var purchasedAt *time.Time
+	var customerID *string
+	if err := scanPetInto(extraColumnsScanner{row, []any{&orderID, &purchasedAt, &customerID}}, pet); err != nil {
+		return err
+	}
+
+	pet.Sale = nil
+	if orderID != nil && purchasedAt != nil && customerID != nil {
+		pet.Sale = &models.PetSale{
+			OrderID:     *orderID,
+			PurchasedAt: *purchasedAt,
+			CustomerID:  *customerID,
+		}
+	}
+
+	return nil
+}
+
+// petFilterWhere builds the WHERE clause and its arguments for a pet filter,
+// numbering placeholders from $1
+func petFilterWhere(filter models.PetFilter) (string, []any) {
+	var whereConditions []string
+	var args []any
+	argIndex := 1
+
+	if filter.StoreID != nil && *filter.StoreID != uuid.Nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("store_id = $%d", argIndex))
+		args = append(args, *filter.StoreID)
+		argIndex++
+	}
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	if filter.StartDate != nil && filter.EndDate != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("created_at BETWEEN $%d AND $%d", argIndex, argIndex+1))
+		args = append(args, *filter.StartDate, *filter.EndDate)
+		argIndex += 2
+	}
+	if filter.SoldFrom != nil && filter.SoldTo != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("sale.purchased_at BETWEEN $%d AND $%d", argIndex, argIndex+1))
+		args = append(args, *filter.SoldFrom, *filter.SoldTo)
+		argIndex += 2
+	}
+	if filter.Near != nil {
+		box := geo.BoundingBox(filter.Near.Latitude, filter.Near.Longitude, filter.Near.RadiusKm)
+		lat, lng := fmt.Sprintf("$%d", argIndex), fmt.Sprintf("$%d", argIndex+1)
+		whereConditions = append(whereConditions, fmt.Sprintf(`store_id IN (
+			SELECT id FROM stores
+			WHERE closed_at IS NULL
+			  AND latitude BETWEEN $%[1]d AND $%[2]d
+			  AND longitude BETWEEN $%[3]d AND $%[4]d
+			  AND %[5]s <= $%[6]d)`,
+			argIndex+2, argIndex+3, argIndex+4, argIndex+5, haversineKm(lat, lng), argIndex+6))
+		args = append(args, filter.Near.Latitude, filter.Near.Longitude,
+			box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, filter.Near.RadiusKm)
+		argIndex += 7
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	return whereClause, args
+}
+
+// petListOrder returns the ORDER BY expression for a pet filter
+func petListOrder(filter models.PetFilter) string {
+	if filter.SoldFrom != nil && filter.SoldTo != nil {
+		return "sale.purchased_at DESC"
+	}
+	return "created_at DESC"
+}
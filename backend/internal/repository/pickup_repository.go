@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// pickupSlotColumns lists the slot columns in the order expected by scanPickupSlotInto
+const pickupSlotColumns = `id, store_id, starts_at, ends_at, capacity, booked, created_at, updated_at`
+
+// PickupSlotOverlapConstraint is the exclusion constraint that keeps a store's slots from overlapping
+const PickupSlotOverlapConstraint = "pickup_slots_no_overlap"
+
+// pickupAppointmentColumns lists the appointment columns in the order expected by scanPickupAppointmentInto
+const pickupAppointmentColumns = `id, order_id, slot_id, customer_id, status, created_at, completed_at`
+
+// PickupRepositoryInterface defines the interface for pickup scheduling data operations
+type PickupRepositoryInterface interface {
+	CreateSlotsWithTx(ctx context.Context, tx *sql.Tx, slots []*models.PickupSlot) error
+	ListSlots(ctx context.Context, storeID uuid.UUID, from, to time.Time) ([]*models.PickupSlot, error)
+	GetSlotForUpdateWithTx(ctx context.Context, tx *sql.Tx, slotID uuid.UUID) (*models.PickupSlot, error)
+	AdjustBookedWithTx(ctx context.Context, tx *sql.Tx, slotID uuid.UUID, delta int) error
+	GetAppointmentByOrderWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*models.PickupAppointment, error)
+	SaveAppointmentWithTx(ctx context.Context, tx *sql.Tx, appointment *models.PickupAppointment) error
+	ListAppointments(ctx context.Context, storeID uuid.UUID, from, to time.Time) ([]*models.PickupAppointment, error)
+	Transaction(fn func(*sql.Tx) error) error
+}
+
+// PickupRepository implements PickupRepositoryInterface
+type PickupRepository struct {
+	BaseRepository
+}
+
+// NewPickupRepository creates a new pickup repository
+func NewPickupRepository(db database.Repository) PickupRepositoryInterface {
+	return &PickupRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// CreateSlotsWithTx inserts pickup slots within a transaction
+func (r *PickupRepository) CreateSlotsWithTx(ctx context.Context, tx *sql.Tx, slots []*models.PickupSlot) error {
+	query := `
+		INSERT INTO pickup_slots (id, store_id, starts_at, ends_at, capacity, booked, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + pickupSlotColumns
+
+	for _, slot := range slots {
+		row := r.QueryInsertWithTx(ctx, tx, query,
+			slot.ID, slot.StoreID, slot.StartsAt, slot.EndsAt, slot.Capacity, slot.Booked,
+			slot.CreatedAt, slot.UpdatedAt,
+		)
+		if err := scanPickupSlotInto(row, slot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListSlots retrieves a store's pickup slots starting within [from, to)
+func (r *PickupRepository) ListSlots(ctx context.Context, storeID uuid.UUID, from, to time.Time) ([]*models.PickupSlot, error) {
+	query := `
+		SELECT ` + pickupSlotColumns + `
+		FROM pickup_slots
+		WHERE store_id = $1 AND starts_at >= $2 AND starts_at < $3
+		ORDER BY starts_at`
+
+	rows, err := r.DB().QueryContext(ctx, query, storeID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pickup slots: %w", err)
+	}
+	defer rows.Close()
+
+	slots := []*models.PickupSlot{}
+	for rows.Next() {
+		var slot models.PickupSlot
+		if err := scanPickupSlotInto(rows, &slot); err != nil {
+			return nil, fmt.Errorf("failed to scan pickup slot: %w", err)
+		}
+		slots = append(slots, &slot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pickup slot rows: %w", err)
+	}
+
+	return slots, nil
+}
+
+// GetSlotForUpdateWithTx retrieves and locks a pickup slot within a transaction
+func (r *PickupRepository) GetSlotForUpdateWithTx(ctx context.Context, tx *sql.Tx, slotID uuid.UUID) (*models.PickupSlot, error) {
+	query := `SELECT ` + pickupSlotColumns + ` FROM pickup_slots WHERE id = $1 FOR UPDATE`
+
+	var slot models.PickupSlot
+	err := scanPickupSlotInto(tx.QueryRowContext(ctx, query, slotID), &slot)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NotFoundError{Resource: "pickup slot", ID: slotID.String()}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get pickup slot: %w", err)
+	}
+
+	return &slot, nil
+}
+
+// AdjustBookedWithTx changes the number of booked appointments of a slot
+func (r *PickupRepository) AdjustBookedWithTx(ctx context.Context, tx *sql.Tx, slotID uuid.UUID, delta int) error {
+	query := `UPDATE pickup_slots SET booked = booked + $2 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, slotID, delta); err != nil {
+		return fmt.Errorf("failed to update pickup slot: %w", err)
+	}
+	return nil
+}
+
+// GetAppointmentByOrderWithTx retrieves the appointment of an order, or nil if none exists
+func (r *PickupRepository) GetAppointmentByOrderWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) (*models.PickupAppointment, error) {
+	query := `SELECT ` + pickupAppointmentColumns + ` FROM pickup_appointments WHERE order_id = $1`
+
+	var appointment models.PickupAppointment
+	err := scanPickupAppointmentInto(tx.QueryRowContext(ctx, query, orderID), &appointment)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get pickup appointment: %w", err)
+	}
+
+	return &appointment, nil
+}
+
+// SaveAppointmentWithTx inserts or updates the appointment of an order
+func (r *PickupRepository) SaveAppointmentWithTx(ctx context.Context, tx *sql.Tx, appointment *models.PickupAppointment) error {
+	query := `
+		INSERT INTO pickup_appointments (id, order_id, slot_id, customer_id, status, created_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (order_id) DO UPDATE
+		SET slot_id = EXCLUDED.slot_id, status = EXCLUDED.status, completed_at = EXCLUDED.completed_at
+		RETURNING ` + pickupAppointmentColumns
+
+	row := r.QueryInsertWithTx(ctx, tx, query,
+		appointment.ID, appointment.OrderID, appointment.SlotID, appointment.CustomerID,
+		appointment.Status, appointment.CreatedAt, appointment.CompletedAt,
+	)
+
+	return scanPickupAppointmentInto(row, appointment)
+}
+
+// ListAppointments retrieves the appointments booked into a store's slots starting within [from, to)
+func (r *PickupRepository) ListAppointments(ctx context.Context, storeID uuid.UUID, from, to time.Time) ([]*models.PickupAppointment, error) {
+	query := `
+		SELECT a.id, a.order_id, a.slot_id, a.customer_id, a.status, a.created_at, a.completed_at
+		FROM pickup_appointments a
+		JOIN pickup_slots s ON s.id = a.slot_id
+		WHERE s.store_id = $1 AND s.starts_at >= $2 AND s.starts_at < $3
+		ORDER BY s.starts_at, a.created_at`
+
+	rows, err := r.DB().QueryContext(ctx, query, storeID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pickup appointments: %w", err)
+	}
+	defer rows.Close()
+
+	appointments := []*models.PickupAppointment{}
+	for rows.Next() {
+		var appointment models.PickupAppointment
+		if err := scanPickupAppointmentInto(rows, &appointment); err != nil {
+			return nil, fmt.Errorf("failed to scan pickup appointment: %w", err)
+		}
+		appointments = append(appointments, &appointment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pickup appointment rows: %w", err)
+	}
+
+	return appointments, nil
+}
+
+// scanPickupSlotInto scans a row selected with pickupSlotColumns into slot
+func scanPickupSlotInto(row rowScanner, slot *models.PickupSlot) error {
+	return row.Scan(
+		&slot.ID, &slot.StoreID, &slot.StartsAt, &slot.EndsAt, &slot.Capacity, &slot.Booked,
+		&slot.CreatedAt, &slot.UpdatedAt,
+	)
+}
+
+// scanPickupAppointmentInto scans a row selected with pickupAppointmentColumns into appointment
+func scanPickupAppointmentInto(row rowScanner, appointment *models.PickupAppointment) error {
+	return row.Scan(
+		&appointment.ID, &appointment.OrderID, &appointment.SlotID, &appointment.CustomerID,
+		&appointment.Status, &appointment.CreatedAt, &appointment.CompletedAt,
+	)
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// promotionColumns lists the promotion columns in the order expected by scanPromotionInto
+const promotionColumns = `id, store_id, code, description, discount_type, discount_value, species,
+			   min_age, max_age, min_quantity, starts_at, ends_at, usage_limit, per_customer_limit,
+			   times_used, active, created_at, updated_at`
+
+// orderDiscountColumns lists the discount line columns in the order expected by scanOrderDiscountInto
+const orderDiscountColumns = `id, order_id, promotion_id, code, pet_id, amount_cents, description, created_at`
+
+// PromotionCodeConstraint is the unique constraint on a store's promotion codes
+const PromotionCodeConstraint = "promotions_store_id_code_key"
+
+// PromotionRepositoryInterface defines the interface for promotion data operations
+type PromotionRepositoryInterface interface {
+	Create(ctx context.Context, promotion *models.Promotion) error
+	GetByCodeWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, code string) (*models.Promotion, error)
+	ListByStore(ctx context.Context, storeID uuid.UUID) ([]*models.Promotion, error)
+	SetActive(ctx context.Context, storeID, promotionID uuid.UUID, active bool) error
+	CountCustomerUsageWithTx(ctx context.Context, tx *sql.Tx, promotionID uuid.UUID, customerID string) (int, error)
+	IncrementUsageWithTx(ctx context.Context, tx *sql.Tx, promotionID uuid.UUID) error
+	CreateDiscountWithTx(ctx context.Context, tx *sql.Tx, discount *models.OrderDiscount) error
+	ReleaseOrderDiscountsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error
+	GetOrderDiscounts(ctx context.Context, orderID uuid.UUID) ([]*models.OrderDiscount, error)
+	GetDiscountsByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID][]*models.OrderDiscount, error)
+}
+
+// PromotionRepository implements PromotionRepositoryInterface
+type PromotionRepository struct {
+	BaseRepository
+}
+
+// NewPromotionRepository creates a new promotion repository
+func NewPromotionRepository(db database.Repository) PromotionRepositoryInterface {
+	return &PromotionRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Create inserts a new promotion into the database
+func (r *PromotionRepository) Create(ctx context.Context, promotion *models.Promotion) error {
+	query := `
+		INSERT INTO promotions (id, store_id, code, description, discount_type, discount_value, species,
+			min_age, max_age, min_quantity, starts_at, ends_at, usage_limit, per_customer_limit,
+			times_used, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING ` + promotionColumns
+
+	row := r.QueryInsert(ctx, query,
+		promotion.ID, promotion.StoreID, promotion.Code, promotion.Description,
+		promotion.DiscountType, promotion.DiscountValue, promotion.Species,
+		promotion.MinAge, promotion.MaxAge, promotion.MinQuantity, promotion.StartsAt, promotion.EndsAt,
+		promotion.UsageLimit, promotion.PerCustomerLimit, promotion.TimesUsed, promotion.Active,
+		promotion.CreatedAt, promotion.UpdatedAt,
+	)
+
+	return scanPromotionInto(row, promotion)
+}
+
+// GetByCodeWithTx retrieves and locks a store's promotion by code within a transaction
+func (r *PromotionRepository) GetByCodeWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, code string) (*models.Promotion, error) {
+	query := `
+		SELECT ` + promotionColumns + `
+		FROM promotions
+		WHERE store_id = $1 AND UPPER(code) = UPPER($2)
+		FOR UPDATE`
+
+	var promotion models.Promotion
+	err := scanPromotionInto(tx.QueryRowContext(ctx, query, storeID, code), &promotion)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NotFoundError{Resource: "promotion", ID: code}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	return &promotion, nil
+}
+
+// ListByStore retrieves all promotions of a store
+func (r *PromotionRepository) ListByStore(ctx context.Context, storeID uuid.UUID) ([]*models.Promotion, error) {
+	query := `
+		SELECT ` + promotionColumns + `
+		FROM promotions
+		WHERE store_id = $1
+		ORDER BY starts_at DESC, code ASC`
+
+	rows, err := r.DB().QueryContext(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotions: %w", err)
+	}
+	defer rows.Close()
+
+	promotions := []*models.Promotion{}
+	for rows.Next() {
+		var promotion models.Promotion
+		if err := scanPromotionInto(rows, &promotion); err != nil {
+			return nil, fmt.Errorf("failed to scan promotion: %w", err)
+		}
+		promotions = append(promotions, &promotion)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating promotion rows: %w", err)
+	}
+
+	return promotions, nil
+}
+
+// SetActive enables or disables a store's promotion
+func (r *PromotionRepository) SetActive(ctx context.Context, storeID, promotionID uuid.UUID, active bool) error {
+	query := `UPDATE promotions SET active = $3 WHERE id = $1 AND store_id = $2`
+	result, err := r.DB().ExecContext(ctx, query, promotionID, storeID, active)
+	if err != nil {
+		return fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NotFoundError{Resource: "promotion", ID: promotionID.String()}
+	}
+
+	return nil
+}
+
+// CountCustomerUsageWithTx counts the orders on which a customer already used a promotion
+func (r *PromotionRepository) CountCustomerUsageWithTx(ctx context.Context, tx *sql.Tx, promotionID uuid.UUID, customerID string) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT od.order_id)
+		FROM order_discounts od
+		JOIN orders o ON o.id = od.order_id
+		WHERE od.promotion_id = $1 AND o.customer_id = $2`
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, promotionID, customerID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count promotion usage: %w", err)
+	}
+
+	return count, nil
+}
+
+// IncrementUsageWithTx records one use of a promotion, enforcing its global usage limit
+func (r *PromotionRepository) IncrementUsageWithTx(ctx context.Context, tx *sql.Tx, promotionID uuid.UUID) error {
+	query := `
+		UPDATE promotions
+		SET times_used = times_used + 1
+		WHERE id = $1 AND (usage_limit IS NULL OR times_used < usage_limit)`
+
+	result, err := tx.ExecContext(ctx, query, promotionID)
+	if err != nil {
+		return fmt.Errorf("failed to record promotion usage: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NewBusinessRuleError("promotion usage limit has been reached")
+	}
+
+	return nil
+}
+
+// CreateDiscountWithTx inserts an order discount line within a transaction
+func (r *PromotionRepository) CreateDiscountWithTx(ctx context.Context, tx *sql.Tx, discount *models.OrderDiscount) error {
+	query := `
+		INSERT INTO order_discounts (id, order_id, promotion_id, code, pet_id, amount_cents, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	return r.ExecInsertWithTx(ctx, tx, query,
+		discount.ID, discount.OrderID, discount.PromotionID, discount.Code,
+		discount.PetID, discount.AmountCents, discount.Description, discount.CreatedAt,
+	)
+}
+
+// ReleaseOrderDiscountsWithTx deletes an order's discount lines and gives back the use
+// each of their promotions recorded for it
+func (r *PromotionRepository) ReleaseOrderDiscountsWithTx(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	query := `
+		WITH released AS (
+			DELETE FROM order_discounts WHERE order_id = $1 RETURNING promotion_id
+		)
+		UPDATE promotions
+		SET times_used = GREATEST(times_used - 1, 0)
+		WHERE id IN (SELECT promotion_id FROM released)`
+
+	if _, err := tx.ExecContext(ctx, query, orderID); err != nil {
+		return fmt.Errorf("failed to release order discounts: %w", err)
+	}
+
+	return nil
+}
+
+// GetOrderDiscounts retrieves the discount lines recorded on an order
+func (r *PromotionRepository) GetOrderDiscounts(ctx context.Context, orderID uuid.UUID) ([]*models.OrderDiscount, error) {
+	query := `
+		SELECT ` + orderDiscountColumns + `
+		FROM order_discounts
+		WHERE order_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := r.DB().QueryContext(ctx, query, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order discounts: %w", err)
+	}
+	defer rows.Close()
+
+	discounts := []*models.OrderDiscount{}
+	for rows.Next() {
+		var discount models.OrderDiscount
+		if err := scanOrderDiscountInto(rows, &discount); err != nil {
+			return nil, fmt.Errorf("failed to scan order discount: %w", err)
+		}
+		discounts = append(discounts, &discount)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order discount rows: %w", err)
+	}
+
+	return discounts, nil
+}
+
+// GetDiscountsByOrderIDs retrieves the discount lines of many orders at once, keyed by order ID
+func (r *PromotionRepository) GetDiscountsByOrderIDs(ctx context.Context, orderIDs []uuid.UUID) (map[uuid.UUID][]*models.OrderDiscount, error) {
+	query := `
+		SELECT ` + orderDiscountColumns + `
+		FROM order_discounts
+		WHERE order_id = ANY($1)
+		ORDER BY created_at, id`
+
+	rows, err := r.DB().QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order discounts: %w", err)
+	}
+	defer rows.Close()
+
+	byOrder := make(map[uuid.UUID][]*models.OrderDiscount, len(orderIDs))
+	for rows.Next() {
+		var discount models.OrderDiscount
+		if err := scanOrderDiscountInto(rows, &discount); err != nil {
+			return nil, fmt.Errorf("failed to scan order discount: %w", err)
+		}
+		byOrder[discount.OrderID] = append(byOrder[discount.OrderID], &discount)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order discount rows: %w", err)
+	}
+
+	return byOrder, nil
+}
+
+// scanPromotionInto scans a row selected with promotionColumns into promotion
+func scanPromotionInto(row rowScanner, promotion *models.Promotion) error {
+	return row.Scan(
+		&promotion.ID, &promotion.StoreID, &promotion.Code, &promotion.Description,
+		&promotion.DiscountType, &promotion.DiscountValue, &promotion.Species,
+		&promotion.MinAge, &promotion.MaxAge, &promotion.MinQuantity, &promotion.StartsAt, &promotion.EndsAt,
+		&promotion.UsageLimit, &promotion.PerCustomerLimit, &promotion.TimesUsed, &promotion.Active,
+		&promotion.CreatedAt, &promotion.UpdatedAt,
+	)
+}
+
+// scanOrderDiscountInto scans a row selected with orderDiscountColumns into discount
+func scanOrderDiscountInto(row rowScanner, discount *models.OrderDiscount) error {
+	return row.Scan(
+		&discount.ID, &discount.OrderID, &discount.PromotionID, &discount.Code,
+		&discount.PetID, &discount.AmountCents, &discount.Description, &discount.CreatedAt,
+	)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	"github.com/fehepe/pet-store/backend/internal/models"
+)
+
+// salesFrom joins each sale to its pet and order, limited to one store and range. Only orders that
+// were free or whose payment was captured count; refunded, failed and still unpaid orders do not.
+// Expects the store ID, start and end of the range as $1, $2 and $3.
+const salesFrom = `
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		JOIN pets p ON p.id = oi.pet_id
+		JOIN LATERAL (
+			SELECT SUM(op.price_cents) AS subtotal_cents
+			FROM order_items ooi
+			JOIN pets op ON op.id = ooi.pet_id
+			WHERE ooi.order_id = o.id
+		) s ON true
+		WHERE o.store_id = $1
+		  AND oi.purchased_at >= $2
+		  AND oi.purchased_at < $3
+		  AND o.payment_status IN ('none', 'captured')`
+
+// saleRevenue is the part of its order's total a sale earned: the order's discounted total split
+// across its pets in proportion to their prices
+const saleRevenue = `COALESCE(ROUND(o.total_cents::numeric * p.price_cents / NULLIF(s.subtotal_cents, 0)), 0)`
+
+// SalesRepositoryInterface defines the interface for sales analytics queries
+type SalesRepositoryInterface interface {
+	SalesBuckets(ctx context.Context, input models.SalesReportInput) ([]*models.SalesBucket, error)
+	SalesSummary(ctx context.Context, input models.SalesReportInput) (*models.SalesSummary, error)
+}
+
+// SalesRepository implements SalesRepositoryInterface
+type SalesRepository struct {
+	BaseRepository
+}
+
+// NewSalesRepository creates a new sales repository
+func NewSalesRepository(db database.Repository) SalesRepositoryInterface {
+	return &SalesRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// SalesBuckets counts the pets sold and their revenue per UTC period or per species
+func (r *SalesRepository) SalesBuckets(ctx context.Context, input models.SalesReportInput) ([]*models.SalesBucket, error) {
+	var bucket string
+	switch input.GroupBy {
+	case models.SalesGroupByDay, models.SalesGroupByWeek, models.SalesGroupByMonth:
+		// The group is a fixed keyword, never user text
+		bucket = fmt.Sprintf("date_trunc('%s', oi.purchased_at AT TIME ZONE 'UTC')", input.GroupBy)
+	case models.SalesGroupBySpecies:
+		bucket = "p.species"
+	default:
+		return nil, fmt.Errorf("unsupported sales grouping: %s", input.GroupBy)
+	}
+
+	query := `
+		SELECT ` + bucket + ` AS bucket, COUNT(*), COALESCE(SUM(` + saleRevenue + `), 0)::bigint` + salesFrom + `
+		GROUP BY bucket
+		ORDER BY ` + salesBucketOrder(input.GroupBy)
+
+	rows, err := r.DB().QueryContext(ctx, query, input.StoreID, input.From, input.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sales: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []*models.SalesBucket{}
+	for rows.Next() {
+		var salesBucket models.SalesBucket
+		if input.GroupBy == models.SalesGroupBySpecies {
+			var species models.PetSpecies
+			err = rows.Scan(&species, &salesBucket.PetsSold, &salesBucket.RevenueCents)
+			salesBucket.Species = &species
+		} else {
+			var periodStart time.Time
+			err = rows.Scan(&periodStart, &salesBucket.PetsSold, &salesBucket.RevenueCents)
+			periodStart = periodStart.UTC()
+			salesBucket.PeriodStart = &periodStart
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sales bucket: %w", err)
+		}
+		buckets = append(buckets, &salesBucket)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sales rows: %w", err)
+	}
+
+	return buckets, nil
+}
+
+// SalesSummary totals the sales in the range, with the average days a pet was
+// listed before selling and the species that sold the most
+func (r *SalesRepository) SalesSummary(ctx context.Context, input models.SalesReportInput) (*models.SalesSummary, error) {
+	query := `
+		WITH sales AS (
+			SELECT o.id AS order_id, p.species, EXTRACT(EPOCH FROM (oi.purchased_at - p.created_at)) / 86400 AS days_on_shelf` + salesFrom + `
+		), top_species AS (
+			SELECT species, COUNT(*) AS sold
+			FROM sales
+			GROUP BY species
+			ORDER BY sold DESC, species ASC
+			LIMIT 1
+		)
+		SELECT COUNT(*),
+			(SELECT COALESCE(SUM(total_cents), 0)::bigint FROM orders WHERE id IN (SELECT order_id FROM sales)),
+			AVG(days_on_shelf),
+			(SELECT species FROM top_species), COALESCE((SELECT sold FROM top_species), 0)
+		FROM sales`
+
+	var summary models.SalesSummary
+	var topSpecies *string
+	err := r.DB().QueryRowContext(ctx, query, input.StoreID, input.From, input.To).Scan(
+		&summary.PetsSold, &summary.RevenueCents, &summary.AverageDaysOnShelf, &topSpecies, &summary.TopSpeciesPetsSold,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize sales: %w", err)
+	}
+
+	if topSpecies != nil {
+		species := models.PetSpecies(*topSpecies)
+		summary.TopSpecies = &species
+	}
+
+	return &summary, nil
+}
+
+func salesBucketOrder(groupBy models.SalesGroupBy) string {
+	if groupBy == models.SalesGroupBySpecies {
+		return "COUNT(*) DESC, bucket ASC"
+	}
+	return "bucket ASC"
+}
@@ -3,17 +3,40 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/database"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/geo"
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// storeColumns lists the store columns in the order expected by scanStoreInto
+const storeColumns = `id, name, owner_id, created_at, updated_at, slug, description, address, phone,
+			   logo_url, opening_hours, closed_at, latitude, longitude`
+
+// StoreSlugConstraint is the unique index that keeps store slugs distinct
+const StoreSlugConstraint = "idx_stores_slug"
+
 // StoreRepositoryInterface defines the interface for store data operations
 type StoreRepositoryInterface interface {
 	Create(ctx context.Context, store *models.Store) error
-	GetByOwnerID(ctx context.Context, ownerID string) (*models.Store, error)
+	ListByOwner(ctx context.Context, ownerID string) ([]*models.Store, error)
+	GetByID(ctx context.Context, storeID uuid.UUID) (*models.Store, error)
+	GetByIDs(ctx context.Context, storeIDs []uuid.UUID) ([]*models.Store, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Store, error)
 	ListAll(ctx context.Context) ([]*models.Store, error)
+	ListOpen(ctx context.Context) ([]*models.Store, error)
+	ListNear(ctx context.Context, near models.GeoRadius, limit, offset int) ([]*models.NearbyStore, int, error)
+	Update(ctx context.Context, store *models.Store) error
+	GetByIDForUpdateWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) (*models.Store, error)
+	CountOpenOrdersWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) (int, error)
+	CloseWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, closedAt time.Time) error
+	Transaction(fn func(*sql.Tx) error) error
 }
 
 // StoreRepository implements StoreRepositoryInterface
@@ -30,37 +53,80 @@ func NewStoreRepository(db database.Repository) StoreRepositoryInterface {
 
 // Create inserts a new store into the database
 func (r *StoreRepository) Create(ctx context.Context, store *models.Store) error {
+	openingHours, err := marshalOpeningHours(store.OpeningHours)
+	if err != nil {
+		return err
+	}
+
 	query := `
-		INSERT INTO stores (id, name, owner_id, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5)
-		RETURNING *`
+		INSERT INTO stores (id, name, owner_id, created_at, updated_at, slug, description, address, phone,
+			logo_url, opening_hours, closed_at, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING ` + storeColumns
 
 	row := r.QueryInsert(ctx, query,
-		store.ID, store.Name, store.OwnerID, store.CreatedAt, store.UpdatedAt,
+		store.ID, store.Name, store.OwnerID, store.CreatedAt, store.UpdatedAt, store.Slug,
+		store.Description, store.Address, store.Phone, store.LogoURL, openingHours, store.ClosedAt,
+		store.Latitude, store.Longitude,
 	)
 
-	return row.Scan(
-		&store.ID, &store.Name, &store.OwnerID, &store.CreatedAt, &store.UpdatedAt,
-	)
+	return scanStoreInto(row, store)
+}
+
+// ListByOwner retrieves all stores of an owner
+func (r *StoreRepository) ListByOwner(ctx context.Context, ownerID string) ([]*models.Store, error) {
+	query := `
+		SELECT ` + storeColumns + `
+		FROM stores
+		WHERE owner_id = $1
+		ORDER BY name ASC`
+
+	return r.list(ctx, query, ownerID)
 }
 
-// GetByOwnerID retrieves a store by its owner ID
-func (r *StoreRepository) GetByOwnerID(ctx context.Context, ownerID string) (*models.Store, error) {
+// GetByID retrieves a store by its ID
+func (r *StoreRepository) GetByID(ctx context.Context, storeID uuid.UUID) (*models.Store, error) {
 	query := `
-		SELECT id, name, owner_id, created_at, updated_at
+		SELECT ` + storeColumns + `
 		FROM stores
-		WHERE owner_id = $1`
+		WHERE id = $1`
 
 	var store models.Store
-	row := r.DB().QueryRowContext(ctx, query, ownerID)
-	err := row.Scan(
-		&store.ID, &store.Name, &store.OwnerID, &store.CreatedAt, &store.UpdatedAt,
-	)
+	err := scanStoreInto(r.DB().QueryRowContext(ctx, query, storeID), &store)
+
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewStoreNotFound(storeID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+
+	return &store, nil
+}
+
+// GetByIDs retrieves the stores with the given IDs in one query; missing stores are left out
+func (r *StoreRepository) GetByIDs(ctx context.Context, storeIDs []uuid.UUID) ([]*models.Store, error) {
+	query := `
+		SELECT ` + storeColumns + `
+		FROM stores
+		WHERE id = ANY($1)`
+
+	return r.list(ctx, query, pq.Array(storeIDs))
+}
+
+// GetBySlug retrieves a store by its public slug
+func (r *StoreRepository) GetBySlug(ctx context.Context, slug string) (*models.Store, error) {
+	query := `
+		SELECT ` + storeColumns + `
+		FROM stores
+		WHERE slug = $1`
+
+	var store models.Store
+	err := scanStoreInto(r.DB().QueryRowContext(ctx, query, slug), &store)
 
 	if err == sql.ErrNoRows {
-		return nil, sql.ErrNoRows // Let the service handle auto-creation
+		return nil, apperrors.NotFoundError{Resource: "store", ID: slug}
 	} else if err != nil {
-		return nil, fmt.Errorf("failed to get store by owner: %w", err)
+		return nil, fmt.Errorf("failed to get store: %w", err)
 	}
 
 	return &store, nil
@@ -69,11 +135,145 @@ func (r *StoreRepository) GetByOwnerID(ctx context.Context, ownerID string) (*mo
 // ListAll retrieves all stores
 func (r *StoreRepository) ListAll(ctx context.Context) ([]*models.Store, error) {
 	query := `
-		SELECT id, name, owner_id, created_at, updated_at
+		SELECT ` + storeColumns + `
+		FROM stores
+		ORDER BY name ASC`
+
+	return r.list(ctx, query)
+}
+
+// ListOpen retrieves all stores that have not been closed
+func (r *StoreRepository) ListOpen(ctx context.Context) ([]*models.Store, error) {
+	query := `
+		SELECT ` + storeColumns + `
 		FROM stores
+		WHERE closed_at IS NULL
 		ORDER BY name ASC`
 
-	rows, err := r.DB().QueryContext(ctx, query)
+	return r.list(ctx, query)
+}
+
+// ListNear retrieves open stores within the radius, nearest first.
+// A bounding box narrows the candidates before the haversine distance is computed.
+func (r *StoreRepository) ListNear(ctx context.Context, near models.GeoRadius, limit, offset int) ([]*models.NearbyStore, int, error) {
+	box := geo.BoundingBox(near.Latitude, near.Longitude, near.RadiusKm)
+
+	nearby := `
+		SELECT ` + storeColumns + `, ` + haversineKm("$1", "$2") + ` AS distance_km
+		FROM stores
+		WHERE closed_at IS NULL
+		  AND latitude BETWEEN $3 AND $4
+		  AND longitude BETWEEN $5 AND $6`
+	args := []any{near.Latitude, near.Longitude, box.MinLat, box.MaxLat, box.MinLng, box.MaxLng, near.RadiusKm}
+
+	countQuery := `SELECT COUNT(*) FROM (` + nearby + `) nearby WHERE distance_km <= $7`
+	var total int
+	if err := r.DB().QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count nearby stores: %w", err)
+	}
+
+	query := `
+		SELECT ` + storeColumns + `, distance_km
+		FROM (` + nearby + `) nearby
+		WHERE distance_km <= $7
+		ORDER BY distance_km ASC, name ASC
+		LIMIT $8 OFFSET $9`
+
+	rows, err := r.DB().QueryContext(ctx, query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query nearby stores: %w", err)
+	}
+	defer rows.Close()
+
+	var stores []*models.NearbyStore
+	for rows.Next() {
+		nearbyStore := &models.NearbyStore{Store: &models.Store{}}
+		if err := scanStoreInto(extraColumnsScanner{rows, []any{&nearbyStore.DistanceKm}}, nearbyStore.Store); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan store: %w", err)
+		}
+		stores = append(stores, nearbyStore)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating store rows: %w", err)
+	}
+
+	return stores, total, nil
+}
+
+// Update saves the profile fields of a store
+func (r *StoreRepository) Update(ctx context.Context, store *models.Store) error {
+	openingHours, err := marshalOpeningHours(store.OpeningHours)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE stores
+		SET name = $2, slug = $3, description = $4, address = $5, phone = $6, logo_url = $7, opening_hours = $8,
+			latitude = $9, longitude = $10
+		WHERE id = $1
+		RETURNING ` + storeColumns
+
+	row := r.DB().QueryRowContext(ctx, query,
+		store.ID, store.Name, store.Slug, store.Description, store.Address, store.Phone,
+		store.LogoURL, openingHours, store.Latitude, store.Longitude,
+	)
+
+	err = scanStoreInto(row, store)
+	if err == sql.ErrNoRows {
+		return apperrors.NewStoreNotFound(store.ID)
+	} else if err != nil {
+		return fmt.Errorf("failed to update store: %w", err)
+	}
+
+	return nil
+}
+
+// GetByIDForUpdateWithTx retrieves and locks a store within a transaction
+func (r *StoreRepository) GetByIDForUpdateWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) (*models.Store, error) {
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE id = $1 FOR UPDATE`
+
+	var store models.Store
+	err := scanStoreInto(tx.QueryRowContext(ctx, query, storeID), &store)
+	if err == sql.ErrNoRows {
+		return nil, apperrors.NewStoreNotFound(storeID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get store: %w", err)
+	}
+
+	return &store, nil
+}
+
+// CountOpenOrdersWithTx counts the store's orders that still have pets waiting to be picked up
+func (r *StoreRepository) CountOpenOrdersWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM orders
+		WHERE store_id = $1
+		  AND status <> 'completed'
+		  AND total_pets > 0
+		  AND payment_status NOT IN ('failed', 'refunded')`
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, storeID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count open orders: %w", err)
+	}
+
+	return count, nil
+}
+
+// CloseWithTx soft-closes a store within a transaction
+func (r *StoreRepository) CloseWithTx(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, closedAt time.Time) error {
+	query := `UPDATE stores SET closed_at = $2 WHERE id = $1`
+	if _, err := tx.ExecContext(ctx, query, storeID, closedAt); err != nil {
+		return fmt.Errorf("failed to close store: %w", err)
+	}
+	return nil
+}
+
+func (r *StoreRepository) list(ctx context.Context, query string, args ...any) ([]*models.Store, error) {
+	rows, err := r.DB().QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query stores: %w", err)
 	}
@@ -82,10 +282,7 @@ func (r *StoreRepository) ListAll(ctx context.Context) ([]*models.Store, error)
 	var stores []*models.Store
 	for rows.Next() {
 		var store models.Store
-		err := rows.Scan(
-			&store.ID, &store.Name, &store.OwnerID, &store.CreatedAt, &store.UpdatedAt,
-		)
-		if err != nil {
+		if err := scanStoreInto(rows, &store); err != nil {
 			return nil, fmt.Errorf("failed to scan store: %w", err)
 		}
 		stores = append(stores, &store)
@@ -97,3 +294,55 @@ func (r *StoreRepository) ListAll(ctx context.Context) ([]*models.Store, error)
 
 	return stores, nil
 }
+
+// scanStoreInto scans a row selected with storeColumns into store
+func scanStoreInto(row rowScanner, store *models.Store) error {
+	var openingHours []byte
+	err := row.Scan(
+		&store.ID, &store.Name, &store.OwnerID, &store.CreatedAt, &store.UpdatedAt, &store.Slug,
+		&store.Description, &store.Address, &store.Phone, &store.LogoURL, &openingHours, &store.ClosedAt,
+		&store.Latitude, &store.Longitude,
+	)
+	if err != nil {
+		return err
+	}
+
+	store.OpeningHours = []models.OpeningHours{}
+	if len(openingHours) > 0 {
+		if err := json.Unmarshal(openingHours, &store.OpeningHours); err != nil {
+			return fmt.Errorf("failed to decode opening hours: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func marshalOpeningHours(hours []models.OpeningHours) ([]byte, error) {
+	if hours == nil {
+		hours = []models.OpeningHours{}
+	}
+	data, err := json.Marshal(hours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode opening hours: %w", err)
+	}
+	return data, nil
+}
+
+// haversineKm returns a SQL expression for the distance in km between the store
+// and the point given by the latitude and longitude placeholders
+func haversineKm(lat, lng string) string {
+	return fmt.Sprintf(`(2 * %[3]g * ASIN(SQRT(LEAST(1,
+			POWER(SIN(RADIANS(latitude - %[1]s) / 2), 2) +
+			COS(RADIANS(%[1]s)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - %[2]s) / 2), 2)))))`,
+		lat, lng, geo.EarthRadiusKm)
+}
+
+// extraColumnsScanner scans trailing columns selected after a fixed column list
+type extraColumnsScanner struct {
+	row   rowScanner
+	extra []any
+}
+
+func (s extraColumnsScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// storeSettingsColumns lists the settings columns in the order expected by scanStoreSettingsInto
+const storeSettingsColumns = `store_id, max_pets_per_order, max_pet_age, max_page_size, min_customer_age,
+			   reservation_hold_hours, show_breeder_names, stale_after_days, timezone, updated_at`
+
+// StoreSettingsRepositoryInterface defines the interface for store settings data operations
+type StoreSettingsRepositoryInterface interface {
+	Get(ctx context.Context, storeID uuid.UUID) (*models.StoreSettings, error)
+	Save(ctx context.Context, settings *models.StoreSettings) error
+}
+
+// StoreSettingsRepository implements StoreSettingsRepositoryInterface
+type StoreSettingsRepository struct {
+	BaseRepository
+}
+
+// NewStoreSettingsRepository creates a new store settings repository
+func NewStoreSettingsRepository(db database.Repository) StoreSettingsRepositoryInterface {
+	return &StoreSettingsRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// Get retrieves the settings of a store, falling back to the defaults when none are saved
+func (r *StoreSettingsRepository) Get(ctx context.Context, storeID uuid.UUID) (*models.StoreSettings, error) {
+	query := `SELECT ` + storeSettingsColumns + ` FROM store_settings WHERE store_id = $1`
+
+	var settings models.StoreSettings
+	err := scanStoreSettingsInto(r.DB().QueryRowContext(ctx, query, storeID), &settings)
+	if err == sql.ErrNoRows {
+		return models.DefaultStoreSettings(storeID), nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get store settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// Save inserts or replaces the settings of a store
+func (r *StoreSettingsRepository) Save(ctx context.Context, settings *models.StoreSettings) error {
+	query := `
+		INSERT INTO store_settings (store_id, max_pets_per_order, max_pet_age, max_page_size, min_customer_age,
+			reservation_hold_hours, show_breeder_names, stale_after_days, timezone, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP)
+		ON CONFLICT (store_id) DO UPDATE
+		SET max_pets_per_order = EXCLUDED.max_pets_per_order,
+			max_pet_age = EXCLUDED.max_pet_age,
+			max_page_size = EXCLUDED.max_page_size,
+			min_customer_age = EXCLUDED.min_customer_age,
+			reservation_hold_hours = EXCLUDED.reservation_hold_hours,
+			show_breeder_names = EXCLUDED.show_breeder_names,
+			stale_after_days = EXCLUDED.stale_after_days,
+			timezone = EXCLUDED.timezone,
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + storeSettingsColumns
+
+	row := r.QueryInsert(ctx, query,
+		settings.StoreID, settings.MaxPetsPerOrder, settings.MaxPetAge, settings.MaxPageSize,
+		settings.MinCustomerAge, settings.ReservationHoldHours, settings.ShowBreederNames,
+		settings.StaleAfterDays, settings.Timezone,
+	)
+
+	if err := scanStoreSettingsInto(row, settings); err != nil {
+		return fmt.Errorf("failed to save store settings: %w", err)
+	}
+
+	return nil
+}
+
+// scanStoreSettingsInto scans a row selected with storeSettingsColumns into settings
+func scanStoreSettingsInto(row rowScanner, settings *models.StoreSettings) error {
+	return row.Scan(
+		&settings.StoreID, &settings.MaxPetsPerOrder, &settings.MaxPetAge, &settings.MaxPageSize,
+		&settings.MinCustomerAge, &settings.ReservationHoldHours, &settings.ShowBreederNames,
+		&settings.StaleAfterDays, &settings.Timezone, &settings.UpdatedAt,
+	)
+}
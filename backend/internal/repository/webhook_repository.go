@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/database"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const webhookDeliveryColumns = `d.id, d.endpoint_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+	d.next_attempt_at, d.last_attempt_at, d.response_status, d.last_error, d.created_at, d.delivered_at`
+
+// WebhookRepositoryInterface defines the interface for webhook endpoint and delivery data operations
+type WebhookRepositoryInterface interface {
+	CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error
+	ListEndpoints(ctx context.Context, storeID uuid.UUID) ([]*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, storeID, endpointID uuid.UUID) error
+	EnqueueDeliveriesWithTx(ctx context.Context, tx *sql.Tx, event *models.WebhookEvent, payload []byte) (int, error)
+	ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int, error)
+}
+
+// WebhookRepository implements WebhookRepositoryInterface
+type WebhookRepository struct {
+	BaseRepository
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db database.Repository) WebhookRepositoryInterface {
+	return &WebhookRepository{
+		BaseRepository: NewBaseRepository(db),
+	}
+}
+
+// CreateEndpoint inserts a webhook endpoint
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	query := `
+		INSERT INTO webhook_endpoints (id, store_id, url, secret_encrypted, event_types, active, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.DB().ExecContext(ctx, query,
+		endpoint.ID, endpoint.StoreID, endpoint.URL, endpoint.SecretEncrypted,
+		pq.Array(eventTypeStrings(endpoint.EventTypes)), endpoint.Active, endpoint.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	return nil
+}
+
+// ListEndpoints returns a store's webhook endpoints, oldest first
+func (r *WebhookRepository) ListEndpoints(ctx context.Context, storeID uuid.UUID) ([]*models.WebhookEndpoint, error) {
+	query := `
+		SELECT id, store_id, url, secret_encrypted, event_types, active, created_at
+		FROM webhook_endpoints
+		WHERE store_id = $1
+		ORDER BY created_at ASC`
+
+	rows, err := r.DB().QueryContext(ctx, query, storeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook endpoints: %w", err)
+	}
+	defer rows.Close()
+
+	endpoints := []*models.WebhookEndpoint{}
+	for rows.Next() {
+		var endpoint models.WebhookEndpoint
+		var eventTypes []string
+		if err := rows.Scan(&endpoint.ID, &endpoint.StoreID, &endpoint.URL, &endpoint.SecretEncrypted,
+			pq.Array(&eventTypes), &endpoint.Active, &endpoint.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook endpoint: %w", err)
+		}
+		for _, eventType := range eventTypes {
+			endpoint.EventTypes = append(endpoint.EventTypes, models.WebhookEventType(eventType))
+		}
+		endpoints = append(endpoints, &endpoint)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook endpoints: %w", err)
+	}
+
+	return endpoints, nil
+}
+
+// DeleteEndpoint removes a store's webhook endpoint along with its delivery log
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, storeID, endpointID uuid.UUID) error {
+	query := `DELETE FROM webhook_endpoints WHERE id = $1 AND store_id = $2`
+	result, err := r.DB().ExecContext(ctx, query, endpointID, storeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return apperrors.NotFoundError{Resource: "webhook endpoint", ID: endpointID.String()}
+	}
+
+	return nil
+}
+
+// EnqueueDeliveriesWithTx queues the event for every active endpoint of its store that subscribes
+// to its type and returns how many deliveries were queued. The deliveries are written in the
+// transaction that records the event's change, so they exist exactly when the change commits.
+func (r *WebhookRepository) EnqueueDeliveriesWithTx(ctx context.Context, tx *sql.Tx, event *models.WebhookEvent, payload []byte) (int, error) {
+	query := `
+		INSERT INTO webhook_deliveries (endpoint_id, event_id, event_type, payload, next_attempt_at, created_at)
+		SELECT id, $3::uuid, $2, $4::jsonb, $5::timestamptz, $5::timestamptz
+		FROM webhook_endpoints
+		WHERE store_id = $1 AND active AND $2::text = ANY(event_types)`
+
+	result, err := tx.ExecContext(ctx, query, event.StoreID, string(event.Type), event.ID, string(payload), event.CreatedAt)
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	queued, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(queued), nil
+}
+
+// ClaimDueDeliveries leases up to limit pending deliveries that are due by pushing their next
+// attempt to leaseUntil, so concurrent dispatchers never send the same delivery twice. The
+// endpoint's URL and secret are returned with each delivery.
+func (r *WebhookRepository) ClaimDueDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM webhook_endpoints e
+		WHERE e.id = d.endpoint_id
+		  AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = $3 AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING ` + webhookDeliveryColumns + `, e.url, e.secret_encrypted`
+
+	rows, err := r.DB().QueryContext(ctx, query, now, leaseUntil, models.WebhookDeliveryPending, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(append(deliveryFields(&delivery), &delivery.URL, &delivery.SecretEncrypted)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// UpdateDelivery records the outcome of a delivery attempt
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, next_attempt_at = $4, last_attempt_at = $5,
+			response_status = $6, last_error = $7, delivered_at = $8
+		WHERE id = $1`
+
+	_, err := r.DB().ExecContext(ctx, query, delivery.ID, delivery.Status, delivery.Attempts, delivery.NextAttemptAt,
+		delivery.LastAttemptAt, delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns a page of a store's delivery log, newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int, error) {
+	whereConditions := []string{"e.store_id = $1"}
+	args := []any{filter.StoreID}
+	argIndex := 2
+
+	if filter.EndpointID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("d.endpoint_id = $%d", argIndex))
+		args = append(args, *filter.EndpointID)
+		argIndex++
+	}
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("d.status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+
+	from := `
+		FROM webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE ` + strings.Join(whereConditions, " AND ")
+
+	var totalCount int
+	if err := r.DB().QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s%s
+		ORDER BY d.created_at DESC, d.id
+		LIMIT $%d OFFSET $%d`, webhookDeliveryColumns, from, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		if err := rows.Scan(deliveryFields(&delivery)...); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating webhook deliveries: %w", err)
+	}
+
+	return deliveries, totalCount, nil
+}
+
+// deliveryFields returns scan targets in webhookDeliveryColumns order
+func deliveryFields(d *models.WebhookDelivery) []any {
+	return []any{&d.ID, &d.EndpointID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.NextAttemptAt, &d.LastAttemptAt, &d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.DeliveredAt}
+}
+
+func eventTypeStrings(eventTypes []models.WebhookEventType) []string {
+	values := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		values[i] = string(eventType)
+	}
+	return values
+}
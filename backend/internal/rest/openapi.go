@@ -0,0 +1,195 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// openAPIDocument describes the operations as an OpenAPI 3 document; schemas come from the body types
+func openAPIDocument(ops []operation) map[string]any {
+	schemas := schemaSet{}
+	errorRef := schemas.ref(reflect.TypeOf(Error{}))
+
+	paths := map[string]any{}
+	for _, op := range ops {
+		item, ok := paths[op.path].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[op.path] = item
+		}
+
+		spec := map[string]any{
+			"operationId": op.id,
+			"summary":     op.summary,
+		}
+
+		var params []any
+		for _, name := range pathParams(op.path) {
+			params = append(params, map[string]any{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   schemas.schema(uuidType),
+			})
+		}
+		for _, param := range op.query {
+			params = append(params, map[string]any{
+				"name":        param.name,
+				"in":          "query",
+				"description": param.description,
+				"schema":      map[string]any{"type": "integer", "minimum": 0},
+			})
+		}
+		if len(params) > 0 {
+			spec["parameters"] = params
+		}
+
+		if op.request != nil {
+			spec["requestBody"] = map[string]any{
+				"required": true,
+				"content":  jsonContent(schemas.ref(reflect.TypeOf(op.request))),
+			}
+		}
+
+		success := map[string]any{"description": http.StatusText(op.status)}
+		if op.response != nil {
+			success["content"] = jsonContent(schemas.ref(reflect.TypeOf(op.response)))
+		}
+		responses := map[string]any{strconv.Itoa(op.status): success}
+		for _, status := range op.errors {
+			responses[strconv.Itoa(status)] = map[string]any{
+				"description": http.StatusText(status),
+				"content":     jsonContent(errorRef),
+			}
+		}
+		spec["responses"] = responses
+
+		if op.access != accessPublic {
+			spec["security"] = []any{map[string]any{"basicAuth": []any{}}}
+		}
+
+		item[strings.ToLower(op.method)] = spec
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "Pet Store REST API",
+			"version":     "1.0.0",
+			"description": "REST facade over the pet store services; the GraphQL API at /graphql offers more.",
+		},
+		"servers": []any{map[string]any{"url": "/api/v1"}},
+		"paths":   paths,
+		"components": map[string]any{
+			"schemas": map[string]any(schemas),
+			"securitySchemes": map[string]any{
+				"basicAuth": map[string]any{"type": "http", "scheme": "basic"},
+			},
+		},
+	}
+}
+
+func jsonContent(schema map[string]any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// pathParams returns the {names} in a chi route pattern
+func pathParams(path string) []string {
+	var names []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			names = append(names, strings.Trim(segment, "{}"))
+		}
+	}
+	return names
+}
+
+// schemaSet collects the component schemas of the named struct types it has seen
+type schemaSet map[string]any
+
+// ref registers a struct type as a component schema and returns a reference to it
+func (s schemaSet) ref(t reflect.Type) map[string]any {
+	if _, ok := s[t.Name()]; !ok {
+		s[t.Name()] = nil // reserve the name so recursive types terminate
+		s[t.Name()] = s.object(t)
+	}
+	return map[string]any{"$ref": "#/components/schemas/" + t.Name()}
+}
+
+// schema describes a Go type as JSON encodes it
+func (s schemaSet) schema(t reflect.Type) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == uuidType:
+		return map[string]any{"type": "string", "format": "uuid"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.schema(t.Elem())
+		schema["nullable"] = true
+		return schema
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Struct:
+		return s.ref(t)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		panic("rest: no schema for " + t.String())
+	}
+}
+
+// object describes a struct from its json and enum tags
+func (s schemaSet) object(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []any
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		property := s.schema(field.Type)
+		if values := field.Tag.Get("enum"); values != "" {
+			var enum []any
+			for _, value := range strings.Split(values, ",") {
+				enum = append(enum, value)
+			}
+			property["enum"] = enum
+		}
+		properties[name] = property
+
+		if !strings.Contains(options, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,641 @@
+package rest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testStoreID = uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+	testPetID   = uuid.MustParse("3f1d2c4b-5a69-4e7f-8a9b-0c1d2e3f4a5b")
+	testOrderID = uuid.MustParse("9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d")
+	testTime    = time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+)
+
+type testMocks struct {
+	pets     *mocks.MockPetService
+	stores   *mocks.MockStoreService
+	orders   *mocks.MockOrderService
+	settings *mocks.MockStoreSettingsService
+}
+
+func newTestHandler() (*Handler, *testMocks) {
+	m := &testMocks{
+		pets:     new(mocks.MockPetService),
+		stores:   new(mocks.MockStoreService),
+		orders:   new(mocks.MockOrderService),
+		settings: new(mocks.MockStoreSettingsService),
+	}
+	return NewHandler(m.pets, m.stores, m.orders, m.settings), m
+}
+
+func testStore() *models.Store {
+	description := "Cats, dogs and the odd frog"
+	return &models.Store{
+		ID:          testStoreID,
+		Name:        "Pet Paradise",
+		OwnerID:     "merchant1",
+		Slug:        "pet-paradise",
+		Description: &description,
+		OpeningHours: []models.OpeningHours{
+			{Day: models.WeekdayMonday, Opens: "09:00", Closes: "17:00"},
+		},
+		CreatedAt: testTime,
+	}
+}
+
+func testPet(status models.PetStatus) *models.Pet {
+	return &models.Pet{
+		ID:                    testPetID,
+		StoreID:               testStoreID,
+		Name:                  "Rex",
+		Species:               models.PetSpeciesDog,
+		Age:                   2,
+		BreederName:           "Jane Breeder",
+		BreederEmailEncrypted: "encrypted",
+		Status:                status,
+		PriceCents:            50000,
+		CreatedAt:             testTime,
+	}
+}
+
+func testOrder(customerID string) *models.Order {
+	return &models.Order{
+		ID:            testOrderID,
+		CustomerID:    customerID,
+		StoreID:       testStoreID,
+		TotalPets:     1,
+		TotalCents:    45000,
+		DiscountCents: 5000,
+		PaymentStatus: models.PaymentStatusCaptured,
+		Status:        models.OrderStatusPlaced,
+		CreatedAt:     testTime,
+	}
+}
+
+func basicAuth(username, password string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+}
+
+// loadSpec fetches the document the router serves
+func loadSpec(t *testing.T, router http.Handler) map[string]any {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &spec))
+	return spec
+}
+
+func TestOpenAPI_DescribesEveryRoute(t *testing.T) {
+	handler, _ := newTestHandler()
+	router := handler.Router()
+	spec := loadSpec(t, router)
+
+	var routes []string
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route != "/openapi.json" {
+			routes = append(routes, method+" "+route)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	var documented []string
+	for path, item := range spec["paths"].(map[string]any) {
+		for method := range item.(map[string]any) {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(routes)
+	sort.Strings(documented)
+	assert.Equal(t, routes, documented)
+	assert.Equal(t, "3.0.3", spec["openapi"])
+}
+
+func TestOpenAPI_ReferencesResolve(t *testing.T) {
+	handler, _ := newTestHandler()
+	spec := loadSpec(t, handler.Router())
+
+	var walk func(value any)
+	walk = func(value any) {
+		switch v := value.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				_, err := resolveRef(spec, ref)
+				assert.NoError(t, err)
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	walk(spec)
+}
+
+func TestHandler_ConformsToOpenAPI(t *testing.T) {
+	merchant := basicAuth("merchant1", "merchant123")
+	customer := basicAuth("customer1", "customer123")
+	otherCustomer := basicAuth("customer2", "customer123")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		auth       string
+		body       string
+		setup      func(m *testMocks)
+		wantStatus int
+		wantCode   string
+		check      func(t *testing.T, body map[string]any)
+	}{
+		{
+			name:   "list open stores",
+			method: http.MethodGet, path: "/stores",
+			setup: func(m *testMocks) {
+				m.stores.On("ListOpenStores", mock.Anything).Return([]*models.Store{testStore()}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "unexpected errors are masked",
+			method: http.MethodGet, path: "/stores",
+			setup: func(m *testMocks) {
+				m.stores.On("ListOpenStores", mock.Anything).Return([]*models.Store(nil), errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   codeInternal,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "internal server error", body["error"].(map[string]any)["message"])
+			},
+		},
+		{
+			name:   "get store",
+			method: http.MethodGet, path: "/stores/" + testStoreID.String(),
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreByID", mock.Anything, testStoreID).Return(testStore(), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "store ID must be a UUID",
+			method: http.MethodGet, path: "/stores/not-a-uuid",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeBadUserInput,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "id", body["error"].(map[string]any)["field"])
+			},
+		},
+		{
+			name:   "unknown store",
+			method: http.MethodGet, path: "/stores/" + testStoreID.String(),
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreByID", mock.Anything, testStoreID).Return(nil, apperrors.NewStoreNotFound(testStoreID))
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeNotFound,
+		},
+		{
+			name:   "list store pets hides breeder details",
+			method: http.MethodGet, path: "/stores/" + testStoreID.String() + "/pets?limit=1",
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreByID", mock.Anything, testStoreID).Return(testStore(), nil)
+				m.pets.On("ListPets", mock.Anything, mock.MatchedBy(func(filter models.PetFilter) bool {
+					return *filter.StoreID == testStoreID && *filter.Status == models.PetStatusAvailable && filter.Limit == 1
+				})).Return([]*models.Pet{testPet(models.PetStatusAvailable)}, 3, nil)
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(&models.StoreSettings{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, float64(1), body["nextOffset"])
+				pet := body["items"].([]any)[0].(map[string]any)
+				assert.Equal(t, "[Hidden]", pet["breederName"])
+				assert.Equal(t, "[Hidden]", pet["breederEmail"])
+			},
+		},
+		{
+			name:   "last page of store pets has no next offset",
+			method: http.MethodGet, path: "/stores/" + testStoreID.String() + "/pets",
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreByID", mock.Anything, testStoreID).Return(testStore(), nil)
+				m.pets.On("ListPets", mock.Anything, mock.Anything).Return([]*models.Pet{testPet(models.PetStatusAvailable)}, 1, nil)
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(&models.StoreSettings{ShowBreederNames: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Nil(t, body["nextOffset"])
+				assert.Equal(t, "Jane Breeder", body["items"].([]any)[0].(map[string]any)["breederName"])
+			},
+		},
+		{
+			name:   "negative page offset",
+			method: http.MethodGet, path: "/stores/" + testStoreID.String() + "/pets?offset=-1",
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeBadUserInput,
+		},
+		{
+			name:   "get available pet",
+			method: http.MethodGet, path: "/pets/" + testPetID.String(),
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(&models.StoreSettings{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "sold pets are not public",
+			method: http.MethodGet, path: "/pets/" + testPetID.String(),
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusSold), nil)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeNotFound,
+		},
+		{
+			name:   "create pet",
+			method: http.MethodPost, path: "/pets", auth: merchant,
+			body: `{"storeID":"` + testStoreID.String() + `","name":"Rex","species":"Dog","age":2,` +
+				`"breederName":"Jane Breeder","breederEmail":"jane@example.com","priceCents":50000}`,
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("CreatePet", mock.Anything, mock.MatchedBy(func(input models.CreatePetInput) bool {
+					return input.StoreID == testStoreID && input.Species == models.PetSpeciesDog
+				})).Return(testPet(models.PetStatusAvailable), nil)
+			},
+			wantStatus: http.StatusCreated,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "jane@example.com", body["breederEmail"])
+			},
+		},
+		{
+			name:   "create pet without credentials",
+			method: http.MethodPost, path: "/pets",
+			body:       `{}`,
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   codeUnauthenticated,
+		},
+		{
+			name:   "create pet with a wrong password",
+			method: http.MethodPost, path: "/pets", auth: basicAuth("merchant1", "wrong"),
+			body:       `{}`,
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   codeUnauthenticated,
+		},
+		{
+			name:   "customers cannot create pets",
+			method: http.MethodPost, path: "/pets", auth: customer,
+			body:       `{}`,
+			wantStatus: http.StatusForbidden,
+			wantCode:   codeForbidden,
+		},
+		{
+			name:   "create pet rejects unknown fields",
+			method: http.MethodPost, path: "/pets", auth: merchant,
+			body:       `{"storeID":"` + testStoreID.String() + `","colour":"brown"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeBadUserInput,
+		},
+		{
+			name:   "create pet in a closed store",
+			method: http.MethodPost, path: "/pets", auth: merchant,
+			body: `{"storeID":"` + testStoreID.String() + `","name":"Rex","species":"Dog","age":2,` +
+				`"breederName":"Jane Breeder","breederEmail":"jane@example.com"}`,
+			setup: func(m *testMocks) {
+				store := testStore()
+				store.ClosedAt = &testTime
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(store, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCode:   codeBusinessRule,
+		},
+		{
+			name:   "delete pet",
+			method: http.MethodDelete, path: "/pets/" + testPetID.String(), auth: merchant,
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("DeletePetByID", mock.Anything, testPetID).Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "delete another merchant's pet",
+			method: http.MethodDelete, path: "/pets/" + testPetID.String(), auth: merchant,
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(nil, apperrors.NewStoreNotFound(testStoreID))
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeNotFound,
+		},
+		{
+			name:   "create order",
+			method: http.MethodPost, path: "/orders", auth: customer,
+			body: `{"petIDs":["` + testPetID.String() + `"],"cardNumber":"4242424242424242"}`,
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.orders.On("CreateOrder", mock.Anything, mock.MatchedBy(func(input models.CreateOrderInput) bool {
+					return input.CustomerID == "customer1" && input.StoreID == testStoreID
+				})).Return(testOrder("customer1"), nil)
+				m.orders.On("GetOrderPets", mock.Anything, testOrderID).Return([]*models.Pet{testPet(models.PetStatusSold)}, nil)
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(&models.StoreSettings{}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, float64(50000), body["subtotalCents"])
+				assert.Equal(t, "[Hidden]", body["pets"].([]any)[0].(map[string]any)["breederName"])
+			},
+		},
+		{
+			name:   "create order needs pets",
+			method: http.MethodPost, path: "/orders", auth: customer,
+			body:       `{"petIDs":[]}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   codeBadUserInput,
+		},
+		{
+			name:   "create order with unavailable pets",
+			method: http.MethodPost, path: "/orders", auth: customer,
+			body: `{"petIDs":["` + testPetID.String() + `"]}`,
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusSold), nil)
+				m.orders.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, apperrors.NewPetsUnavailable([]uuid.UUID{testPetID}))
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   codePetUnavailable,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, []any{testPetID.String()}, body["error"].(map[string]any)["petIDs"])
+			},
+		},
+		{
+			name:   "get own order",
+			method: http.MethodGet, path: "/orders/" + testOrderID.String(), auth: customer,
+			setup: func(m *testMocks) {
+				m.orders.On("GetOrderByID", mock.Anything, testOrderID).Return(testOrder("customer1"), nil)
+				m.orders.On("GetOrderPets", mock.Anything, testOrderID).Return([]*models.Pet{testPet(models.PetStatusSold)}, nil)
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(&models.StoreSettings{ShowBreederNames: true}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "Jane Breeder", body["pets"].([]any)[0].(map[string]any)["breederName"])
+			},
+		},
+		{
+			name:   "get order at the merchant's store",
+			method: http.MethodGet, path: "/orders/" + testOrderID.String(), auth: merchant,
+			setup: func(m *testMocks) {
+				m.orders.On("GetOrderByID", mock.Anything, testOrderID).Return(testOrder("customer1"), nil)
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.orders.On("GetOrderPets", mock.Anything, testOrderID).Return([]*models.Pet{testPet(models.PetStatusSold)}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, body map[string]any) {
+				assert.Equal(t, "Jane Breeder", body["pets"].([]any)[0].(map[string]any)["breederName"])
+			},
+		},
+		{
+			name:   "another customer's order",
+			method: http.MethodGet, path: "/orders/" + testOrderID.String(), auth: otherCustomer,
+			setup: func(m *testMocks) {
+				m.orders.On("GetOrderByID", mock.Anything, testOrderID).Return(testOrder("customer1"), nil)
+			},
+			wantStatus: http.StatusNotFound,
+			wantCode:   codeNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, m := newTestHandler()
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			router := handler.Router()
+			spec := loadSpec(t, router)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+
+			op := specOperation(t, spec, router, tt.method, tt.path)
+			if tt.wantStatus < http.StatusBadRequest && tt.body != "" {
+				schema := op["requestBody"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"]
+				assert.NoError(t, validateJSON(spec, schema.(map[string]any), []byte(tt.body)), "request body")
+			}
+
+			response, ok := op["responses"].(map[string]any)[strconv.Itoa(rec.Code)].(map[string]any)
+			require.True(t, ok, "status %d is not documented", rec.Code)
+
+			content, ok := response["content"].(map[string]any)
+			if !ok {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+			schema := content["application/json"].(map[string]any)["schema"].(map[string]any)
+			require.NoError(t, validateJSON(spec, schema, rec.Body.Bytes()), rec.Body.String())
+
+			var decoded map[string]any
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &decoded))
+			if tt.wantCode != "" {
+				assert.Equal(t, tt.wantCode, decoded["error"].(map[string]any)["code"])
+			}
+			if tt.check != nil {
+				tt.check(t, decoded)
+			}
+
+			m.pets.AssertExpectations(t)
+			m.stores.AssertExpectations(t)
+			m.orders.AssertExpectations(t)
+			m.settings.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRouter_UnknownEndpoints(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "unknown path", method: http.MethodGet, path: "/breeders", wantStatus: http.StatusNotFound},
+		{name: "unsupported method", method: http.MethodPut, path: "/stores", wantStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, _ := newTestHandler()
+			rec := httptest.NewRecorder()
+			handler.Router().ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			var body Error
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.NotEmpty(t, body.Error.Code)
+		})
+	}
+}
+
+// specOperation finds the documented operation for the route chi matches
+func specOperation(t *testing.T, spec map[string]any, router chi.Router, method, path string) map[string]any {
+	t.Helper()
+
+	rctx := chi.NewRouteContext()
+	path, _, _ = strings.Cut(path, "?")
+	require.True(t, router.Match(rctx, method, path), "no route for %s %s", method, path)
+
+	item, ok := spec["paths"].(map[string]any)[rctx.RoutePattern()].(map[string]any)
+	require.True(t, ok, "path %s is not documented", rctx.RoutePattern())
+	op, ok := item[strings.ToLower(method)].(map[string]any)
+	require.True(t, ok, "%s %s is not documented", method, rctx.RoutePattern())
+	return op
+}
+
+func resolveRef(spec map[string]any, ref string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(ref, "#/components/schemas/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported reference %q", ref)
+	}
+	schema, ok := spec["components"].(map[string]any)["schemas"].(map[string]any)[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q", name)
+	}
+	return schema, nil
+}
+
+func validateJSON(spec, schema map[string]any, data []byte) error {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	return validateValue(spec, schema, value, "$")
+}
+
+// validateValue checks the subset of JSON Schema the generated document uses
+func validateValue(spec, schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := resolveRef(spec, ref)
+		if err != nil {
+			return err
+		}
+		schema = resolved
+	}
+
+	if value == nil {
+		if schema["nullable"] == true {
+			return nil
+		}
+		return fmt.Errorf("%s: null is not allowed", path)
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: want an object", path)
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]any)
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		for name, child := range object {
+			property, ok := properties[name].(map[string]any)
+			if !ok {
+				if schema["additionalProperties"] == false {
+					return fmt.Errorf("%s: unexpected property %q", path, name)
+				}
+				continue
+			}
+			if err := validateValue(spec, property, child, path+"."+name); err != nil {
+				return err
+			}
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return fmt.Errorf("%s: want an array", path)
+		}
+		for i, item := range items {
+			if err := validateValue(spec, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: want a string", path)
+		}
+		switch schema["format"] {
+		case "uuid":
+			if _, err := uuid.Parse(s); err != nil {
+				return fmt.Errorf("%s: %q is not a UUID", path, s)
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				return fmt.Errorf("%s: %q is not a date-time", path, s)
+			}
+		}
+		if enum, ok := schema["enum"].([]any); ok {
+			found := false
+			for _, allowed := range enum {
+				found = found || allowed == s
+			}
+			if !found {
+				return fmt.Errorf("%s: %q is not one of %v", path, s, enum)
+			}
+		}
+	case "integer":
+		n, ok := value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Errorf("%s: want an integer", path)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: want a number", path)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: want a boolean", path)
+		}
+	default:
+		return fmt.Errorf("%s: schema has no type", path)
+	}
+	return nil
+}
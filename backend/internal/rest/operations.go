@@ -0,0 +1,415 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+)
+
+// access says who may call an operation
+type access int
+
+const (
+	accessPublic access = iota
+	accessUser
+	accessMerchant
+	accessCustomer
+)
+
+// operation is one endpoint; the router and the OpenAPI document are both built from these
+type operation struct {
+	id       string
+	method   string
+	path     string
+	summary  string
+	access   access
+	query    []queryParam
+	request  any // request body type, nil for none
+	status   int
+	response any // response body type, nil for none
+	errors   []int
+	handle   http.HandlerFunc
+}
+
+// queryParam is an optional integer query parameter
+type queryParam struct {
+	name        string
+	description string
+}
+
+var pageParams = []queryParam{
+	{name: "limit", description: "Page size, capped by the store's maximum (default 50)"},
+	{name: "offset", description: "Number of pets to skip"},
+}
+
+func (h *Handler) operations() []operation {
+	return []operation{
+		{
+			id: "listStores", method: http.MethodGet, path: "/stores",
+			summary:  "List open stores",
+			status:   http.StatusOK,
+			response: StoreList{},
+			errors:   []int{http.StatusInternalServerError},
+			handle:   h.listStores,
+		},
+		{
+			id: "getStore", method: http.MethodGet, path: "/stores/{id}",
+			summary:  "Get a store",
+			status:   http.StatusOK,
+			response: Store{},
+			errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+			handle:   h.getStore,
+		},
+		{
+			id: "listStorePets", method: http.MethodGet, path: "/stores/{id}/pets",
+			summary:  "List a store's available pets",
+			query:    pageParams,
+			status:   http.StatusOK,
+			response: PetList{},
+			errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+			handle:   h.listStorePets,
+		},
+		{
+			id: "getPet", method: http.MethodGet, path: "/pets/{id}",
+			summary:  "Get an available pet",
+			status:   http.StatusOK,
+			response: Pet{},
+			errors:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+			handle:   h.getPet,
+		},
+		{
+			id: "createPet", method: http.MethodPost, path: "/pets",
+			summary:  "List a new pet in one of the merchant's stores",
+			access:   accessMerchant,
+			request:  CreatePetRequest{},
+			status:   http.StatusCreated,
+			response: Pet{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+				http.StatusUnprocessableEntity, http.StatusInternalServerError},
+			handle: h.createPet,
+		},
+		{
+			id: "deletePet", method: http.MethodDelete, path: "/pets/{id}",
+			summary: "Delete a pet from one of the merchant's stores",
+			access:  accessMerchant,
+			status:  http.StatusNoContent,
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+				http.StatusInternalServerError},
+			handle: h.deletePet,
+		},
+		{
+			id: "createOrder", method: http.MethodPost, path: "/orders",
+			summary:  "Buy pets from one store",
+			access:   accessCustomer,
+			request:  CreateOrderRequest{},
+			status:   http.StatusCreated,
+			response: Order{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound,
+				http.StatusConflict, http.StatusUnprocessableEntity, http.StatusInternalServerError},
+			handle: h.createOrder,
+		},
+		{
+			id: "getOrder", method: http.MethodGet, path: "/orders/{id}",
+			summary:  "Get an order placed by the customer or at the merchant's store",
+			access:   accessUser,
+			status:   http.StatusOK,
+			response: Order{},
+			errors: []int{http.StatusBadRequest, http.StatusUnauthorized, http.StatusNotFound,
+				http.StatusInternalServerError},
+			handle: h.getOrder,
+		},
+	}
+}
+
+func (h *Handler) listStores(w http.ResponseWriter, r *http.Request) {
+	stores, err := h.stores.ListOpenStores(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := StoreList{Items: []Store{}}
+	for _, store := range stores {
+		result.Items = append(result.Items, storeFromModel(store))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) getStore(w http.ResponseWriter, r *http.Request) {
+	storeID, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	store, err := h.stores.GetStoreByID(r.Context(), storeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, storeFromModel(store))
+}
+
+// listStorePets follows the availablePets rules: available pets only, breeder emails hidden and
+// breeder names shown only where the store allows it
+func (h *Handler) listStorePets(w http.ResponseWriter, r *http.Request) {
+	storeID, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	offset, err := queryInt(r, "offset")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if _, err := h.stores.GetStoreByID(r.Context(), storeID); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	status := models.PetStatusAvailable
+	pets, totalCount, err := h.pets.ListPets(r.Context(), models.PetFilter{
+		StoreID: &storeID,
+		Status:  &status,
+		Limit:   limit,
+		Offset:  offset,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	settings, err := h.settings.GetStoreSettings(r.Context(), storeID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := PetList{Items: []Pet{}, TotalCount: totalCount}
+	for _, pet := range pets {
+		result.Items = append(result.Items, publicPet(pet, settings))
+	}
+	if next := offset + len(pets); next < totalCount {
+		result.NextOffset = &next
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) getPet(w http.ResponseWriter, r *http.Request) {
+	petID, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	pet, err := h.pets.GetPetByID(r.Context(), petID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if pet.Status != models.PetStatusAvailable {
+		writeError(w, apperrors.NewPetNotFound(petID))
+		return
+	}
+
+	settings, err := h.settings.GetStoreSettings(r.Context(), pet.StoreID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, publicPet(pet, settings))
+}
+
+// createPet applies the createPet mutation's rules: the store must be the merchant's and open
+func (h *Handler) createPet(w http.ResponseWriter, r *http.Request) {
+	var body CreatePetRequest
+	if err := decodeJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+
+	username, _ := auth.GetUser(r.Context())
+	store, err := h.stores.GetStoreForOwner(r.Context(), username, body.StoreID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if !store.IsOpen() {
+		writeError(w, apperrors.NewBusinessRuleError("pets cannot be added to a closed store"))
+		return
+	}
+
+	pet, err := h.pets.CreatePet(r.Context(), models.CreatePetInput{
+		StoreID:      store.ID,
+		Name:         body.Name,
+		Species:      models.PetSpecies(body.Species),
+		Age:          body.Age,
+		PictureURL:   body.PictureURL,
+		Description:  body.Description,
+		BreederName:  body.BreederName,
+		BreederEmail: body.BreederEmail,
+		PriceCents:   body.PriceCents,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := petFromModel(pet)
+	result.BreederEmail = body.BreederEmail
+	writeJSON(w, http.StatusCreated, result)
+}
+
+func (h *Handler) deletePet(w http.ResponseWriter, r *http.Request) {
+	petID, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	pet, err := h.pets.GetPetByID(r.Context(), petID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	username, _ := auth.GetUser(r.Context())
+	if _, err := h.stores.GetStoreForOwner(r.Context(), username, pet.StoreID); err != nil {
+		if apperrors.IsNotFound(err) {
+			err = apperrors.NewPetNotFound(petID)
+		}
+		writeError(w, err)
+		return
+	}
+
+	if err := h.pets.DeletePetByID(r.Context(), petID); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// createOrder buys pets like the purchasePets mutation; the store is the first pet's
+func (h *Handler) createOrder(w http.ResponseWriter, r *http.Request) {
+	var body CreateOrderRequest
+	if err := decodeJSON(w, r, &body); err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(body.PetIDs) == 0 {
+		writeError(w, apperrors.NewValidationError("petIDs", "no pets specified"))
+		return
+	}
+
+	firstPet, err := h.pets.GetPetByID(r.Context(), body.PetIDs[0])
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	username, _ := auth.GetUser(r.Context())
+	order, err := h.orders.CreateOrder(r.Context(), models.CreateOrderInput{
+		CustomerID:   username,
+		StoreID:      firstPet.StoreID,
+		PetIDs:       body.PetIDs,
+		CardNumber:   body.CardNumber,
+		DiscountCode: body.DiscountCode,
+		CustomerAge:  body.CustomerAge,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	h.writeOrder(w, r, http.StatusCreated, order, false)
+}
+
+// getOrder shows an order to the customer who placed it or the merchant of its store, like receipts
+func (h *Handler) getOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := pathID(r, "id")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	order, err := h.orders.GetOrderByID(r.Context(), orderID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	username, _ := auth.GetUser(r.Context())
+	userType, _ := auth.GetUserType(r.Context())
+	merchant := userType == auth.UserTypeMerchant
+	if merchant {
+		if _, err := h.stores.GetStoreForOwner(r.Context(), username, order.StoreID); err != nil {
+			if apperrors.IsNotFound(err) {
+				err = apperrors.NewOrderNotFound(orderID)
+			}
+			writeError(w, err)
+			return
+		}
+	} else if order.CustomerID != username {
+		writeError(w, apperrors.NewOrderNotFound(orderID))
+		return
+	}
+
+	h.writeOrder(w, r, http.StatusOK, order, merchant)
+}
+
+// writeOrder writes an order with its pets; merchant says the caller is the store's merchant,
+// who sees breeder names the store hides from its customers
+func (h *Handler) writeOrder(w http.ResponseWriter, r *http.Request, status int, order *models.Order, merchant bool) {
+	pets, err := h.orders.GetOrderPets(r.Context(), order.ID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := orderFromModel(order, pets)
+	if !merchant {
+		settings, err := h.settings.GetStoreSettings(r.Context(), order.StoreID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		result.Pets = result.Pets[:0]
+		for _, pet := range pets {
+			result.Pets = append(result.Pets, publicPet(pet, settings))
+		}
+	}
+	writeJSON(w, status, result)
+}
+
+// publicPet converts a pet for anyone but its merchant
+func publicPet(pet *models.Pet, settings *models.StoreSettings) Pet {
+	result := petFromModel(pet)
+	if !settings.ShowBreederNames {
+		result.BreederName = "[Hidden]"
+	}
+	return result
+}
+
+// queryInt parses an optional non-negative integer query parameter
+func queryInt(r *http.Request, name string) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, apperrors.NewValidationError(name, "must be a non-negative integer")
+	}
+	return n, nil
+}
@@ -0,0 +1,175 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// The types below are the API's request and response bodies. The OpenAPI document is generated
+// from them: fields without omitempty are required, pointers are nullable and the enum tag lists
+// the allowed values.
+
+// Store is a pet store
+type Store struct {
+	ID           uuid.UUID      `json:"id"`
+	Name         string         `json:"name"`
+	Slug         string         `json:"slug"`
+	Description  *string        `json:"description"`
+	Address      *string        `json:"address"`
+	Phone        *string        `json:"phone"`
+	LogoURL      *string        `json:"logoURL"`
+	OpeningHours []OpeningHours `json:"openingHours"`
+	Open         bool           `json:"open"`
+	Latitude     *float64       `json:"latitude"`
+	Longitude    *float64       `json:"longitude"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// OpeningHours are a store's hours on one weekday
+type OpeningHours struct {
+	Day    string `json:"day" enum:"monday,tuesday,wednesday,thursday,friday,saturday,sunday"`
+	Opens  string `json:"opens"`
+	Closes string `json:"closes"`
+}
+
+// StoreList is every open store
+type StoreList struct {
+	Items []Store `json:"items"`
+}
+
+// Pet is a pet listed by a store. Breeder emails are only shown to the store's merchant.
+type Pet struct {
+	ID           uuid.UUID `json:"id"`
+	StoreID      uuid.UUID `json:"storeID"`
+	Name         string    `json:"name"`
+	Species      string    `json:"species" enum:"Cat,Dog,Frog"`
+	Age          int       `json:"age"`
+	PictureURL   *string   `json:"pictureUrl"`
+	Description  *string   `json:"description"`
+	BreederName  string    `json:"breederName"`
+	BreederEmail string    `json:"breederEmail"`
+	PriceCents   int64     `json:"priceCents"`
+	Status       string    `json:"status" enum:"available,reserved,sold"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// PetList is one page of pets; nextOffset is null on the last page
+type PetList struct {
+	Items      []Pet `json:"items"`
+	TotalCount int   `json:"totalCount"`
+	NextOffset *int  `json:"nextOffset"`
+}
+
+// CreatePetRequest lists a new pet in one of the merchant's stores
+type CreatePetRequest struct {
+	StoreID      uuid.UUID `json:"storeID"`
+	Name         string    `json:"name"`
+	Species      string    `json:"species" enum:"Cat,Dog,Frog"`
+	Age          int       `json:"age"`
+	PictureURL   *string   `json:"pictureUrl,omitempty"`
+	Description  *string   `json:"description,omitempty"`
+	BreederName  string    `json:"breederName"`
+	BreederEmail string    `json:"breederEmail"`
+	PriceCents   int64     `json:"priceCents,omitempty"`
+}
+
+// Order is a purchase of one or more pets from a store
+type Order struct {
+	ID            uuid.UUID `json:"id"`
+	StoreID       uuid.UUID `json:"storeID"`
+	CustomerID    string    `json:"customerID"`
+	Pets          []Pet     `json:"pets"`
+	TotalPets     int       `json:"totalPets"`
+	SubtotalCents int64     `json:"subtotalCents"`
+	DiscountCents int64     `json:"discountCents"`
+	TotalCents    int64     `json:"totalCents"`
+	PaymentStatus string    `json:"paymentStatus" enum:"none,pending,authorized,captured,refunded,failed"`
+	Status        string    `json:"status" enum:"placed,pickup_scheduled,completed"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// CreateOrderRequest buys pets from one store
+type CreateOrderRequest struct {
+	PetIDs       []uuid.UUID `json:"petIDs"`
+	CardNumber   string      `json:"cardNumber,omitempty"`
+	DiscountCode string      `json:"discountCode,omitempty"`
+	CustomerAge  *int        `json:"customerAge,omitempty"`
+}
+
+// Error is the body of every failed request
+type Error struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes what went wrong; code matches the GraphQL API's extensions.code
+type ErrorDetail struct {
+	Code    string      `json:"code" enum:"BAD_USER_INPUT,NOT_FOUND,CONFLICT,BUSINESS_RULE_VIOLATION,PET_UNAVAILABLE,UNAUTHENTICATED,FORBIDDEN,INTERNAL_SERVER_ERROR"`
+	Message string      `json:"message"`
+	Field   string      `json:"field,omitempty"`
+	PetIDs  []uuid.UUID `json:"petIDs,omitempty"`
+}
+
+func storeFromModel(store *models.Store) Store {
+	result := Store{
+		ID:           store.ID,
+		Name:         store.Name,
+		Slug:         store.Slug,
+		Description:  store.Description,
+		Address:      store.Address,
+		Phone:        store.Phone,
+		LogoURL:      store.LogoURL,
+		OpeningHours: []OpeningHours{},
+		Open:         store.IsOpen(),
+		Latitude:     store.Latitude,
+		Longitude:    store.Longitude,
+		CreatedAt:    store.CreatedAt,
+	}
+	for _, hours := range store.OpeningHours {
+		result.OpeningHours = append(result.OpeningHours, OpeningHours{
+			Day:    string(hours.Day),
+			Opens:  hours.Opens,
+			Closes: hours.Closes,
+		})
+	}
+	return result
+}
+
+// petFromModel converts a pet with its breeder email hidden; callers fill it in for the store's merchant
+func petFromModel(pet *models.Pet) Pet {
+	return Pet{
+		ID:           pet.ID,
+		StoreID:      pet.StoreID,
+		Name:         pet.Name,
+		Species:      string(pet.Species),
+		Age:          pet.Age,
+		PictureURL:   pet.PictureURL,
+		Description:  pet.Description,
+		BreederName:  pet.BreederName,
+		BreederEmail: "[Hidden]",
+		PriceCents:   pet.PriceCents,
+		Status:       string(pet.Status),
+		CreatedAt:    pet.CreatedAt,
+	}
+}
+
+func orderFromModel(order *models.Order, pets []*models.Pet) Order {
+	result := Order{
+		ID:            order.ID,
+		StoreID:       order.StoreID,
+		CustomerID:    order.CustomerID,
+		Pets:          []Pet{},
+		TotalPets:     order.TotalPets,
+		SubtotalCents: order.SubtotalCents(),
+		DiscountCents: order.DiscountCents,
+		TotalCents:    order.TotalCents,
+		PaymentStatus: string(order.PaymentStatus),
+		Status:        string(order.Status),
+		CreatedAt:     order.CreatedAt,
+	}
+	for _, pet := range pets {
+		result.Pets = append(result.Pets, petFromModel(pet))
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// maxBodySize caps JSON request bodies
+const maxBodySize = 1 << 20
+
+// Error codes, the same values the GraphQL API reports in extensions.code
+const (
+	codeBadUserInput    = "BAD_USER_INPUT"
+	codeNotFound        = "NOT_FOUND"
+	codeConflict        = "CONFLICT"
+	codeBusinessRule    = "BUSINESS_RULE_VIOLATION"
+	codePetUnavailable  = "PET_UNAVAILABLE"
+	codeUnauthenticated = "UNAUTHENTICATED"
+	codeForbidden       = "FORBIDDEN"
+	codeInternal        = "INTERNAL_SERVER_ERROR"
+)
+
+// Handler serves the versioned REST API on top of the services the GraphQL resolvers use
+type Handler struct {
+	pets     service.PetServiceInterface
+	stores   service.StoreServiceInterface
+	orders   service.OrderServiceInterface
+	settings service.StoreSettingsServiceInterface
+}
+
+// NewHandler creates a new REST API handler
+func NewHandler(pets service.PetServiceInterface, stores service.StoreServiceInterface, orders service.OrderServiceInterface, settings service.StoreSettingsServiceInterface) *Handler {
+	return &Handler{
+		pets:     pets,
+		stores:   stores,
+		orders:   orders,
+		settings: settings,
+	}
+}
+
+// Router returns every operation, plus the OpenAPI document generated from them at /openapi.json
+func (h *Handler) Router() chi.Router {
+	router := chi.NewRouter()
+
+	ops := h.operations()
+	for _, op := range ops {
+		router.Method(op.method, op.path, authenticate(op.access, op.handle))
+	}
+
+	spec, err := json.Marshal(openAPIDocument(ops))
+	if err != nil {
+		panic(fmt.Sprintf("rest: failed to build the OpenAPI document: %v", err))
+	}
+	router.Get("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(spec)
+	})
+
+	router.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeErrorCode(w, http.StatusNotFound, codeNotFound, "no such endpoint")
+	})
+	router.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		writeErrorCode(w, http.StatusMethodNotAllowed, codeBadUserInput, "method not allowed")
+	})
+
+	return router
+}
+
+// authenticate checks Basic credentials for everything but public operations
+func authenticate(level access, next http.HandlerFunc) http.HandlerFunc {
+	if level == accessPublic {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := auth.Authenticate(r.Context(), r.Header.Get("Authorization"))
+		if err == nil {
+			switch level {
+			case accessMerchant:
+				err = auth.RequireMerchant(ctx)
+			case accessCustomer:
+				err = auth.RequireCustomer(ctx)
+			}
+		}
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// writeJSON writes value as the JSON response body
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("Failed to write REST response: %v", err)
+	}
+}
+
+// decodeJSON reads a request body into dst, rejecting unknown fields
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return apperrors.NewValidationError("body", fmt.Sprintf("invalid JSON: %v", err))
+	}
+	return nil
+}
+
+// pathID parses a UUID path parameter
+func pathID(r *http.Request, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(chi.URLParam(r, name))
+	if err != nil {
+		return uuid.Nil, apperrors.NewValidationError(name, "must be a UUID")
+	}
+	return id, nil
+}
+
+// writeError maps service and auth errors to a status code and an Error body.
+// Unexpected errors are logged and reported without their details.
+func writeError(w http.ResponseWriter, err error) {
+	var (
+		validationErr   apperrors.ValidationError
+		conflictErr     apperrors.ConflictError
+		businessRuleErr apperrors.BusinessRuleError
+		unavailableErr  apperrors.PetsUnavailableError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		writeJSON(w, http.StatusBadRequest, Error{Error: ErrorDetail{Code: codeBadUserInput, Message: err.Error(), Field: validationErr.Field}})
+	case apperrors.IsNotFound(err):
+		writeErrorCode(w, http.StatusNotFound, codeNotFound, err.Error())
+	case errors.As(err, &conflictErr):
+		writeErrorCode(w, http.StatusConflict, codeConflict, err.Error())
+	case errors.As(err, &unavailableErr):
+		writeJSON(w, http.StatusConflict, Error{Error: ErrorDetail{Code: codePetUnavailable, Message: err.Error(), PetIDs: unavailableErr.PetIDs}})
+	case errors.As(err, &businessRuleErr):
+		writeErrorCode(w, http.StatusUnprocessableEntity, codeBusinessRule, err.Error())
+	case errors.Is(err, auth.ErrAuthorizationRequired), errors.Is(err, auth.ErrInvalidCredentials),
+		errors.Is(err, auth.ErrNoUser), errors.Is(err, auth.ErrNoUserType):
+		w.Header().Set("WWW-Authenticate", `Basic realm="Restricted"`)
+		writeErrorCode(w, http.StatusUnauthorized, codeUnauthenticated, err.Error())
+	case errors.Is(err, auth.ErrMerchantRequired), errors.Is(err, auth.ErrCustomerRequired):
+		writeErrorCode(w, http.StatusForbidden, codeForbidden, err.Error())
+	default:
+		log.Printf("REST request failed: %v", err)
+		writeErrorCode(w, http.StatusInternalServerError, codeInternal, "internal server error")
+	}
+}
+
+func writeErrorCode(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, Error{Error: ErrorDetail{Code: code, Message: message}})
+}
@@ -0,0 +1,96 @@
+package rpc
+
+import (
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/rpc/petstorev1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var petSpeciesFromProto = map[petstorev1.PetSpecies]models.PetSpecies{
+	petstorev1.PetSpecies_PET_SPECIES_CAT:  models.PetSpeciesCat,
+	petstorev1.PetSpecies_PET_SPECIES_DOG:  models.PetSpeciesDog,
+	petstorev1.PetSpecies_PET_SPECIES_FROG: models.PetSpeciesFrog,
+}
+
+var petSpeciesToProto = map[models.PetSpecies]petstorev1.PetSpecies{
+	models.PetSpeciesCat:  petstorev1.PetSpecies_PET_SPECIES_CAT,
+	models.PetSpeciesDog:  petstorev1.PetSpecies_PET_SPECIES_DOG,
+	models.PetSpeciesFrog: petstorev1.PetSpecies_PET_SPECIES_FROG,
+}
+
+var petStatusFromProto = map[petstorev1.PetStatus]models.PetStatus{
+	petstorev1.PetStatus_PET_STATUS_AVAILABLE: models.PetStatusAvailable,
+	petstorev1.PetStatus_PET_STATUS_SOLD:      models.PetStatusSold,
+	petstorev1.PetStatus_PET_STATUS_RESERVED:  models.PetStatusReserved,
+}
+
+var petStatusToProto = map[models.PetStatus]petstorev1.PetStatus{
+	models.PetStatusAvailable: petstorev1.PetStatus_PET_STATUS_AVAILABLE,
+	models.PetStatusSold:      petstorev1.PetStatus_PET_STATUS_SOLD,
+	models.PetStatusReserved:  petstorev1.PetStatus_PET_STATUS_RESERVED,
+}
+
+var paymentStatusToProto = map[models.PaymentStatus]petstorev1.PaymentStatus{
+	models.PaymentStatusNone:       petstorev1.PaymentStatus_PAYMENT_STATUS_NONE,
+	models.PaymentStatusPending:    petstorev1.PaymentStatus_PAYMENT_STATUS_PENDING,
+	models.PaymentStatusAuthorized: petstorev1.PaymentStatus_PAYMENT_STATUS_AUTHORIZED,
+	models.PaymentStatusCaptured:   petstorev1.PaymentStatus_PAYMENT_STATUS_CAPTURED,
+	models.PaymentStatusRefunded:   petstorev1.PaymentStatus_PAYMENT_STATUS_REFUNDED,
+	models.PaymentStatusFailed:     petstorev1.PaymentStatus_PAYMENT_STATUS_FAILED,
+}
+
+var orderStatusToProto = map[models.OrderStatus]petstorev1.OrderStatus{
+	models.OrderStatusPlaced:          petstorev1.OrderStatus_ORDER_STATUS_PLACED,
+	models.OrderStatusPickupScheduled: petstorev1.OrderStatus_ORDER_STATUS_PICKUP_SCHEDULED,
+	models.OrderStatusCompleted:       petstorev1.OrderStatus_ORDER_STATUS_COMPLETED,
+}
+
+func storeToProto(store *models.Store) *petstorev1.Store {
+	return &petstorev1.Store{
+		Id:        store.ID.String(),
+		Name:      store.Name,
+		Slug:      store.Slug,
+		Address:   store.Address,
+		Phone:     store.Phone,
+		Open:      store.IsOpen(),
+		CreatedAt: timestamppb.New(store.CreatedAt),
+	}
+}
+
+// petToProto converts a pet with its breeder email hidden; callers fill it in for the store's merchant
+// and hide the breeder name from customers of stores that do not show it
+func petToProto(pet *models.Pet) *petstorev1.Pet {
+	return &petstorev1.Pet{
+		Id:           pet.ID.String(),
+		StoreId:      pet.StoreID.String(),
+		Name:         pet.Name,
+		Species:      petSpeciesToProto[pet.Species],
+		Age:          int32(pet.Age),
+		PictureUrl:   pet.PictureURL,
+		Description:  pet.Description,
+		BreederName:  pet.BreederName,
+		BreederEmail: "[Hidden]",
+		PriceCents:   pet.PriceCents,
+		Status:       petStatusToProto[pet.Status],
+		CreatedAt:    timestamppb.New(pet.CreatedAt),
+	}
+}
+
+func orderToProto(order *models.Order, pets []*models.Pet) *petstorev1.Order {
+	result := &petstorev1.Order{
+		Id:            order.ID.String(),
+		StoreId:       order.StoreID.String(),
+		CustomerId:    order.CustomerID,
+		TotalPets:     int32(order.TotalPets),
+		SubtotalCents: order.SubtotalCents(),
+		DiscountCents: order.DiscountCents,
+		TotalCents:    order.TotalCents,
+		PaymentStatus: paymentStatusToProto[order.PaymentStatus],
+		Status:        orderStatusToProto[order.Status],
+		CreatedAt:     timestamppb.New(order.CreatedAt),
+	}
+	for _, pet := range pets {
+		result.Pets = append(result.Pets, petToProto(pet))
+	}
+	return result
+}
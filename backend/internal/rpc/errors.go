@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain names the service in ErrorInfo details
+const errorDomain = "petstore"
+
+// Error reasons, the same values the GraphQL API reports in extensions.code
+const (
+	reasonBadUserInput    = "BAD_USER_INPUT"
+	reasonNotFound        = "NOT_FOUND"
+	reasonConflict        = "CONFLICT"
+	reasonBusinessRule    = "BUSINESS_RULE_VIOLATION"
+	reasonPetUnavailable  = "PET_UNAVAILABLE"
+	reasonUnauthenticated = "UNAUTHENTICATED"
+	reasonForbidden       = "FORBIDDEN"
+)
+
+// toStatus maps service and auth errors to a gRPC status with an ErrorInfo detail.
+// Unexpected errors are logged and reported without their details.
+func toStatus(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var (
+		validationErr   apperrors.ValidationError
+		conflictErr     apperrors.ConflictError
+		businessRuleErr apperrors.BusinessRuleError
+		unavailableErr  apperrors.PetsUnavailableError
+	)
+	switch {
+	case errors.As(err, &validationErr):
+		return withReason(codes.InvalidArgument, err.Error(), reasonBadUserInput, map[string]string{"field": validationErr.Field})
+	case apperrors.IsNotFound(err):
+		return withReason(codes.NotFound, err.Error(), reasonNotFound, nil)
+	case errors.As(err, &conflictErr):
+		return withReason(codes.AlreadyExists, err.Error(), reasonConflict, nil)
+	case errors.As(err, &unavailableErr):
+		petIDs := make([]string, 0, len(unavailableErr.PetIDs))
+		for _, petID := range unavailableErr.PetIDs {
+			petIDs = append(petIDs, petID.String())
+		}
+		return withReason(codes.Aborted, err.Error(), reasonPetUnavailable, map[string]string{"petIDs": strings.Join(petIDs, ",")})
+	case errors.As(err, &businessRuleErr):
+		return withReason(codes.FailedPrecondition, err.Error(), reasonBusinessRule, nil)
+	case errors.Is(err, auth.ErrAuthorizationRequired), errors.Is(err, auth.ErrInvalidCredentials),
+		errors.Is(err, auth.ErrNoUser), errors.Is(err, auth.ErrNoUserType):
+		return withReason(codes.Unauthenticated, err.Error(), reasonUnauthenticated, nil)
+	case errors.Is(err, auth.ErrMerchantRequired), errors.Is(err, auth.ErrCustomerRequired):
+		return withReason(codes.PermissionDenied, err.Error(), reasonForbidden, nil)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err)
+	default:
+		log.Printf("gRPC request failed: %v", err)
+		return status.New(codes.Internal, "internal server error")
+	}
+}
+
+func withReason(code codes.Code, message, reason string, metadata map[string]string) *status.Status {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
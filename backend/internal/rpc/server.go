@@ -0,0 +1,92 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	"github.com/fehepe/pet-store/backend/internal/rpc/petstorev1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// access says who may call a method
+type access int
+
+const (
+	accessUser access = iota
+	accessMerchant
+	accessCustomer
+)
+
+// methodAccess lists the role each method needs; unlisted methods only need a signed-in user
+var methodAccess = map[string]access{
+	petstorev1.PetStoreService_ListPets_FullMethodName:    accessMerchant,
+	petstorev1.PetStoreService_GetPet_FullMethodName:      accessMerchant,
+	petstorev1.PetStoreService_CreatePet_FullMethodName:   accessMerchant,
+	petstorev1.PetStoreService_CreateOrder_FullMethodName: accessCustomer,
+}
+
+// NewServer creates a gRPC server for the service. Calls are logged, their errors mapped to
+// status codes and their Basic credentials checked, in that order.
+func NewServer(svc *Service, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append(opts, grpc.ChainUnaryInterceptor(logRequests, mapErrors, authenticate))
+	server := grpc.NewServer(opts...)
+	petstorev1.RegisterPetStoreServiceServer(server, svc)
+	return server
+}
+
+// logRequests logs each call with its status code and duration
+func logRequests(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s in %s", info.FullMethod, status.Code(err), time.Since(start))
+	return resp, err
+}
+
+// mapErrors turns service errors and panics into gRPC statuses
+func mapErrors(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("gRPC panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+			resp, err = nil, status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+	if err != nil {
+		return nil, toStatus(err).Err()
+	}
+	return resp, nil
+}
+
+// authenticate checks the Basic credentials in the authorization metadata and the method's role
+func authenticate(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var header string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			header = values[0]
+		}
+	}
+
+	ctx, err := auth.Authenticate(ctx, header)
+	if err != nil {
+		return nil, err
+	}
+
+	switch methodAccess[info.FullMethod] {
+	case accessMerchant:
+		err = auth.RequireMerchant(ctx)
+	case accessCustomer:
+		err = auth.RequireCustomer(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
@@ -0,0 +1,492 @@
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/rpc/petstorev1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var (
+	testStoreID = uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+	testPetID   = uuid.MustParse("3f1d2c4b-5a69-4e7f-8a9b-0c1d2e3f4a5b")
+	testOrderID = uuid.MustParse("9a8b7c6d-5e4f-4a3b-9c2d-1e0f9a8b7c6d")
+	testTime    = time.Date(2026, 3, 14, 10, 0, 0, 0, time.UTC)
+)
+
+type testMocks struct {
+	pets     *mocks.MockPetService
+	stores   *mocks.MockStoreService
+	orders   *mocks.MockOrderService
+	settings *mocks.MockStoreSettingsService
+}
+
+// newTestClient serves the API over an in-memory connection
+func newTestClient(t *testing.T) (petstorev1.PetStoreServiceClient, *testMocks) {
+	t.Helper()
+
+	m := &testMocks{
+		pets:     new(mocks.MockPetService),
+		stores:   new(mocks.MockStoreService),
+		orders:   new(mocks.MockOrderService),
+		settings: new(mocks.MockStoreSettingsService),
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(NewService(m.pets, m.stores, m.orders, m.settings))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return petstorev1.NewPetStoreServiceClient(conn), m
+}
+
+func withCredentials(username, password string) context.Context {
+	header := "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", header)
+}
+
+func testStore() *models.Store {
+	address := "1 Main St"
+	return &models.Store{
+		ID:        testStoreID,
+		Name:      "Pet Paradise",
+		OwnerID:   "merchant1",
+		Slug:      "pet-paradise",
+		Address:   &address,
+		CreatedAt: testTime,
+	}
+}
+
+func testPet(status models.PetStatus) *models.Pet {
+	return &models.Pet{
+		ID:                    testPetID,
+		StoreID:               testStoreID,
+		Name:                  "Rex",
+		Species:               models.PetSpeciesDog,
+		Age:                   2,
+		BreederName:           "Jane Breeder",
+		BreederEmailEncrypted: "encrypted",
+		Status:                status,
+		PriceCents:            50000,
+		CreatedAt:             testTime,
+	}
+}
+
+// errorInfo returns the ErrorInfo detail of a status error
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", err)
+	return nil
+}
+
+func TestService_ListPets(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		req        *petstorev1.ListPetsRequest
+		setup      func(m *testMocks)
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "merchant lists their store",
+			ctx:  withCredentials("merchant1", "merchant123"),
+			req:  &petstorev1.ListPetsRequest{StoreId: testStoreID.String(), Status: petstorev1.PetStatus_PET_STATUS_SOLD, Limit: 10},
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("ListPets", mock.Anything, mock.MatchedBy(func(filter models.PetFilter) bool {
+					return *filter.StoreID == testStoreID && *filter.Status == models.PetStatusSold && filter.Limit == 10
+				})).Return([]*models.Pet{testPet(models.PetStatusSold)}, 1, nil)
+				m.pets.On("DecryptBreederEmail", "encrypted").Return("jane@example.com", nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "missing credentials",
+			ctx:        context.Background(),
+			req:        &petstorev1.ListPetsRequest{StoreId: testStoreID.String()},
+			wantCode:   codes.Unauthenticated,
+			wantReason: reasonUnauthenticated,
+		},
+		{
+			name:       "wrong password",
+			ctx:        withCredentials("merchant1", "wrong"),
+			req:        &petstorev1.ListPetsRequest{StoreId: testStoreID.String()},
+			wantCode:   codes.Unauthenticated,
+			wantReason: reasonUnauthenticated,
+		},
+		{
+			name:       "customers cannot list inventory",
+			ctx:        withCredentials("customer1", "customer123"),
+			req:        &petstorev1.ListPetsRequest{StoreId: testStoreID.String()},
+			wantCode:   codes.PermissionDenied,
+			wantReason: reasonForbidden,
+		},
+		{
+			name:       "store ID must be a UUID",
+			ctx:        withCredentials("merchant1", "merchant123"),
+			req:        &petstorev1.ListPetsRequest{StoreId: "not-a-uuid"},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonBadUserInput,
+		},
+		{
+			name: "another merchant's store",
+			ctx:  withCredentials("merchant1", "merchant123"),
+			req:  &petstorev1.ListPetsRequest{StoreId: testStoreID.String()},
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(nil, apperrors.NewStoreNotFound(testStoreID))
+			},
+			wantCode:   codes.NotFound,
+			wantReason: reasonNotFound,
+		},
+		{
+			name: "unexpected errors are masked",
+			ctx:  withCredentials("merchant1", "merchant123"),
+			req:  &petstorev1.ListPetsRequest{StoreId: testStoreID.String()},
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("ListPets", mock.Anything, mock.Anything).Return([]*models.Pet(nil), 0, errors.New("connection refused"))
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, m := newTestClient(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			resp, err := client.ListPets(tt.ctx, tt.req)
+
+			assert.Equal(t, tt.wantCode, status.Code(err), err)
+			if tt.wantReason != "" {
+				info := errorInfo(t, err)
+				assert.Equal(t, tt.wantReason, info.Reason)
+				assert.Equal(t, errorDomain, info.Domain)
+			}
+			if tt.wantCode == codes.Internal {
+				assert.Equal(t, "internal server error", status.Convert(err).Message())
+			}
+			if tt.wantCode == codes.OK {
+				require.Len(t, resp.Pets, 1)
+				assert.Equal(t, "Pet Paradise", resp.Store.Name)
+				assert.Equal(t, int32(1), resp.TotalCount)
+				assert.Equal(t, petstorev1.PetSpecies_PET_SPECIES_DOG, resp.Pets[0].Species)
+				assert.Equal(t, petstorev1.PetStatus_PET_STATUS_SOLD, resp.Pets[0].Status)
+				assert.Equal(t, "jane@example.com", resp.Pets[0].BreederEmail)
+			}
+			m.pets.AssertExpectations(t)
+			m.stores.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_GetPet(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(m *testMocks)
+		wantCode codes.Code
+	}{
+		{
+			name: "pet in the merchant's store",
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("DecryptBreederEmail", "encrypted").Return("jane@example.com", nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "pet in another merchant's store",
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(nil, apperrors.NewStoreNotFound(testStoreID))
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name: "unknown pet",
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return((*models.Pet)(nil), apperrors.NewPetNotFound(testPetID))
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, m := newTestClient(t)
+			tt.setup(m)
+
+			pet, err := client.GetPet(withCredentials("merchant1", "merchant123"), &petstorev1.GetPetRequest{Id: testPetID.String()})
+
+			assert.Equal(t, tt.wantCode, status.Code(err), err)
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, testPetID.String(), pet.Id)
+				assert.Equal(t, "jane@example.com", pet.BreederEmail)
+			} else {
+				assert.Contains(t, status.Convert(err).Message(), "pet with ID")
+			}
+			m.pets.AssertExpectations(t)
+			m.stores.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_CreatePet(t *testing.T) {
+	validRequest := func() *petstorev1.CreatePetRequest {
+		return &petstorev1.CreatePetRequest{
+			StoreId:      testStoreID.String(),
+			Name:         "Rex",
+			Species:      petstorev1.PetSpecies_PET_SPECIES_DOG,
+			Age:          2,
+			BreederName:  "Jane Breeder",
+			BreederEmail: "jane@example.com",
+			PriceCents:   50000,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        func() *petstorev1.CreatePetRequest
+		setup      func(m *testMocks)
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "create in an open store",
+			req:  validRequest,
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("CreatePet", mock.Anything, mock.MatchedBy(func(input models.CreatePetInput) bool {
+					return input.StoreID == testStoreID && input.Species == models.PetSpeciesDog && input.PriceCents == 50000
+				})).Return(testPet(models.PetStatusAvailable), nil)
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "species is required",
+			req: func() *petstorev1.CreatePetRequest {
+				req := validRequest()
+				req.Species = petstorev1.PetSpecies_PET_SPECIES_UNSPECIFIED
+				return req
+			},
+			wantCode:   codes.InvalidArgument,
+			wantReason: reasonBadUserInput,
+		},
+		{
+			name: "closed store",
+			req:  validRequest,
+			setup: func(m *testMocks) {
+				store := testStore()
+				store.ClosedAt = &testTime
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(store, nil)
+			},
+			wantCode:   codes.FailedPrecondition,
+			wantReason: reasonBusinessRule,
+		},
+		{
+			name: "duplicate pet",
+			req:  validRequest,
+			setup: func(m *testMocks) {
+				m.stores.On("GetStoreForOwner", mock.Anything, "merchant1", testStoreID).Return(testStore(), nil)
+				m.pets.On("CreatePet", mock.Anything, mock.Anything).
+					Return((*models.Pet)(nil), apperrors.ConflictError{Resource: "pet", Message: "already listed"})
+			},
+			wantCode:   codes.AlreadyExists,
+			wantReason: reasonConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, m := newTestClient(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			pet, err := client.CreatePet(withCredentials("merchant1", "merchant123"), tt.req())
+
+			assert.Equal(t, tt.wantCode, status.Code(err), err)
+			if tt.wantReason != "" {
+				assert.Equal(t, tt.wantReason, errorInfo(t, err).Reason)
+			}
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, "jane@example.com", pet.BreederEmail)
+				assert.Equal(t, petstorev1.PetStatus_PET_STATUS_AVAILABLE, pet.Status)
+			}
+			m.pets.AssertExpectations(t)
+			m.stores.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_CreateOrder(t *testing.T) {
+	tests := []struct {
+		name            string
+		ctx             context.Context
+		req             *petstorev1.CreateOrderRequest
+		setup           func(m *testMocks)
+		wantCode        codes.Code
+		wantReason      string
+		wantMetadata    map[string]string
+		wantBreederName string
+	}{
+		{
+			name: "customer buys a pet",
+			ctx:  withCredentials("customer1", "customer123"),
+			req:  &petstorev1.CreateOrderRequest{PetIds: []string{testPetID.String()}, CardNumber: "4242424242424242", CustomerAge: proto32(30)},
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.orders.On("CreateOrder", mock.Anything, mock.MatchedBy(func(input models.CreateOrderInput) bool {
+					return input.CustomerID == "customer1" && input.StoreID == testStoreID && *input.CustomerAge == 30
+				})).Return(&models.Order{
+					ID:            testOrderID,
+					CustomerID:    "customer1",
+					StoreID:       testStoreID,
+					TotalPets:     1,
+					TotalCents:    45000,
+					DiscountCents: 5000,
+					PaymentStatus: models.PaymentStatusCaptured,
+					Status:        models.OrderStatusPlaced,
+					CreatedAt:     testTime,
+				}, nil)
+				m.orders.On("GetOrderPets", mock.Anything, testOrderID).Return([]*models.Pet{testPet(models.PetStatusSold)}, nil)
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(models.DefaultStoreSettings(testStoreID), nil)
+			},
+			wantCode:        codes.OK,
+			wantBreederName: testPet(models.PetStatusSold).BreederName,
+		},
+		{
+			name: "store hides breeder names",
+			ctx:  withCredentials("customer1", "customer123"),
+			req:  &petstorev1.CreateOrderRequest{PetIds: []string{testPetID.String()}},
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusAvailable), nil)
+				m.orders.On("CreateOrder", mock.Anything, mock.Anything).Return(&models.Order{
+					ID:            testOrderID,
+					CustomerID:    "customer1",
+					StoreID:       testStoreID,
+					TotalPets:     1,
+					TotalCents:    50000,
+					PaymentStatus: models.PaymentStatusCaptured,
+					Status:        models.OrderStatusPlaced,
+					CreatedAt:     testTime,
+				}, nil)
+				m.orders.On("GetOrderPets", mock.Anything, testOrderID).Return([]*models.Pet{testPet(models.PetStatusSold)}, nil)
+				settings := models.DefaultStoreSettings(testStoreID)
+				settings.ShowBreederNames = false
+				m.settings.On("GetStoreSettings", mock.Anything, testStoreID).Return(settings, nil)
+			},
+			wantCode:        codes.OK,
+			wantBreederName: "[Hidden]",
+		},
+		{
+			name:       "merchants cannot buy",
+			ctx:        withCredentials("merchant1", "merchant123"),
+			req:        &petstorev1.CreateOrderRequest{PetIds: []string{testPetID.String()}},
+			wantCode:   codes.PermissionDenied,
+			wantReason: reasonForbidden,
+		},
+		{
+			name:         "pets are required",
+			ctx:          withCredentials("customer1", "customer123"),
+			req:          &petstorev1.CreateOrderRequest{},
+			wantCode:     codes.InvalidArgument,
+			wantReason:   reasonBadUserInput,
+			wantMetadata: map[string]string{"field": "pet_ids"},
+		},
+		{
+			name: "pets sold in the meantime",
+			ctx:  withCredentials("customer1", "customer123"),
+			req:  &petstorev1.CreateOrderRequest{PetIds: []string{testPetID.String()}},
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Return(testPet(models.PetStatusSold), nil)
+				m.orders.On("CreateOrder", mock.Anything, mock.Anything).Return(nil, apperrors.NewPetsUnavailable([]uuid.UUID{testPetID}))
+			},
+			wantCode:     codes.Aborted,
+			wantReason:   reasonPetUnavailable,
+			wantMetadata: map[string]string{"petIDs": testPetID.String()},
+		},
+		{
+			name: "panics become internal errors",
+			ctx:  withCredentials("customer1", "customer123"),
+			req:  &petstorev1.CreateOrderRequest{PetIds: []string{testPetID.String()}},
+			setup: func(m *testMocks) {
+				m.pets.On("GetPetByID", mock.Anything, testPetID).Run(func(mock.Arguments) {
+					panic("boom")
+				}).Return(nil, nil)
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, m := newTestClient(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			order, err := client.CreateOrder(tt.ctx, tt.req)
+
+			assert.Equal(t, tt.wantCode, status.Code(err), err)
+			if tt.wantReason != "" {
+				info := errorInfo(t, err)
+				assert.Equal(t, tt.wantReason, info.Reason)
+				if tt.wantMetadata != nil {
+					assert.Equal(t, tt.wantMetadata, info.Metadata)
+				}
+			}
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, testOrderID.String(), order.Id)
+				assert.Equal(t, int64(50000), order.SubtotalCents)
+				assert.Equal(t, petstorev1.PaymentStatus_PAYMENT_STATUS_CAPTURED, order.PaymentStatus)
+				require.Len(t, order.Pets, 1)
+				assert.Equal(t, "[Hidden]", order.Pets[0].BreederEmail)
+				assert.Equal(t, tt.wantBreederName, order.Pets[0].BreederName)
+			}
+			m.pets.AssertExpectations(t)
+			m.orders.AssertExpectations(t)
+		})
+	}
+}
+
+func proto32(n int32) *int32 {
+	return &n
+}
@@ -0,0 +1,221 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/fehepe/pet-store/backend/internal/auth"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/rpc/petstorev1"
+	"github.com/fehepe/pet-store/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// Service implements the PetStoreService gRPC API on top of the services the HTTP APIs use
+type Service struct {
+	petstorev1.UnimplementedPetStoreServiceServer
+
+	pets     service.PetServiceInterface
+	stores   service.StoreServiceInterface
+	orders   service.OrderServiceInterface
+	settings service.StoreSettingsServiceInterface
+}
+
+// NewService creates a new gRPC pet store service
+func NewService(pets service.PetServiceInterface, stores service.StoreServiceInterface, orders service.OrderServiceInterface, settings service.StoreSettingsServiceInterface) *Service {
+	return &Service{
+		pets:     pets,
+		stores:   stores,
+		orders:   orders,
+		settings: settings,
+	}
+}
+
+// ListPets follows the listPets query: a page of one of the merchant's stores, in any status
+func (s *Service) ListPets(ctx context.Context, req *petstorev1.ListPetsRequest) (*petstorev1.ListPetsResponse, error) {
+	storeID, err := parseID("store_id", req.GetStoreId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetLimit() < 0 {
+		return nil, apperrors.NewValidationError("limit", "must not be negative")
+	}
+	if req.GetOffset() < 0 {
+		return nil, apperrors.NewValidationError("offset", "must not be negative")
+	}
+
+	store, err := s.storeForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := models.PetFilter{
+		StoreID: &store.ID,
+		Limit:   int(req.GetLimit()),
+		Offset:  int(req.GetOffset()),
+	}
+	if req.GetStatus() != petstorev1.PetStatus_PET_STATUS_UNSPECIFIED {
+		status, ok := petStatusFromProto[req.GetStatus()]
+		if !ok {
+			return nil, apperrors.NewValidationError("status", "unknown pet status")
+		}
+		filter.Status = &status
+	}
+
+	pets, totalCount, err := s.pets.ListPets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &petstorev1.ListPetsResponse{
+		Store:      storeToProto(store),
+		TotalCount: int32(totalCount),
+	}
+	for _, pet := range pets {
+		resp.Pets = append(resp.Pets, s.merchantPet(pet))
+	}
+	return resp, nil
+}
+
+// GetPet returns a pet from one of the merchant's stores; other merchants' pets are not found
+func (s *Service) GetPet(ctx context.Context, req *petstorev1.GetPetRequest) (*petstorev1.Pet, error) {
+	petID, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	pet, err := s.pets.GetPetByID(ctx, petID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.storeForMerchant(ctx, pet.StoreID); err != nil {
+		if apperrors.IsNotFound(err) {
+			err = apperrors.NewPetNotFound(petID)
+		}
+		return nil, err
+	}
+
+	return s.merchantPet(pet), nil
+}
+
+// CreatePet applies the createPet mutation's rules: the store must be the merchant's and open
+func (s *Service) CreatePet(ctx context.Context, req *petstorev1.CreatePetRequest) (*petstorev1.Pet, error) {
+	storeID, err := parseID("store_id", req.GetStoreId())
+	if err != nil {
+		return nil, err
+	}
+	species, ok := petSpeciesFromProto[req.GetSpecies()]
+	if !ok {
+		return nil, apperrors.NewValidationError("species", "must be specified")
+	}
+
+	store, err := s.storeForMerchant(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+	if !store.IsOpen() {
+		return nil, apperrors.NewBusinessRuleError("pets cannot be added to a closed store")
+	}
+
+	pet, err := s.pets.CreatePet(ctx, models.CreatePetInput{
+		StoreID:      store.ID,
+		Name:         req.GetName(),
+		Species:      species,
+		Age:          int(req.GetAge()),
+		PictureURL:   req.PictureUrl,
+		Description:  req.Description,
+		BreederName:  req.GetBreederName(),
+		BreederEmail: req.GetBreederEmail(),
+		PriceCents:   req.GetPriceCents(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := petToProto(pet)
+	result.BreederEmail = req.GetBreederEmail()
+	return result, nil
+}
+
+// CreateOrder buys pets like the purchasePets mutation; the store is the first pet's
+func (s *Service) CreateOrder(ctx context.Context, req *petstorev1.CreateOrderRequest) (*petstorev1.Order, error) {
+	if len(req.GetPetIds()) == 0 {
+		return nil, apperrors.NewValidationError("pet_ids", "no pets specified")
+	}
+
+	petIDs := make([]uuid.UUID, 0, len(req.GetPetIds()))
+	for _, value := range req.GetPetIds() {
+		petID, err := parseID("pet_ids", value)
+		if err != nil {
+			return nil, err
+		}
+		petIDs = append(petIDs, petID)
+	}
+
+	firstPet, err := s.pets.GetPetByID(ctx, petIDs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var customerAge *int
+	if req.CustomerAge != nil {
+		age := int(req.GetCustomerAge())
+		customerAge = &age
+	}
+
+	username, _ := auth.GetUser(ctx)
+	order, err := s.orders.CreateOrder(ctx, models.CreateOrderInput{
+		CustomerID:   username,
+		StoreID:      firstPet.StoreID,
+		PetIDs:       petIDs,
+		CardNumber:   req.GetCardNumber(),
+		DiscountCode: req.GetDiscountCode(),
+		CustomerAge:  customerAge,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pets, err := s.orders.GetOrderPets(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only customers buy, so breeder names follow the store's setting like in availablePets
+	settings, err := s.settings.GetStoreSettings(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := orderToProto(order, pets)
+	if !settings.ShowBreederNames {
+		for _, pet := range result.Pets {
+			pet.BreederName = "[Hidden]"
+		}
+	}
+	return result, nil
+}
+
+// storeForMerchant returns the store if the calling merchant owns it
+func (s *Service) storeForMerchant(ctx context.Context, storeID uuid.UUID) (*models.Store, error) {
+	username, _ := auth.GetUser(ctx)
+	return s.stores.GetStoreForOwner(ctx, username, storeID)
+}
+
+// merchantPet converts a pet for its store's merchant, with the breeder email decrypted
+func (s *Service) merchantPet(pet *models.Pet) *petstorev1.Pet {
+	result := petToProto(pet)
+	if email, err := s.pets.DecryptBreederEmail(pet.BreederEmailEncrypted); err == nil {
+		result.BreederEmail = email
+	}
+	return result
+}
+
+// parseID parses a UUID request field
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, apperrors.NewValidationError(field, "must be a UUID")
+	}
+	return id, nil
+}
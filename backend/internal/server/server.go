@@ -1,22 +1,41 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/fehepe/pet-store/backend/internal/app"
 	"github.com/fehepe/pet-store/backend/internal/auth"
+	"github.com/fehepe/pet-store/backend/internal/cache"
+	"github.com/fehepe/pet-store/backend/internal/dataloader"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/export"
 	"github.com/fehepe/pet-store/backend/internal/graph"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/receipt"
+	"github.com/fehepe/pet-store/backend/internal/rest"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
 )
 
+// maxUploadSize caps the size of files uploaded through GraphQL, such as pet imports
+const maxUploadSize = 5 << 20
+
 // Server represents the HTTP server
 type Server struct {
 	*http.Server
@@ -48,7 +67,7 @@ func setupRouter(deps *app.Dependencies) chi.Router {
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
-	router.Use(middleware.Timeout(60 * time.Second))
+	router.Use(timeoutExceptWebSocket(60 * time.Second))
 	router.Use(CORS())
 
 	// Health check endpoint
@@ -58,12 +77,65 @@ func setupRouter(deps *app.Dependencies) chi.Router {
 	fileServer := http.FileServer(http.Dir(deps.Config.UploadDir))
 	router.Handle("/uploads/*", http.StripPrefix("/uploads/", fileServer))
 
-	// GraphQL endpoints with conditional authentication
+	// Public storefront pages for search engines, following the availablePets access rules
+	router.Get("/stores/{slug}", storePageHandler(deps))
+	router.Get("/pets/{id}", petPageHandler(deps))
+	router.Get("/sitemap.xml", sitemapHandler(deps))
+
+	// REST facade for integrators that cannot use GraphQL, described at /api/v1/openapi.json
+	router.Mount("/api/v1", rest.NewHandler(deps.Services.Pet, deps.Services.Store, deps.Services.Order, deps.Services.Settings).Router())
+
+	// Payment provider callbacks (authenticated by signature, not by user)
+	router.Post("/payments/webhook", paymentWebhookHandler(deps))
+
+	// Order receipts for the customer who placed the order or the store's merchant
+	router.With(auth.BasicAuthMiddleware).Get("/orders/{id}/receipt", orderReceiptHandler(deps))
+
+	// Streaming CSV (.csv) and JSON Lines (.jsonl) exports for the store's merchant
+	router.Route("/export", func(r chi.Router) {
+		r.Use(auth.BasicAuthMiddleware)
+		r.Get("/pets.{format}", exportPetsHandler(deps))
+		r.Get("/orders.{format}", exportOrdersHandler(deps))
+	})
+
+	// GraphQL endpoints; anonymous clients may only run public queries
 	router.Route("/graphql", func(r chi.Router) {
-		r.Use(auth.ConditionalAuthMiddleware) // Enable authentication for mutations
-		srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: deps.Resolver}))
+		r.Use(auth.OptionalAuthMiddleware)
+		srv := handler.New(graph.NewExecutableSchema(graph.Config{
+			Resolvers:  deps.Resolver,
+			Complexity: graph.NewComplexityRoot(),
+		}))
 		srv.AddTransport(transport.POST{})
 		srv.AddTransport(transport.GET{})
+		srv.AddTransport(transport.MultipartForm{MaxUploadSize: maxUploadSize, MaxMemory: maxUploadSize})
+		srv.AddTransport(transport.Websocket{
+			Upgrader: websocket.Upgrader{
+				CheckOrigin: func(r *http.Request) bool {
+					origin := r.Header.Get("Origin")
+					return origin == "" || isAllowedOrigin(origin)
+				},
+			},
+			InitFunc: func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+				ctx, err := auth.Authenticate(ctx, payload.Authorization())
+				return ctx, nil, err
+			},
+			KeepAlivePingInterval: 15 * time.Second,
+		})
+		// The allowlist resolves registered hashes itself, so it runs before APQ
+		if deps.Allowlist != nil {
+			srv.Use(deps.Allowlist)
+		}
+		srv.Use(extension.AutomaticPersistedQuery{Cache: cache.NewAPQCache(deps.Cache, deps.Config.GraphQLAPQTTL)})
+		srv.Use(graph.PublicOperations{})
+		if deps.Config.GraphQLMaxComplexity > 0 {
+			srv.Use(extension.FixedComplexityLimit(deps.Config.GraphQLMaxComplexity))
+		}
+		if deps.Config.GraphQLMaxDepth > 0 {
+			srv.Use(graph.DepthLimit{MaxDepth: deps.Config.GraphQLMaxDepth})
+		}
+		srv.AroundOperations(dataloader.Middleware(deps.Repositories.Pet, deps.Repositories.Store, deps.Repositories.Order, deps.Repositories.Promotion))
+		srv.SetErrorPresenter(graph.ErrorPresenter)
+		srv.SetRecoverFunc(graph.Recover)
 		r.Handle("/", srv)
 	})
 
@@ -79,21 +151,9 @@ func setupRouter(deps *app.Dependencies) chi.Router {
 func CORS() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Get allowed origins from environment variable, default to localhost for development
-			allowedOrigins := os.Getenv("CORS_ALLOWED_ORIGINS")
-			if allowedOrigins == "" {
-				allowedOrigins = "http://localhost:3000,http://localhost:3001"
-			}
-
 			origin := r.Header.Get("Origin")
-			if origin != "" {
-				origins := strings.Split(allowedOrigins, ",")
-				for _, allowedOrigin := range origins {
-					if strings.TrimSpace(allowedOrigin) == origin {
-						w.Header().Set("Access-Control-Allow-Origin", origin)
-						break
-					}
-				}
+			if origin != "" && isAllowedOrigin(origin) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
 			}
 
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
@@ -112,6 +172,38 @@ func CORS() func(http.Handler) http.Handler {
 	}
 }
 
+// isAllowedOrigin reports whether browsers on origin may call the API
+func isAllowedOrigin(origin string) bool {
+	// Get allowed origins from environment variable, default to localhost for development
+	allowedOrigins := os.Getenv("CORS_ALLOWED_ORIGINS")
+	if allowedOrigins == "" {
+		allowedOrigins = "http://localhost:3000,http://localhost:3001"
+	}
+
+	for _, allowedOrigin := range strings.Split(allowedOrigins, ",") {
+		if strings.TrimSpace(allowedOrigin) == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// timeoutExceptWebSocket applies the request timeout to everything but subscription
+// connections, which stay open for as long as the client listens, and streamed exports,
+// which keep their connection alive with a per-write deadline instead
+func timeoutExceptWebSocket(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		withTimeout := middleware.Timeout(timeout)(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") || strings.HasPrefix(r.URL.Path, "/export/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+			withTimeout.ServeHTTP(w, r)
+		})
+	}
+}
+
 // healthCheckHandler returns a simple health check endpoint
 func healthCheckHandler() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -127,3 +219,292 @@ func healthCheckHandler() http.HandlerFunc {
 		json.NewEncoder(w).Encode(response)
 	}
 }
+
+// paymentWebhookHandler receives signed payment provider callbacks
+func paymentWebhookHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			http.Error(w, "Failed to read request body", http.StatusBadRequest)
+			return
+		}
+
+		signature := r.Header.Get("X-Payment-Signature")
+		if err := deps.Services.Order.HandlePaymentWebhook(r.Context(), payload, signature); err != nil {
+			var validationErr apperrors.ValidationError
+			if errors.As(err, &validationErr) {
+				http.Error(w, "Invalid webhook signature", http.StatusUnauthorized)
+				return
+			}
+			log.Printf("Failed to process payment webhook: %v", err)
+			http.Error(w, "Failed to process webhook", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// orderReceiptHandler serves an order receipt as HTML or PDF (?format=pdf)
+func orderReceiptHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			http.Error(w, "Invalid order ID", http.StatusBadRequest)
+			return
+		}
+
+		format, err := receipt.ParseFormat(r.URL.Query().Get("format"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		username, _ := auth.GetUser(r.Context())
+		userType, _ := auth.GetUserType(r.Context())
+
+		doc, err := deps.Services.Receipt.GetOrderReceipt(r.Context(), orderID, username, userType == auth.UserTypeMerchant, format)
+		if err != nil {
+			var notFoundErr apperrors.OrderNotFoundError
+			if errors.As(err, &notFoundErr) {
+				http.Error(w, "Order not found", http.StatusNotFound)
+				return
+			}
+			log.Printf("Failed to render receipt for order %s: %v", orderID, err)
+			http.Error(w, "Failed to render receipt", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", doc.ContentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", doc.Filename))
+		w.Header().Set("Cache-Control", "private, no-store")
+		w.Write(doc.Content)
+	}
+}
+
+// storePageHandler renders a store's available pets (?page=)
+func storePageHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if value := r.URL.Query().Get("page"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				http.Error(w, "Invalid page", http.StatusBadRequest)
+				return
+			}
+			page = parsed
+		}
+
+		body, err := deps.Services.Storefront.StorePage(r.Context(), chi.URLParam(r, "slug"), page)
+		writePublicPage(w, "text/html; charset=utf-8", body, err)
+	}
+}
+
+// petPageHandler renders an available pet
+func petPageHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		petID, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		body, err := deps.Services.Storefront.PetPage(r.Context(), petID)
+		writePublicPage(w, "text/html; charset=utf-8", body, err)
+	}
+}
+
+// sitemapHandler lists the open stores and their available pets
+func sitemapHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := deps.Services.Storefront.Sitemap(r.Context())
+		writePublicPage(w, "application/xml; charset=utf-8", body, err)
+	}
+}
+
+// writePublicPage writes a cacheable storefront response or maps the error to a status code
+func writePublicPage(w http.ResponseWriter, contentType string, body []byte, err error) {
+	if err != nil {
+		var notFoundErr apperrors.NotFoundError
+		var petNotFoundErr apperrors.PetNotFoundError
+		var storeNotFoundErr apperrors.StoreNotFoundError
+		var validationErr apperrors.ValidationError
+		switch {
+		case errors.As(err, &notFoundErr), errors.As(err, &petNotFoundErr), errors.As(err, &storeNotFoundErr):
+			http.Error(w, "Page not found", http.StatusNotFound)
+		case errors.As(err, &validationErr):
+			http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		default:
+			log.Printf("Failed to render storefront page: %v", err)
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(body)
+}
+
+// exportPetsHandler streams a store's pets, filtered like listPets (?storeID=&status=&startDate=&endDate=).
+// Breeder emails are decrypted with ?decrypt=true.
+func exportPetsHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		filter := models.PetFilter{}
+
+		storeID, err := uuid.Parse(query.Get("storeID"))
+		if err != nil {
+			http.Error(w, "Invalid store ID", http.StatusBadRequest)
+			return
+		}
+		filter.StoreID = &storeID
+
+		if value := query.Get("status"); value != "" {
+			status := models.PetStatus(strings.ToLower(value))
+			if status != models.PetStatusAvailable && status != models.PetStatusReserved && status != models.PetStatusSold {
+				http.Error(w, "Invalid pet status", http.StatusBadRequest)
+				return
+			}
+			filter.Status = &status
+		}
+
+		if filter.StartDate, filter.EndDate, err = parseDateRange(query.Get("startDate"), query.Get("endDate")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		streamExport(w, r, "pets-"+storeID.String(), func(ownerID string, format export.Format, out io.Writer) error {
+			return deps.Services.Export.ExportPets(r.Context(), ownerID, filter, query.Get("decrypt") == "true", format, out)
+		})
+	}
+}
+
+// exportOrdersHandler streams a store's orders (?storeID=&status=&paymentStatus=&startDate=&endDate=)
+func exportOrdersHandler(deps *app.Dependencies) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		storeID, err := uuid.Parse(query.Get("storeID"))
+		if err != nil {
+			http.Error(w, "Invalid store ID", http.StatusBadRequest)
+			return
+		}
+		filter := models.OrderFilter{StoreID: storeID}
+
+		if value := query.Get("status"); value != "" {
+			status := models.OrderStatus(strings.ToLower(value))
+			switch status {
+			case models.OrderStatusPlaced, models.OrderStatusPickupScheduled, models.OrderStatusCompleted:
+				filter.Status = &status
+			default:
+				http.Error(w, "Invalid order status", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if value := query.Get("paymentStatus"); value != "" {
+			status := models.PaymentStatus(strings.ToLower(value))
+			switch status {
+			case models.PaymentStatusNone, models.PaymentStatusPending, models.PaymentStatusAuthorized, models.PaymentStatusCaptured,
+				models.PaymentStatusRefunded, models.PaymentStatusFailed:
+				filter.PaymentStatus = &status
+			default:
+				http.Error(w, "Invalid payment status", http.StatusBadRequest)
+				return
+			}
+		}
+
+		if filter.StartDate, filter.EndDate, err = parseDateRange(query.Get("startDate"), query.Get("endDate")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		streamExport(w, r, "orders-"+storeID.String(), func(ownerID string, format export.Format, out io.Writer) error {
+			return deps.Services.Export.ExportOrders(r.Context(), ownerID, filter, format, out)
+		})
+	}
+}
+
+// streamExport checks the caller is a merchant, sets the download headers and runs the export.
+// Errors raised before any data is written get a proper status; later ones can only be logged.
+func streamExport(w http.ResponseWriter, r *http.Request, name string, run func(ownerID string, format export.Format, out io.Writer) error) {
+	if err := auth.RequireMerchant(r.Context()); err != nil {
+		http.Error(w, "Merchant access required", http.StatusForbidden)
+		return
+	}
+	username, _ := auth.GetUser(r.Context())
+
+	format, err := export.ParseFormat(chi.URLParam(r, "format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+"."+string(format)))
+	w.Header().Set("Cache-Control", "private, no-store")
+
+	// Large exports outlast the server's write timeout, so the deadline moves forward with each write
+	out := &countingWriter{w: w, rc: http.NewResponseController(w)}
+	if err := run(username, format, out); err != nil {
+		if out.n > 0 {
+			log.Printf("Export %s failed after %d bytes: %v", name, out.n, err)
+			return
+		}
+		w.Header().Del("Content-Disposition")
+
+		var validationErr apperrors.ValidationError
+		var notFoundErr apperrors.StoreNotFoundError
+		switch {
+		case errors.As(err, &validationErr):
+			http.Error(w, validationErr.Error(), http.StatusBadRequest)
+		case errors.As(err, &notFoundErr):
+			http.Error(w, "Store not found", http.StatusNotFound)
+		default:
+			log.Printf("Export %s failed: %v", name, err)
+			http.Error(w, "Failed to export", http.StatusInternalServerError)
+		}
+	}
+}
+
+// parseDateRange parses optional RFC 3339 startDate and endDate query parameters
+func parseDateRange(start, end string) (*time.Time, *time.Time, error) {
+	var startDate, endDate *time.Time
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid startDate: %w", err)
+		}
+		startDate = &t
+	}
+	if end != "" {
+		t, err := time.Parse(time.RFC3339, end)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid endDate: %w", err)
+		}
+		endDate = &t
+	}
+	return startDate, endDate, nil
+}
+
+// exportWriteTimeout is how long a streamed export may go without writing before the
+// connection is dropped
+const exportWriteTimeout = 30 * time.Second
+
+// countingWriter records how many bytes have been written to the response and extends
+// the write deadline before each write
+type countingWriter struct {
+	w  io.Writer
+	rc *http.ResponseController
+	n  int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.rc != nil {
+		_ = c.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout))
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// CartServiceInterface defines the interface for shopping cart operations
+type CartServiceInterface interface {
+	GetCart(ctx context.Context, customerID string) (*models.Cart, error)
+	AddToCart(ctx context.Context, customerID string, petID uuid.UUID) (*models.Cart, error)
+	RemoveFromCart(ctx context.Context, customerID string, petID uuid.UUID) (*models.Cart, error)
+	CheckoutCart(ctx context.Context, customerID, cardNumber, discountCode string, customerAge *int) (*models.Order, error)
+}
+
+// CartService implements CartServiceInterface on top of the order flow
+type CartService struct {
+	repo         repository.CartRepositoryInterface
+	petService   PetServiceInterface
+	orderService OrderServiceInterface
+	settings     StoreSettingsServiceInterface
+}
+
+// NewCartService creates a new cart service
+func NewCartService(
+	repo repository.CartRepositoryInterface,
+	petService PetServiceInterface,
+	orderService OrderServiceInterface,
+	settings StoreSettingsServiceInterface,
+) *CartService {
+	return &CartService{
+		repo:         repo,
+		petService:   petService,
+		orderService: orderService,
+		settings:     settings,
+	}
+}
+
+// GetCart retrieves the customer's cart with live availability for every item. Customers who never
+// added a pet get an empty cart without an ID; reading a cart never creates one.
+func (s *CartService) GetCart(ctx context.Context, customerID string) (*models.Cart, error) {
+	if strings.TrimSpace(customerID) == "" {
+		return nil, apperrors.NewValidationError("customerID", "customer ID cannot be empty")
+	}
+
+	cart, err := s.repo.Get(ctx, customerID)
+	if apperrors.IsNotFound(err) {
+		return &models.Cart{CustomerID: customerID, Items: []*models.CartItem{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withItems(ctx, cart)
+}
+
+// AddToCart adds an available pet to the customer's cart, creating the cart on the first add
+func (s *CartService) AddToCart(ctx context.Context, customerID string, petID uuid.UUID) (*models.Cart, error) {
+	if strings.TrimSpace(customerID) == "" {
+		return nil, apperrors.NewValidationError("customerID", "customer ID cannot be empty")
+	}
+
+	cart, err := s.repo.GetOrCreate(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	if cart, err = s.withItems(ctx, cart); err != nil {
+		return nil, err
+	}
+
+	pet, err := s.petService.GetPetByID(ctx, petID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pet.Status != models.PetStatusAvailable {
+		return nil, apperrors.NewBusinessRuleError(fmt.Sprintf("pet '%s' is no longer available", pet.Name))
+	}
+
+	for _, item := range cart.Items {
+		if item.PetID == petID {
+			return cart, nil
+		}
+		if item.Pet != nil && item.Pet.StoreID != pet.StoreID {
+			return nil, apperrors.ConflictError{
+				Resource: "cart",
+				Message:  "cart already contains pets from another store",
+			}
+		}
+	}
+
+	settings, err := s.settings.GetStoreSettings(ctx, pet.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cart.Items) >= settings.MaxPetsPerOrder {
+		return nil, apperrors.NewValidationError("petID", fmt.Sprintf("cart cannot hold more than %d pets", settings.MaxPetsPerOrder))
+	}
+
+	if err := s.repo.AddItem(ctx, cart.ID, petID); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(ctx, customerID)
+}
+
+// RemoveFromCart removes a pet from the customer's cart
+func (s *CartService) RemoveFromCart(ctx context.Context, customerID string, petID uuid.UUID) (*models.Cart, error) {
+	cart, err := s.GetCart(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart.ID == uuid.Nil {
+		return cart, nil
+	}
+
+	if err := s.repo.RemoveItem(ctx, cart.ID, petID); err != nil {
+		return nil, err
+	}
+
+	return s.GetCart(ctx, customerID)
+}
+
+// withItems loads the items of a stored cart
+func (s *CartService) withItems(ctx context.Context, cart *models.Cart) (*models.Cart, error) {
+	items, err := s.repo.GetItems(ctx, cart.ID)
+	if err != nil {
+		return nil, err
+	}
+	cart.Items = items
+
+	return cart, nil
+}
+
+// CheckoutCart purchases every available pet in the cart through the order service
+func (s *CartService) CheckoutCart(ctx context.Context, customerID, cardNumber, discountCode string, customerAge *int) (*models.Order, error) {
+	cart, err := s.GetCart(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var petIDs []uuid.UUID
+	var storeID uuid.UUID
+	for _, item := range cart.Items {
+		if !item.Available() {
+			continue
+		}
+		petIDs = append(petIDs, item.PetID)
+		storeID = item.Pet.StoreID
+	}
+
+	if len(petIDs) == 0 {
+		return nil, apperrors.NewBusinessRuleError("cart has no available pets to purchase")
+	}
+
+	order, err := s.orderService.CreateOrder(ctx, models.CreateOrderInput{
+		CustomerID:   customerID,
+		StoreID:      storeID,
+		PetIDs:       petIDs,
+		CardNumber:   cardNumber,
+		DiscountCode: discountCode,
+		CustomerAge:  customerAge,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	purchased, err := s.orderService.GetOrderPets(ctx, order.ID)
+	if err != nil {
+		return order, err
+	}
+	for _, pet := range purchased {
+		if err := s.repo.RemoveItem(ctx, cart.ID, pet.ID); err != nil {
+			return order, err
+		}
+	}
+
+	return order, nil
+}
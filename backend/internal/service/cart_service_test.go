@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCartService_GetCart(t *testing.T) {
+	cartID := uuid.New()
+	storeID := uuid.New()
+	items := []*models.CartItem{
+		{CartID: cartID, PetID: uuid.New(), Pet: &models.Pet{StoreID: storeID, Status: models.PetStatusAvailable}},
+		{CartID: cartID, PetID: uuid.New(), Pet: &models.Pet{StoreID: storeID, Status: models.PetStatusSold}},
+	}
+
+	repo := new(mocks.MockCartRepository)
+	repo.On("Get", mock.Anything, "customer1").Return(&models.Cart{ID: cartID, CustomerID: "customer1"}, nil)
+	repo.On("GetItems", mock.Anything, cartID).Return(items, nil)
+
+	service := NewCartService(repo, new(mocks.MockPetService), new(mocks.MockOrderService), defaultSettingsService())
+
+	cart, err := service.GetCart(context.Background(), "customer1")
+
+	assert.NoError(t, err)
+	assert.Len(t, cart.Items, 2)
+	assert.True(t, cart.Items[0].Available())
+	assert.False(t, cart.Items[1].Available())
+
+	_, err = service.GetCart(context.Background(), " ")
+	assert.IsType(t, apperrors.ValidationError{}, err)
+}
+
+func TestCartService_GetCartWithoutStoredCart(t *testing.T) {
+	repo := new(mocks.MockCartRepository)
+	repo.On("Get", mock.Anything, "customer1").Return(nil, apperrors.NotFoundError{Resource: "cart", ID: "customer1"})
+
+	service := NewCartService(repo, new(mocks.MockPetService), new(mocks.MockOrderService), defaultSettingsService())
+
+	cart, err := service.GetCart(context.Background(), "customer1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uuid.Nil, cart.ID)
+	assert.Empty(t, cart.Items)
+	repo.AssertNotCalled(t, "GetOrCreate", mock.Anything, mock.Anything)
+
+	removed, err := service.RemoveFromCart(context.Background(), "customer1", uuid.New())
+
+	assert.NoError(t, err)
+	assert.Empty(t, removed.Items)
+	repo.AssertNotCalled(t, "RemoveItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCartService_AddToCart(t *testing.T) {
+	cartID := uuid.New()
+	storeID := uuid.New()
+	petID := uuid.New()
+
+	tests := []struct {
+		name     string
+		pet      *models.Pet
+		items    []*models.CartItem
+		settings *models.StoreSettings // nil uses the defaults
+		wantErr  interface{}
+	}{
+		{
+			name:  "adds available pet",
+			pet:   &models.Pet{ID: petID, StoreID: storeID, Status: models.PetStatusAvailable},
+			items: []*models.CartItem{},
+		},
+		{
+			name:    "rejects sold pet",
+			pet:     &models.Pet{ID: petID, StoreID: storeID, Name: "Luna", Status: models.PetStatusSold},
+			items:   []*models.CartItem{},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name: "rejects pet from another store",
+			pet:  &models.Pet{ID: petID, StoreID: storeID, Status: models.PetStatusAvailable},
+			items: []*models.CartItem{
+				{PetID: uuid.New(), Pet: &models.Pet{StoreID: uuid.New(), Status: models.PetStatusAvailable}},
+			},
+			wantErr: apperrors.ConflictError{},
+		},
+		{
+			name: "rejects full cart",
+			pet:  &models.Pet{ID: petID, StoreID: storeID, Status: models.PetStatusAvailable},
+			items: func() []*models.CartItem {
+				items := []*models.CartItem{}
+				for i := 0; i < 10; i++ {
+					items = append(items, &models.CartItem{PetID: uuid.New(), Pet: &models.Pet{StoreID: storeID}})
+				}
+				return items
+			}(),
+			wantErr: apperrors.ValidationError{},
+		},
+		{
+			name: "rejects cart above the store limit",
+			pet:  &models.Pet{ID: petID, StoreID: storeID, Status: models.PetStatusAvailable},
+			items: []*models.CartItem{
+				{PetID: uuid.New(), Pet: &models.Pet{StoreID: storeID}},
+				{PetID: uuid.New(), Pet: &models.Pet{StoreID: storeID}},
+			},
+			settings: &models.StoreSettings{StoreID: storeID, MaxPetsPerOrder: 2},
+			wantErr:  apperrors.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockCartRepository)
+			petService := new(mocks.MockPetService)
+			settings := defaultSettingsService()
+			if tt.settings != nil {
+				settings = new(mocks.MockStoreSettingsService)
+				settings.On("GetStoreSettings", mock.Anything, storeID).Return(tt.settings, nil)
+			}
+
+			repo.On("GetOrCreate", mock.Anything, "customer1").Return(&models.Cart{ID: cartID}, nil)
+			repo.On("GetItems", mock.Anything, cartID).Return(tt.items, nil)
+			petService.On("GetPetByID", mock.Anything, petID).Return(tt.pet, nil)
+			if tt.wantErr == nil {
+				repo.On("AddItem", mock.Anything, cartID, petID).Return(nil)
+				repo.On("Get", mock.Anything, "customer1").Return(&models.Cart{ID: cartID}, nil)
+			}
+
+			service := NewCartService(repo, petService, new(mocks.MockOrderService), settings)
+
+			cart, err := service.AddToCart(context.Background(), "customer1", petID)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, cart)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, cart)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCartService_CheckoutCart(t *testing.T) {
+	cartID := uuid.New()
+	storeID := uuid.New()
+	availablePet := &models.Pet{ID: uuid.New(), StoreID: storeID, Status: models.PetStatusAvailable}
+	soldPet := &models.Pet{ID: uuid.New(), StoreID: storeID, Status: models.PetStatusSold}
+	customerAge := 30
+
+	t.Run("purchases available pets and removes them from the cart", func(t *testing.T) {
+		repo := new(mocks.MockCartRepository)
+		orderService := new(mocks.MockOrderService)
+		order := &models.Order{ID: uuid.New(), StoreID: storeID, TotalPets: 1}
+
+		repo.On("Get", mock.Anything, "customer1").Return(&models.Cart{ID: cartID}, nil)
+		repo.On("GetItems", mock.Anything, cartID).Return([]*models.CartItem{
+			{PetID: availablePet.ID, Pet: availablePet},
+			{PetID: soldPet.ID, Pet: soldPet},
+		}, nil)
+		orderService.On("CreateOrder", mock.Anything, models.CreateOrderInput{
+			CustomerID:   "customer1",
+			StoreID:      storeID,
+			PetIDs:       []uuid.UUID{availablePet.ID},
+			CardNumber:   "4242424242424242",
+			DiscountCode: "SPRING10",
+			CustomerAge:  &customerAge,
+		}).Return(order, nil)
+		orderService.On("GetOrderPets", mock.Anything, order.ID).Return([]*models.Pet{availablePet}, nil)
+		repo.On("RemoveItem", mock.Anything, cartID, availablePet.ID).Return(nil)
+
+		service := NewCartService(repo, new(mocks.MockPetService), orderService, defaultSettingsService())
+
+		result, err := service.CheckoutCart(context.Background(), "customer1", "4242424242424242", "SPRING10", &customerAge)
+
+		assert.NoError(t, err)
+		assert.Equal(t, order, result)
+		repo.AssertExpectations(t)
+		orderService.AssertExpectations(t)
+	})
+
+	t.Run("fails when nothing is available", func(t *testing.T) {
+		repo := new(mocks.MockCartRepository)
+		repo.On("Get", mock.Anything, "customer1").Return(&models.Cart{ID: cartID}, nil)
+		repo.On("GetItems", mock.Anything, cartID).Return([]*models.CartItem{{PetID: soldPet.ID, Pet: soldPet}}, nil)
+
+		service := NewCartService(repo, new(mocks.MockPetService), new(mocks.MockOrderService), defaultSettingsService())
+
+		result, err := service.CheckoutCart(context.Background(), "customer1", "", "", nil)
+
+		assert.IsType(t, apperrors.BusinessRuleError{}, err)
+		assert.Nil(t, result)
+	})
+}
+
+func TestCartServiceInterface_Implementation(t *testing.T) {
+	var _ CartServiceInterface = NewCartService(new(mocks.MockCartRepository), new(mocks.MockPetService), new(mocks.MockOrderService), new(mocks.MockStoreSettingsService))
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/export"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/pkg/encryption"
+	"github.com/google/uuid"
+)
+
+// exportFlushEvery is how many records are buffered before they are sent to the client
+const exportFlushEvery = 500
+
+// Columns of the pet and order exports
+var (
+	petExportColumns = []string{
+		"id", "name", "species", "age", "status", "price_cents", "breeder_name", "breeder_email",
+		"description", "picture_url", "created_at", "order_id", "sold_at", "customer_id",
+	}
+	orderExportColumns = []string{
+		"id", "customer_id", "status", "payment_status", "total_pets",
+		"subtotal_cents", "discount_cents", "total_cents", "created_at",
+	}
+)
+
+// ExportServiceInterface defines the interface for merchant data exports
+type ExportServiceInterface interface {
+	ExportPets(ctx context.Context, ownerID string, filter models.PetFilter, includeEmails bool, format export.Format, w io.Writer) error
+	ExportOrders(ctx context.Context, ownerID string, filter models.OrderFilter, format export.Format, w io.Writer) error
+}
+
+// ExportService streams a store's pets and orders as CSV or JSON Lines
+type ExportService struct {
+	petRepo   repository.PetRepositoryInterface
+	orderRepo repository.OrderRepositoryInterface
+	storeRepo repository.StoreRepositoryInterface
+	encryptor encryption.EncryptorInterface
+}
+
+// NewExportService creates a new export service
+func NewExportService(
+	petRepo repository.PetRepositoryInterface,
+	orderRepo repository.OrderRepositoryInterface,
+	storeRepo repository.StoreRepositoryInterface,
+	encryptor encryption.EncryptorInterface,
+) *ExportService {
+	return &ExportService{
+		petRepo:   petRepo,
+		orderRepo: orderRepo,
+		storeRepo: storeRepo,
+		encryptor: encryptor,
+	}
+}
+
+// ExportPets writes the pets of a store owned by ownerID that match the filter.
+// Breeder emails are decrypted only when includeEmails is set.
+func (s *ExportService) ExportPets(ctx context.Context, ownerID string, filter models.PetFilter, includeEmails bool, format export.Format, w io.Writer) error {
+	if filter.StoreID == nil {
+		return apperrors.NewValidationError("storeID", "store ID is required")
+	}
+	if err := validateExportRange(filter.StartDate, filter.EndDate); err != nil {
+		return err
+	}
+	if err := s.checkStoreOwner(ctx, ownerID, *filter.StoreID); err != nil {
+		return err
+	}
+
+	writer, err := export.NewWriter(w, format, petExportColumns)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = s.petRepo.Stream(ctx, filter, func(pet *models.Pet) error {
+		breederEmail := "[Hidden]"
+		if includeEmails {
+			decrypted, err := s.encryptor.Decrypt(pet.BreederEmailEncrypted)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt breeder email of pet %s: %w", pet.ID, err)
+			}
+			breederEmail = decrypted
+		}
+
+		var orderID *uuid.UUID
+		var soldAt *time.Time
+		var customerID *string
+		if pet.Sale != nil {
+			orderID, soldAt, customerID = &pet.Sale.OrderID, &pet.Sale.PurchasedAt, &pet.Sale.CustomerID
+		}
+
+		if err := writer.Write(
+			pet.ID, pet.Name, string(pet.Species), pet.Age, string(pet.Status), pet.PriceCents, pet.BreederName, breederEmail,
+			pet.Description, pet.PictureURL, pet.CreatedAt, orderID, soldAt, customerID,
+		); err != nil {
+			return err
+		}
+		return flushEvery(writer, &count)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// ExportOrders writes the orders of a store owned by ownerID that match the filter
+func (s *ExportService) ExportOrders(ctx context.Context, ownerID string, filter models.OrderFilter, format export.Format, w io.Writer) error {
+	if err := validateExportRange(filter.StartDate, filter.EndDate); err != nil {
+		return err
+	}
+	if err := s.checkStoreOwner(ctx, ownerID, filter.StoreID); err != nil {
+		return err
+	}
+
+	writer, err := export.NewWriter(w, format, orderExportColumns)
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	err = s.orderRepo.Stream(ctx, filter, func(order *models.Order) error {
+		if err := writer.Write(
+			order.ID, order.CustomerID, string(order.Status), string(order.PaymentStatus), order.TotalPets,
+			order.SubtotalCents(), order.DiscountCents, order.TotalCents, order.CreatedAt,
+		); err != nil {
+			return err
+		}
+		return flushEvery(writer, &count)
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
+}
+
+// checkStoreOwner reports stores owned by someone else as not found
+func (s *ExportService) checkStoreOwner(ctx context.Context, ownerID string, storeID uuid.UUID) error {
+	store, err := s.storeRepo.GetByID(ctx, storeID)
+	if err != nil {
+		return err
+	}
+	if store.OwnerID != ownerID {
+		return apperrors.NewStoreNotFound(storeID)
+	}
+	return nil
+}
+
+// validateExportRange requires both ends of a date range, in order, or neither
+func validateExportRange(start, end *time.Time) error {
+	if (start == nil) != (end == nil) {
+		return apperrors.NewValidationError("startDate", "startDate and endDate must be given together")
+	}
+	if start != nil && end.Before(*start) {
+		return apperrors.NewValidationError("endDate", "must not be before startDate")
+	}
+	return nil
+}
+
+// flushEvery flushes the writer after every exportFlushEvery records
+func flushEvery(writer export.Writer, count *int) error {
+	*count++
+	if *count%exportFlushEvery == 0 {
+		return writer.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/export"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// streamPets makes a Stream expectation call fn for each pet
+func streamPets(pets ...*models.Pet) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(2).(func(*models.Pet) error)
+		for _, pet := range pets {
+			if err := fn(pet); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestExportService_ExportPets(t *testing.T) {
+	storeID := uuid.New()
+	store := &models.Store{ID: storeID, OwnerID: "merchant1"}
+	createdAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	available := &models.Pet{ID: uuid.New(), StoreID: storeID, Name: "Tom", Species: models.PetSpeciesCat, Age: 2,
+		BreederName: "Alice", BreederEmailEncrypted: "enc-alice", Status: models.PetStatusAvailable, PriceCents: 15000, CreatedAt: createdAt}
+	sold := &models.Pet{ID: uuid.New(), StoreID: storeID, Name: "Rex", Species: models.PetSpeciesDog, Age: 4,
+		BreederName: "Bob", BreederEmailEncrypted: "enc-bob", Status: models.PetStatusSold, PriceCents: 20000, CreatedAt: createdAt,
+		Sale: &models.PetSale{OrderID: uuid.New(), PurchasedAt: createdAt.AddDate(0, 0, 10), CustomerID: "customer1"}}
+
+	t.Run("streams CSV with hidden emails", func(t *testing.T) {
+		petRepo := new(mocks.MockPetRepository)
+		storeRepo := new(mocks.MockStoreRepository)
+		encryptor := new(mocks.MockEncryptor)
+		storeRepo.On("GetByID", mock.Anything, storeID).Return(store, nil)
+		petRepo.On("Stream", mock.Anything, mock.AnythingOfType("models.PetFilter"), mock.Anything).Run(streamPets(available, sold)).Return(nil)
+
+		service := NewExportService(petRepo, new(mocks.MockOrderRepository), storeRepo, encryptor)
+
+		var buf bytes.Buffer
+		err := service.ExportPets(context.Background(), "merchant1", models.PetFilter{StoreID: &storeID}, false, export.FormatCSV, &buf)
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "id,name,species,age,status,price_cents,breeder_name,breeder_email"))
+		assert.Contains(t, lines[1], ",Tom,Cat,2,available,15000,Alice,[Hidden],")
+		assert.Contains(t, lines[2], sold.Sale.OrderID.String()+",2025-03-11T09:00:00Z,customer1")
+		encryptor.AssertNotCalled(t, "Decrypt", mock.Anything)
+	})
+
+	t.Run("decrypts emails for the owner", func(t *testing.T) {
+		petRepo := new(mocks.MockPetRepository)
+		storeRepo := new(mocks.MockStoreRepository)
+		encryptor := new(mocks.MockEncryptor)
+		storeRepo.On("GetByID", mock.Anything, storeID).Return(store, nil)
+		petRepo.On("Stream", mock.Anything, mock.AnythingOfType("models.PetFilter"), mock.Anything).Run(streamPets(available)).Return(nil)
+		encryptor.On("Decrypt", "enc-alice").Return("alice@example.com", nil)
+
+		service := NewExportService(petRepo, new(mocks.MockOrderRepository), storeRepo, encryptor)
+
+		var buf bytes.Buffer
+		err := service.ExportPets(context.Background(), "merchant1", models.PetFilter{StoreID: &storeID}, true, export.FormatJSONL, &buf)
+
+		require.NoError(t, err)
+		var record map[string]any
+		require.NoError(t, json.Unmarshal(bytes.TrimSpace(buf.Bytes()), &record))
+		assert.Equal(t, "alice@example.com", record["breeder_email"])
+		assert.Nil(t, record["order_id"])
+	})
+
+	t.Run("store of another merchant", func(t *testing.T) {
+		petRepo := new(mocks.MockPetRepository)
+		storeRepo := new(mocks.MockStoreRepository)
+		storeRepo.On("GetByID", mock.Anything, storeID).Return(store, nil)
+
+		service := NewExportService(petRepo, new(mocks.MockOrderRepository), storeRepo, new(mocks.MockEncryptor))
+
+		var buf bytes.Buffer
+		err := service.ExportPets(context.Background(), "merchant2", models.PetFilter{StoreID: &storeID}, true, export.FormatCSV, &buf)
+
+		var notFound apperrors.StoreNotFoundError
+		assert.True(t, errors.As(err, &notFound))
+		assert.Empty(t, buf.String())
+		petRepo.AssertNotCalled(t, "Stream", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		start := createdAt
+		end := createdAt.AddDate(0, 0, -1)
+		tests := []struct {
+			name   string
+			filter models.PetFilter
+		}{
+			{name: "missing store", filter: models.PetFilter{}},
+			{name: "start without end", filter: models.PetFilter{StoreID: &storeID, StartDate: &start}},
+			{name: "end before start", filter: models.PetFilter{StoreID: &storeID, StartDate: &start, EndDate: &end}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service := NewExportService(new(mocks.MockPetRepository), new(mocks.MockOrderRepository), new(mocks.MockStoreRepository), new(mocks.MockEncryptor))
+
+				err := service.ExportPets(context.Background(), "merchant1", tt.filter, false, export.FormatCSV, &bytes.Buffer{})
+
+				var validationErr apperrors.ValidationError
+				assert.True(t, errors.As(err, &validationErr))
+			})
+		}
+	})
+}
+
+func TestExportService_ExportOrders(t *testing.T) {
+	storeID := uuid.New()
+	store := &models.Store{ID: storeID, OwnerID: "merchant1"}
+	captured := models.PaymentStatusCaptured
+	order := &models.Order{ID: uuid.New(), CustomerID: "customer1", StoreID: storeID, TotalPets: 2, TotalCents: 27000,
+		DiscountCents: 3000, PaymentStatus: captured, Status: models.OrderStatusPlaced, CreatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)}
+
+	t.Run("streams filtered orders", func(t *testing.T) {
+		orderRepo := new(mocks.MockOrderRepository)
+		storeRepo := new(mocks.MockStoreRepository)
+		storeRepo.On("GetByID", mock.Anything, storeID).Return(store, nil)
+		orderRepo.On("Stream", mock.Anything, mock.MatchedBy(func(filter models.OrderFilter) bool {
+			return filter.StoreID == storeID && filter.PaymentStatus != nil && *filter.PaymentStatus == captured
+		}), mock.Anything).Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*models.Order) error)
+			_ = fn(order)
+		}).Return(nil)
+
+		service := NewExportService(new(mocks.MockPetRepository), orderRepo, storeRepo, new(mocks.MockEncryptor))
+
+		var buf bytes.Buffer
+		err := service.ExportOrders(context.Background(), "merchant1", models.OrderFilter{StoreID: storeID, PaymentStatus: &captured}, export.FormatCSV, &buf)
+
+		require.NoError(t, err)
+		assert.Equal(t,
+			"id,customer_id,status,payment_status,total_pets,subtotal_cents,discount_cents,total_cents,created_at\n"+
+				order.ID.String()+",customer1,placed,captured,2,30000,3000,27000,2025-03-02T00:00:00Z\n",
+			buf.String())
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		orderRepo := new(mocks.MockOrderRepository)
+		storeRepo := new(mocks.MockStoreRepository)
+		storeRepo.On("GetByID", mock.Anything, storeID).Return(store, nil)
+		orderRepo.On("Stream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+
+		service := NewExportService(new(mocks.MockPetRepository), orderRepo, storeRepo, new(mocks.MockEncryptor))
+
+		err := service.ExportOrders(context.Background(), "merchant1", models.OrderFilter{StoreID: storeID}, export.FormatJSONL, &bytes.Buffer{})
+
+		assert.Error(t, err)
+	})
+}
+
+func TestExportServiceInterface_Implementation(t *testing.T) {
+	var _ ExportServiceInterface = NewExportService(new(mocks.MockPetRepository), new(mocks.MockOrderRepository), new(mocks.MockStoreRepository), new(mocks.MockEncryptor))
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/notify"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// InventoryServiceInterface defines the interface for inventory aging operations
+type InventoryServiceInterface interface {
+	InventoryAging(ctx context.Context, storeID uuid.UUID) (*models.InventoryAging, error)
+	InventoryAlerts(ctx context.Context, storeID uuid.UUID, limit, offset int) ([]*models.InventoryAlert, int, error)
+	CheckStaleInventory(ctx context.Context) ([]*models.InventoryAlert, error)
+}
+
+// InventoryService implements InventoryServiceInterface
+type InventoryService struct {
+	repo     repository.InventoryRepositoryInterface
+	settings StoreSettingsServiceInterface
+	notifier notify.Notifier // optional; digests are skipped when nil
+	now      func() time.Time
+}
+
+// NewInventoryService creates a new inventory service
+func NewInventoryService(repo repository.InventoryRepositoryInterface, settings StoreSettingsServiceInterface, notifier notify.Notifier) *InventoryService {
+	return &InventoryService{
+		repo:     repo,
+		settings: settings,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+// InventoryAging buckets a store's unsold pets by how many days they have been listed
+func (s *InventoryService) InventoryAging(ctx context.Context, storeID uuid.UUID) (*models.InventoryAging, error) {
+	settings, err := s.settings.GetStoreSettings(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := s.now().UTC()
+	buckets, stale, err := s.repo.AgingBuckets(ctx, storeID, asOf, settings.StaleAfterDays)
+	if err != nil {
+		return nil, err
+	}
+
+	aging := &models.InventoryAging{
+		StoreID:        storeID,
+		AsOf:           asOf,
+		StaleAfterDays: settings.StaleAfterDays,
+		Buckets:        buckets,
+		StalePets:      stale,
+	}
+	for _, bucket := range buckets {
+		aging.TotalPets += bucket.PetCount
+		aging.TotalValueCents += bucket.ValueCents
+	}
+
+	return aging, nil
+}
+
+// InventoryAlerts lists the open stale listing alerts of a store
+func (s *InventoryService) InventoryAlerts(ctx context.Context, storeID uuid.UUID, limit, offset int) ([]*models.InventoryAlert, int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.repo.ListAlerts(ctx, storeID, limit, offset)
+}
+
+// CheckStaleInventory records an alert for every newly stale pet and sends each
+// affected merchant a digest. Pets are alerted on once, so repeated runs are cheap.
+func (s *InventoryService) CheckStaleInventory(ctx context.Context) ([]*models.InventoryAlert, error) {
+	stale, err := s.repo.ListStalePets(ctx, s.now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if len(stale) == 0 {
+		return nil, nil
+	}
+
+	alerts, err := s.repo.CreateAlerts(ctx, stale)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.notifier != nil {
+		s.sendDigests(ctx, stale, alerts)
+	}
+
+	return alerts, nil
+}
+
+// RunAgingJob checks for stale inventory immediately and then every interval until ctx is cancelled
+func (s *InventoryService) RunAgingJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if alerts, err := s.CheckStaleInventory(ctx); err != nil {
+			log.Printf("Inventory aging check failed: %v", err)
+		} else if len(alerts) > 0 {
+			log.Printf("Inventory aging check raised %d alerts", len(alerts))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendDigests notifies each merchant once about the stale pets of all their stores.
+// Delivery failures are logged; the alerts are already recorded.
+func (s *InventoryService) sendDigests(ctx context.Context, stale []*models.StalePet, alerts []*models.InventoryAlert) {
+	created := make(map[uuid.UUID]bool, len(alerts))
+	for _, alert := range alerts {
+		created[alert.PetID] = true
+	}
+
+	var owners []string
+	digests := make(map[string][]*models.StalePet)
+	for _, pet := range stale {
+		if !created[pet.PetID] {
+			continue
+		}
+		if _, ok := digests[pet.OwnerID]; !ok {
+			owners = append(owners, pet.OwnerID)
+		}
+		digests[pet.OwnerID] = append(digests[pet.OwnerID], pet)
+	}
+
+	for _, owner := range owners {
+		pets := digests[owner]
+
+		var body strings.Builder
+		for _, pet := range pets {
+			fmt.Fprintf(&body, "%s at %s has been listed for %d days\n", pet.PetName, pet.StoreName, pet.DaysListed)
+		}
+
+		msg := notify.Message{
+			Recipient: owner,
+			Subject:   fmt.Sprintf("%d pets have been listed without selling", len(pets)),
+			Body:      body.String(),
+		}
+		if err := s.notifier.Notify(ctx, msg); err != nil {
+			log.Printf("Failed to send inventory digest to %s: %v", owner, err)
+		}
+	}
+}
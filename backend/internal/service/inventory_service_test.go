@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/notify"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryService_InventoryAging(t *testing.T) {
+	storeID := uuid.New()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("totals the buckets", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		buckets := []models.AgingBucket{
+			{AgingRange: models.InventoryAgingRanges[0], PetCount: 4, ValueCents: 40000},
+			{AgingRange: models.InventoryAgingRanges[1], PetCount: 2, ValueCents: 15000},
+			{AgingRange: models.InventoryAgingRanges[2]},
+			{AgingRange: models.InventoryAgingRanges[3], PetCount: 1, ValueCents: 5000},
+		}
+		repo.On("AgingBuckets", mock.Anything, storeID, now, models.DefaultStaleAfterDays).Return(buckets, 1, nil)
+
+		service := NewInventoryService(repo, defaultSettingsService(), nil)
+		service.now = func() time.Time { return now }
+
+		aging, err := service.InventoryAging(context.Background(), storeID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, aging.TotalPets)
+		assert.Equal(t, int64(60000), aging.TotalValueCents)
+		assert.Equal(t, 1, aging.StalePets)
+		assert.Equal(t, models.DefaultStaleAfterDays, aging.StaleAfterDays)
+		assert.Len(t, aging.Buckets, 4)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("uses the store threshold", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		settings := new(mocks.MockStoreSettingsService)
+		custom := models.DefaultStoreSettings(storeID)
+		custom.StaleAfterDays = 14
+		settings.On("GetStoreSettings", mock.Anything, storeID).Return(custom, nil)
+		repo.On("AgingBuckets", mock.Anything, storeID, now, 14).Return([]models.AgingBucket{}, 0, nil)
+
+		service := NewInventoryService(repo, settings, nil)
+		service.now = func() time.Time { return now }
+
+		aging, err := service.InventoryAging(context.Background(), storeID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 14, aging.StaleAfterDays)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		repo.On("AgingBuckets", mock.Anything, storeID, mock.Anything, mock.Anything).Return(nil, 0, errors.New("db down"))
+
+		service := NewInventoryService(repo, defaultSettingsService(), nil)
+
+		_, err := service.InventoryAging(context.Background(), storeID)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestInventoryService_InventoryAlerts(t *testing.T) {
+	storeID := uuid.New()
+
+	tests := []struct {
+		name       string
+		limit      int
+		offset     int
+		wantLimit  int
+		wantOffset int
+	}{
+		{name: "default page size", limit: 0, offset: 0, wantLimit: 20, wantOffset: 0},
+		{name: "page size capped", limit: 500, offset: 10, wantLimit: 100, wantOffset: 10},
+		{name: "negative offset", limit: 5, offset: -3, wantLimit: 5, wantOffset: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockInventoryRepository)
+			repo.On("ListAlerts", mock.Anything, storeID, tt.wantLimit, tt.wantOffset).Return([]*models.InventoryAlert{}, 0, nil)
+
+			service := NewInventoryService(repo, defaultSettingsService(), nil)
+
+			_, _, err := service.InventoryAlerts(context.Background(), storeID, tt.limit, tt.offset)
+
+			assert.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestInventoryService_CheckStaleInventory(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	storeA, storeB := uuid.New(), uuid.New()
+	rex := &models.StalePet{PetID: uuid.New(), PetName: "Rex", StoreID: storeA, StoreName: "Downtown", OwnerID: "merchant1", DaysListed: 45}
+	tom := &models.StalePet{PetID: uuid.New(), PetName: "Tom", StoreID: storeB, StoreName: "Uptown", OwnerID: "merchant1", DaysListed: 31}
+	kermit := &models.StalePet{PetID: uuid.New(), PetName: "Kermit", StoreID: uuid.New(), StoreName: "Pond", OwnerID: "merchant2", DaysListed: 120}
+
+	t.Run("records alerts and sends one digest per merchant", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		notifier := new(mocks.MockNotifier)
+		stale := []*models.StalePet{rex, tom, kermit}
+		alerts := []*models.InventoryAlert{
+			{ID: uuid.New(), PetID: rex.PetID}, {ID: uuid.New(), PetID: tom.PetID}, {ID: uuid.New(), PetID: kermit.PetID},
+		}
+		repo.On("ListStalePets", mock.Anything, now).Return(stale, nil)
+		repo.On("CreateAlerts", mock.Anything, stale).Return(alerts, nil)
+		notifier.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+			return msg.Recipient == "merchant1" && strings.Contains(msg.Body, "Rex at Downtown has been listed for 45 days") &&
+				strings.Contains(msg.Body, "Tom at Uptown")
+		})).Return(nil).Once()
+		notifier.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+			return msg.Recipient == "merchant2"
+		})).Return(nil).Once()
+
+		service := NewInventoryService(repo, defaultSettingsService(), notifier)
+		service.now = func() time.Time { return now }
+
+		created, err := service.CheckStaleInventory(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, created, 3)
+		repo.AssertExpectations(t)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("skips pets alerted by a concurrent run", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		notifier := new(mocks.MockNotifier)
+		stale := []*models.StalePet{rex, kermit}
+		repo.On("ListStalePets", mock.Anything, now).Return(stale, nil)
+		repo.On("CreateAlerts", mock.Anything, stale).Return([]*models.InventoryAlert{{ID: uuid.New(), PetID: kermit.PetID}}, nil)
+		notifier.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+			return msg.Recipient == "merchant2"
+		})).Return(nil).Once()
+
+		service := NewInventoryService(repo, defaultSettingsService(), notifier)
+		service.now = func() time.Time { return now }
+
+		created, err := service.CheckStaleInventory(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, created, 1)
+		notifier.AssertExpectations(t)
+	})
+
+	t.Run("notification failures do not fail the check", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		notifier := new(mocks.MockNotifier)
+		stale := []*models.StalePet{kermit}
+		repo.On("ListStalePets", mock.Anything, now).Return(stale, nil)
+		repo.On("CreateAlerts", mock.Anything, stale).Return([]*models.InventoryAlert{{ID: uuid.New(), PetID: kermit.PetID}}, nil)
+		notifier.On("Notify", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+
+		service := NewInventoryService(repo, defaultSettingsService(), notifier)
+		service.now = func() time.Time { return now }
+
+		created, err := service.CheckStaleInventory(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, created, 1)
+	})
+
+	t.Run("nothing stale", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		repo.On("ListStalePets", mock.Anything, now).Return([]*models.StalePet{}, nil)
+
+		service := NewInventoryService(repo, defaultSettingsService(), new(mocks.MockNotifier))
+		service.now = func() time.Time { return now }
+
+		created, err := service.CheckStaleInventory(context.Background())
+
+		require.NoError(t, err)
+		assert.Empty(t, created)
+		repo.AssertNotCalled(t, "CreateAlerts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("without a notifier", func(t *testing.T) {
+		repo := new(mocks.MockInventoryRepository)
+		stale := []*models.StalePet{kermit}
+		repo.On("ListStalePets", mock.Anything, now).Return(stale, nil)
+		repo.On("CreateAlerts", mock.Anything, stale).Return([]*models.InventoryAlert{{ID: uuid.New(), PetID: kermit.PetID}}, nil)
+
+		service := NewInventoryService(repo, defaultSettingsService(), nil)
+		service.now = func() time.Time { return now }
+
+		created, err := service.CheckStaleInventory(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, created, 1)
+	})
+}
+
+func TestInventoryServiceInterface_Implementation(t *testing.T) {
+	var _ InventoryServiceInterface = NewInventoryService(new(mocks.MockInventoryRepository), new(mocks.MockStoreSettingsService), nil)
+}
@@ -3,21 +3,29 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/cache"
 	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/payment"
 	"github.com/fehepe/pet-store/backend/internal/repository"
 	"github.com/fehepe/pet-store/backend/internal/validation"
 	"github.com/google/uuid"
 )
 
+// expiryBatchSize bounds how many unpaid orders one expiry run releases
+const expiryBatchSize = 100
+
 // OrderServiceInterface defines the interface for order operations
 type OrderServiceInterface interface {
 	CreateOrder(ctx context.Context, input models.CreateOrderInput) (*models.Order, error)
+	GetOrderByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error)
 	GetOrderPets(ctx context.Context, orderID uuid.UUID) ([]*models.Pet, error)
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error
 }
 
 // OrderService implements OrderServiceInterface with improved error handling and validation
@@ -26,6 +34,12 @@ type OrderService struct {
 	petRepo    repository.PetRepositoryInterface
 	cache      cache.CacheInterface
 	petService PetServiceInterface
+	payments   payment.PaymentProvider
+	promotions PromotionServiceInterface
+	pickups    PickupServiceInterface
+	settings   StoreSettingsServiceInterface
+	events     PetEventServiceInterface
+	webhooks   WebhookServiceInterface // optional; webhook events are skipped when nil
 }
 
 // NewOrderService creates a new order service
@@ -34,60 +48,158 @@ func NewOrderService(
 	petRepo repository.PetRepositoryInterface,
 	cache cache.CacheInterface,
 	petService PetServiceInterface,
+	payments payment.PaymentProvider,
+	promotions PromotionServiceInterface,
+	pickups PickupServiceInterface,
+	settings StoreSettingsServiceInterface,
+	events PetEventServiceInterface,
+	webhooks WebhookServiceInterface,
 ) *OrderService {
 	return &OrderService{
 		repo:       repo,
 		petRepo:    petRepo,
 		cache:      cache,
 		petService: petService,
+		payments:   payments,
+		promotions: promotions,
+		pickups:    pickups,
+		settings:   settings,
+		events:     events,
+		webhooks:   webhooks,
 	}
 }
 
-// CreateOrder creates a new order with proper validation and error handling
+// CreateOrder creates a new order with proper validation and error handling.
+// The order fails unless every requested pet is still available. Discount
+// codes are applied to the locked pets and the pets are reserved under a
+// pending payment; the payment is then authorized and captured once the
+// locks are released, and the reservation is undone if either step fails.
+// Pets are reserved rather than authorized for up front because the total is
+// only known once they are locked, and no provider call is made under a lock;
+// they are marked sold when the payment is captured.
 func (s *OrderService) CreateOrder(ctx context.Context, input models.CreateOrderInput) (*models.Order, error) {
-	if err := validation.ValidateCreateOrderInput(input); err != nil {
+	settings, err := s.settings.GetStoreSettings(ctx, input.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateCreateOrderInput(input, settings); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
 	input.CustomerID = validation.SanitizeString(input.CustomerID)
+	input.CardNumber = validation.SanitizeString(input.CardNumber)
+	input.DiscountCode = validation.SanitizeString(input.DiscountCode)
+
+	order, orderItems, err := s.reserveOrder(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pets are off sale from the moment they are reserved
+	reason := models.PetAvailabilityReserved
+	if order.PaymentStatus == models.PaymentStatusNone {
+		reason = models.PetAvailabilitySold
+	}
+	s.invalidateOrderPets(ctx, input.StoreID, orderItems)
+	for _, item := range orderItems {
+		s.events.PublishAvailabilityChanged(ctx, models.PetAvailabilityChange{
+			PetID:     item.PetID,
+			StoreID:   input.StoreID,
+			Available: false,
+			Reason:    reason,
+			ChangedAt: item.PurchasedAt,
+		})
+	}
+
+	if order.PaymentStatus == models.PaymentStatusPending {
+		if err := s.payForOrder(ctx, order, input.CardNumber); err != nil {
+			return nil, err
+		}
+	}
 
+	return order, nil
+}
+
+// reserveOrder locks the requested pets, prices the order and reserves the pets in one
+// transaction. Priced orders are committed with a pending payment so no provider call is
+// made while the pets and the store are locked; the pets of free orders are sold at once.
+func (s *OrderService) reserveOrder(ctx context.Context, input models.CreateOrderInput) (*models.Order, []*models.OrderItem, error) {
 	var order *models.Order
 	var orderItems []*models.OrderItem
-	unavailablePets := []string{}
 
 	err := s.repo.Transaction(func(tx *sql.Tx) error {
+		if err := s.repo.EnsureStoreOpenWithTx(ctx, tx, input.StoreID); err != nil {
+			return err
+		}
+
+		availablePets, err := s.petRepo.LockAvailableWithTx(ctx, tx, input.StoreID, input.PetIDs)
+		if err != nil {
+			return err
+		}
+		if unavailable := missingPets(input.PetIDs, availablePets); len(unavailable) > 0 {
+			// Nothing is bought unless every pet is, so the customer is never charged for part of an order
+			return apperrors.NewPetsUnavailable(unavailable)
+		}
+
 		order = &models.Order{
-			ID:         uuid.New(),
-			CustomerID: input.CustomerID,
-			StoreID:    input.StoreID,
-			TotalPets:  len(input.PetIDs),
-			CreatedAt:  time.Now(),
+			ID:            uuid.New(),
+			CustomerID:    input.CustomerID,
+			StoreID:       input.StoreID,
+			TotalPets:     len(availablePets),
+			CreatedAt:     time.Now(),
+			PaymentStatus: models.PaymentStatusNone,
 		}
 
 		if err := s.repo.CreateWithTx(ctx, tx, order); err != nil {
 			return fmt.Errorf("failed to create order: %w", err)
 		}
 
-		for _, petID := range input.PetIDs {
-			var petName string
-			checkQuery := `SELECT name FROM pets WHERE id = $1 AND store_id = $2 AND status = $3 FOR UPDATE`
-			err := tx.QueryRowContext(ctx, checkQuery, petID, input.StoreID, models.PetStatusAvailable).Scan(&petName)
-			if err == sql.ErrNoRows {
-				unavailablePets = append(unavailablePets, petID.String())
-				continue
-			} else if err != nil {
-				return fmt.Errorf("failed to check pet availability: %w", err)
+		var subtotalCents int64
+		for _, pet := range availablePets {
+			subtotalCents += pet.PriceCents
+		}
+		if subtotalCents > models.MaxPriceCents {
+			return apperrors.NewBusinessRuleError(fmt.Sprintf("an order cannot exceed %d cents; buy these pets in separate orders", models.MaxPriceCents))
+		}
+
+		discounts, err := s.promotions.ApplyDiscount(ctx, tx, order, availablePets, input.DiscountCode)
+		if err != nil {
+			return err
+		}
+
+		var discountCents int64
+		for _, discount := range discounts {
+			discountCents += discount.AmountCents
+		}
+		if discountCents > subtotalCents {
+			discountCents = subtotalCents
+		}
+
+		order.DiscountCents = discountCents
+		order.TotalCents = subtotalCents - discountCents
+
+		if order.TotalCents > 0 {
+			if input.CardNumber == "" {
+				return apperrors.NewValidationError("cardNumber", "a payment card is required for priced orders")
 			}
+			order.PaymentStatus = models.PaymentStatusPending
+		}
+
+		markPet := s.petRepo.MarkAsReserved
+		if order.PaymentStatus == models.PaymentStatusNone {
+			markPet = s.petRepo.MarkAsSold
+		}
 
-			if err := s.petRepo.MarkAsSold(ctx, tx, petID); err != nil {
-				unavailablePets = append(unavailablePets, petName)
-				continue
+		for _, pet := range availablePets {
+			if err := markPet(ctx, tx, pet.ID); err != nil {
+				return fmt.Errorf("failed to reserve pet: %w", err)
 			}
 
 			orderItem := &models.OrderItem{
 				ID:          uuid.New(),
 				OrderID:     order.ID,
-				PetID:       petID,
+				PetID:       pet.ID,
 				PurchasedAt: time.Now(),
 			}
 
@@ -98,36 +210,380 @@ func (s *OrderService) CreateOrder(ctx context.Context, input models.CreateOrder
 			orderItems = append(orderItems, orderItem)
 		}
 
-		if len(orderItems) == 0 {
-			return apperrors.NewBusinessRuleError("no pets were available for purchase")
+		if err := s.repo.UpdateWithTx(ctx, tx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
 		}
 
-		if len(orderItems) != len(input.PetIDs) {
-			order.TotalPets = len(orderItems)
-			if err := s.repo.UpdateWithTx(ctx, tx, order); err != nil {
-				return fmt.Errorf("failed to update order: %w", err)
-			}
+		if order.PaymentStatus == models.PaymentStatusNone {
+			// Free orders are complete once reserved
+			return s.publishOrderWithTx(ctx, tx, order, orderItems)
 		}
 
 		return nil
 	})
+	if err != nil {
+		return nil, nil, err
+	}
 
+	return order, orderItems, nil
+}
+
+// payForOrder authorizes and captures the total of a reserved order, undoing the
+// reservation when the payment does not go through
+func (s *OrderService) payForOrder(ctx context.Context, order *models.Order, cardNumber string) error {
+	authorization, err := s.authorizePayment(ctx, order, cardNumber)
 	if err != nil {
-		return nil, err
+		if compErr := s.compensateOrder(ctx, order, nil); compErr != nil {
+			return fmt.Errorf("failed to authorize payment: %v (compensation failed: %w)", err, compErr)
+		}
+		return err
 	}
 
-	for _, item := range orderItems {
-		_ = s.cache.Delete(ctx, cache.PetCacheKey(input.StoreID.String(), item.PetID.String()))
+	if err := s.recordAuthorization(ctx, order, authorization); err != nil {
+		if compErr := s.compensateOrder(ctx, order, authorization); compErr != nil {
+			return fmt.Errorf("failed to record payment: %v (compensation failed: %w)", err, compErr)
+		}
+		return fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	if err := s.payments.Capture(ctx, authorization.ID); err != nil {
+		if compErr := s.compensateOrder(ctx, order, authorization); compErr != nil {
+			return fmt.Errorf("failed to capture payment: %v (compensation failed: %w)", err, compErr)
+		}
+		return apperrors.NewBusinessRuleError(fmt.Sprintf("payment could not be captured: %v", err))
 	}
-	_ = s.cache.InvalidatePattern(ctx, fmt.Sprintf("pets:list:%s:*", input.StoreID))
 
-	if len(unavailablePets) > 0 {
-		return order, fmt.Errorf("the following pets are no longer available: %v", unavailablePets)
+	captured, err := s.transitionPayment(ctx, order.ID, models.PaymentStatusCaptured)
+	if err != nil {
+		// The customer has been charged, so the order stands. The provider's capture webhook
+		// records the status and queues the merchant's order webhooks once it arrives.
+		log.Printf("Failed to record captured payment for order %s: %v", order.ID, err)
+		order.PaymentStatus = models.PaymentStatusCaptured
+		return nil
+	}
+	if captured.PaymentStatus == models.PaymentStatusFailed {
+		// The order expired and was released while the payment was being captured
+		s.refundReleasedOrder(ctx, captured)
+		return apperrors.NewBusinessRuleError("order expired before its payment completed")
+	}
+
+	order.PaymentStatus = captured.PaymentStatus
+	return nil
+}
+
+// recordAuthorization stores the authorization of a pending order, unless the order was
+// released while the payment was being authorized
+func (s *OrderService) recordAuthorization(ctx context.Context, order *models.Order, authorization *payment.Authorization) error {
+	return s.repo.Transaction(func(tx *sql.Tx) error {
+		current, err := s.repo.GetByIDForUpdateWithTx(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if !current.PaymentStatus.CanTransitionTo(models.PaymentStatusAuthorized) {
+			return apperrors.NewBusinessRuleError("order is no longer awaiting payment")
+		}
+
+		current.PaymentID = &authorization.ID
+		current.PaymentStatus = models.PaymentStatusAuthorized
+		if err := s.repo.UpdateWithTx(ctx, tx, current); err != nil {
+			return err
+		}
+
+		order.PaymentID = current.PaymentID
+		order.PaymentStatus = current.PaymentStatus
+		return nil
+	})
+}
+
+// publishOrderWithTx queues the order's merchant webhooks in the transaction that completes it
+func (s *OrderService) publishOrderWithTx(ctx context.Context, tx *sql.Tx, order *models.Order, items []*models.OrderItem) error {
+	if s.webhooks == nil {
+		return nil
 	}
+	return s.webhooks.PublishOrderCreated(ctx, tx, order, items)
+}
+
+// missingPets returns the requested pets that are not among the locked ones
+func missingPets(requested []uuid.UUID, locked []*models.Pet) []uuid.UUID {
+	found := make(map[uuid.UUID]bool, len(locked))
+	for _, pet := range locked {
+		found[pet.ID] = true
+	}
+
+	var missing []uuid.UUID
+	for _, petID := range requested {
+		if !found[petID] {
+			missing = append(missing, petID)
+		}
+	}
+	return missing
+}
+
+// authorizePayment places a hold for the order total and maps provider failures to business errors
+func (s *OrderService) authorizePayment(ctx context.Context, order *models.Order, cardNumber string) (*payment.Authorization, error) {
+	auth, err := s.payments.Authorize(ctx, payment.AuthorizeRequest{
+		OrderID:     order.ID,
+		AmountCents: order.TotalCents,
+		Currency:    payment.DefaultCurrency,
+		CardNumber:  cardNumber,
+	})
+
+	var declined payment.DeclinedError
+	switch {
+	case err == nil:
+		return auth, nil
+	case errors.As(err, &declined):
+		return nil, apperrors.NewBusinessRuleError(fmt.Sprintf("payment declined: %s", declined.Message))
+	case errors.Is(err, payment.ErrTimeout):
+		return nil, apperrors.NewBusinessRuleError("payment provider did not respond, please try again")
+	default:
+		return nil, fmt.Errorf("failed to authorize payment: %w", err)
+	}
+}
+
+// compensateOrder undoes a committed order whose payment could not be authorized or captured;
+// authorization is nil when no hold was placed
+func (s *OrderService) compensateOrder(ctx context.Context, order *models.Order, authorization *payment.Authorization) error {
+	if authorization != nil {
+		if err := s.payments.Void(ctx, authorization.ID); err != nil {
+			// The hold lapses at the provider on its own, but it needs a look before then
+			log.Printf("Failed to void payment %s for order %s: %v", authorization.ID, order.ID, err)
+		}
+	}
+
+	failed, err := s.transitionPayment(ctx, order.ID, models.PaymentStatusFailed)
+	if err != nil {
+		return err
+	}
+
+	order.PaymentStatus = failed.PaymentStatus
+	return nil
+}
+
+// transitionPayment locks an order and moves its payment to status when the transition is
+// allowed, returning the order as it now stands. A captured payment sells the reserved pets,
+// completes the order and queues its webhooks; a failed or refunded one releases the order.
+func (s *OrderService) transitionPayment(ctx context.Context, orderID uuid.UUID, status models.PaymentStatus) (*models.Order, error) {
+	var order *models.Order
+	var released []*models.OrderItem
 
+	err := s.repo.Transaction(func(tx *sql.Tx) error {
+		var err error
+		order, err = s.repo.GetByIDForUpdateWithTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if !order.PaymentStatus.CanTransitionTo(status) {
+			return nil
+		}
+
+		items, err := s.repo.GetItemsWithTx(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		if status.ReleasesOrder() {
+			released, err = s.releaseOrderWithTx(ctx, tx, order, items)
+			if err != nil {
+				return err
+			}
+		}
+
+		order.PaymentStatus = status
+		if err := s.repo.UpdateWithTx(ctx, tx, order); err != nil {
+			return err
+		}
+
+		if status == models.PaymentStatusCaptured {
+			for _, item := range items {
+				if err := s.petRepo.MarkAsSold(ctx, tx, item.PetID); err != nil {
+					return fmt.Errorf("failed to mark pet as sold: %w", err)
+				}
+			}
+			return s.publishOrderWithTx(ctx, tx, order, items)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.announceReleased(ctx, order.StoreID, released)
 	return order, nil
 }
 
+// releaseOrderWithTx cancels an order's pickup appointment and gives up its discount. Pets
+// of an order that has not reached pickup are put back on sale and their items marked
+// released; pets of a scheduled or completed pickup may already be with the customer, so
+// they stay sold. Returns the items whose pets went back on sale.
+func (s *OrderService) releaseOrderWithTx(ctx context.Context, tx *sql.Tx, order *models.Order, items []*models.OrderItem) ([]*models.OrderItem, error) {
+	if err := s.pickups.CancelPickup(ctx, tx, order.ID); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotions.ReleaseDiscount(ctx, tx, order.ID); err != nil {
+		return nil, err
+	}
+
+	if order.Status == models.OrderStatusPickupScheduled || order.Status == models.OrderStatusCompleted {
+		return nil, nil
+	}
+
+	var released []*models.OrderItem
+	for _, item := range items {
+		if item.ReleasedAt != nil {
+			continue
+		}
+		if err := s.petRepo.MarkAsAvailable(ctx, tx, item.PetID); err != nil {
+			return nil, err
+		}
+		released = append(released, item)
+	}
+
+	if err := s.repo.ReleaseItemsWithTx(ctx, tx, order.ID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return released, nil
+}
+
+// announceReleased publishes that the pets of a released order are available again
+func (s *OrderService) announceReleased(ctx context.Context, storeID uuid.UUID, items []*models.OrderItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	s.invalidateOrderPets(ctx, storeID, items)
+	releasedAt := time.Now()
+	for _, item := range items {
+		s.events.PublishAvailabilityChanged(ctx, models.PetAvailabilityChange{
+			PetID:     item.PetID,
+			StoreID:   storeID,
+			Available: true,
+			Reason:    models.PetAvailabilityReleased,
+			ChangedAt: releasedAt,
+		})
+	}
+}
+
+// refundReleasedOrder returns a payment captured for an order that was already released
+func (s *OrderService) refundReleasedOrder(ctx context.Context, order *models.Order) {
+	if order.PaymentID == nil {
+		return
+	}
+	if err := s.payments.Refund(ctx, *order.PaymentID, order.TotalCents); err != nil {
+		log.Printf("Failed to refund payment %s captured for released order %s: %v", *order.PaymentID, order.ID, err)
+	}
+}
+
+// ExpireUnpaidOrders fails orders that have awaited payment for longer than timeout, which
+// happens when the server stops between reserving an order and paying for it. Their pets
+// and discount are released and any authorization is voided. Returns how many expired.
+func (s *OrderService) ExpireUnpaidOrders(ctx context.Context, timeout time.Duration) (int, error) {
+	orders, err := s.repo.ListAwaitingPayment(ctx, time.Now().Add(-timeout), expiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, order := range orders {
+		failed, err := s.transitionPayment(ctx, order.ID, models.PaymentStatusFailed)
+		if err != nil {
+			log.Printf("Failed to expire order %s: %v", order.ID, err)
+			continue
+		}
+		if failed.PaymentStatus != models.PaymentStatusFailed {
+			// The payment was captured after the order was listed
+			continue
+		}
+
+		expired++
+		if failed.PaymentID != nil {
+			// Voided after the order is failed, so a capture racing the expiry is refunded rather than lost
+			if err := s.payments.Void(ctx, *failed.PaymentID); err != nil {
+				log.Printf("Failed to void payment %s for expired order %s: %v", *failed.PaymentID, failed.ID, err)
+			}
+		}
+	}
+
+	return expired, nil
+}
+
+// RunExpiryJob expires unpaid orders immediately and then every interval until ctx is cancelled
+func (s *OrderService) RunExpiryJob(ctx context.Context, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if expired, err := s.ExpireUnpaidOrders(ctx, timeout); err != nil && ctx.Err() == nil {
+			log.Printf("Order expiry failed: %v", err)
+		} else if expired > 0 {
+			log.Printf("Order expiry released %d unpaid orders", expired)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// HandlePaymentWebhook verifies a provider callback and moves its order's payment to the reported
+// status when the transition is allowed; failed and refunded payments release the order
+func (s *OrderService) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := s.payments.VerifyWebhook(payload, signature)
+	if err != nil {
+		return apperrors.NewValidationError("signature", err.Error())
+	}
+
+	var status models.PaymentStatus
+	switch event.Type {
+	case payment.EventPaymentCaptured:
+		status = models.PaymentStatusCaptured
+	case payment.EventPaymentRefunded:
+		status = models.PaymentStatusRefunded
+	case payment.EventPaymentFailed:
+		status = models.PaymentStatusFailed
+	default:
+		// Unknown events are acknowledged so the provider stops retrying
+		return nil
+	}
+
+	order, err := s.repo.GetByPaymentID(ctx, event.AuthorizationID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := s.transitionPayment(ctx, order.ID, status)
+	if err != nil {
+		return err
+	}
+
+	if updated.PaymentStatus != status {
+		// Late or repeated events never move an order backwards; they are acknowledged so the provider stops retrying
+		log.Printf("Ignoring %s for order %s with payment %s", event.Type, updated.ID, updated.PaymentStatus)
+		if status == models.PaymentStatusCaptured && updated.PaymentStatus == models.PaymentStatusFailed {
+			s.refundReleasedOrder(ctx, updated)
+		}
+	}
+
+	return nil
+}
+
+// invalidateOrderPets drops cached entries for pets whose availability changed
+func (s *OrderService) invalidateOrderPets(ctx context.Context, storeID uuid.UUID, items []*models.OrderItem) {
+	for _, item := range items {
+		_ = s.cache.Delete(ctx, cache.PetCacheKey(storeID.String(), item.PetID.String()))
+	}
+	_ = s.cache.InvalidatePattern(ctx, fmt.Sprintf("pets:list:%s:*", storeID))
+}
+
+// GetOrderByID retrieves an order; callers check that the viewer may see it
+func (s *OrderService) GetOrderByID(ctx context.Context, orderID uuid.UUID) (*models.Order, error) {
+	return s.repo.GetByID(ctx, orderID)
+}
+
 // GetOrderPets retrieves pets for a specific order
 func (s *OrderService) GetOrderPets(ctx context.Context, orderID uuid.UUID) ([]*models.Pet, error) {
 	cacheKey := fmt.Sprintf("order:pets:%s", orderID.String())
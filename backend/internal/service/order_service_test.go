@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
 	"github.com/fehepe/pet-store/backend/internal/mocks"
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/payment"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -82,7 +85,7 @@ func TestOrderService_CreateOrder_Validation(t *testing.T) {
 				mockOrderRepo.On("Transaction", mock.AnythingOfType("func(*sql.Tx) error")).Return(assert.AnError)
 			}
 
-			service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, mockPetService)
+			service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, mockPetService, payment.NewFakeProvider("secret"), new(mocks.MockPromotionService), new(mocks.MockPickupService), defaultSettingsService(), testPetEvents(), nil)
 
 			_, err := service.CreateOrder(context.Background(), tt.input)
 
@@ -95,6 +98,255 @@ func TestOrderService_CreateOrder_Validation(t *testing.T) {
 	}
 }
 
+func TestOrderService_CreateOrder_TotalTooLarge(t *testing.T) {
+	storeID := uuid.New()
+	pets := []*models.Pet{
+		{ID: uuid.New(), StoreID: storeID, PriceCents: models.MaxPriceCents, Status: models.PetStatusAvailable},
+		{ID: uuid.New(), StoreID: storeID, PriceCents: 1, Status: models.PetStatusAvailable},
+	}
+
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockPetRepo := new(mocks.MockPetRepository)
+	mockOrderRepo.On("Transaction", mock.Anything).Return(nil)
+	mockOrderRepo.On("EnsureStoreOpenWithTx", mock.Anything, mock.Anything, storeID).Return(nil)
+	mockOrderRepo.On("CreateWithTx", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockPetRepo.On("LockAvailableWithTx", mock.Anything, mock.Anything, storeID, mock.Anything).Return(pets, nil)
+
+	service := NewOrderService(mockOrderRepo, mockPetRepo, new(mocks.MockCache), new(mocks.MockPetService), payment.NewFakeProvider("secret"), new(mocks.MockPromotionService), new(mocks.MockPickupService), defaultSettingsService(), testPetEvents(), nil)
+
+	_, err := service.CreateOrder(context.Background(), models.CreateOrderInput{
+		CustomerID: "customer123",
+		StoreID:    storeID,
+		PetIDs:     []uuid.UUID{pets[0].ID, pets[1].ID},
+		CardNumber: payment.CardApproved,
+	})
+
+	assert.IsType(t, apperrors.BusinessRuleError{}, err)
+	mockPetRepo.AssertNotCalled(t, "MarkAsReserved", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	storeID := uuid.New()
+	firstPet := &models.Pet{ID: uuid.New(), StoreID: storeID, Name: "Rex", PriceCents: 5000, Status: models.PetStatusAvailable}
+	secondPet := &models.Pet{ID: uuid.New(), StoreID: storeID, Name: "Tom", PriceCents: 3000, Status: models.PetStatusAvailable}
+
+	tests := []struct {
+		name            string
+		cardNumber      string
+		lockedPets      []*models.Pet
+		updateStatusErr error
+		wantUnavailable []uuid.UUID
+		wantErr         bool
+		wantCompensated bool
+		wantVoided      bool
+		wantWebhooks    int
+	}{
+		{
+			name:         "every pet available",
+			cardNumber:   payment.CardApproved,
+			lockedPets:   []*models.Pet{firstPet, secondPet},
+			wantWebhooks: 3,
+		},
+		{
+			name:            "one pet unavailable fails the whole order",
+			cardNumber:      payment.CardApproved,
+			lockedPets:      []*models.Pet{firstPet},
+			wantUnavailable: []uuid.UUID{secondPet.ID},
+			wantErr:         true,
+		},
+		{
+			name:            "captured order survives a failed status update",
+			cardNumber:      payment.CardApproved,
+			lockedPets:      []*models.Pet{firstPet, secondPet},
+			updateStatusErr: assert.AnError,
+		},
+		{
+			name:            "declined card releases the reservation",
+			cardNumber:      payment.CardDeclined,
+			lockedPets:      []*models.Pet{firstPet, secondPet},
+			wantErr:         true,
+			wantCompensated: true,
+		},
+		{
+			name:            "failed capture releases the reservation",
+			cardNumber:      payment.CardCaptureFails,
+			lockedPets:      []*models.Pet{firstPet, secondPet},
+			wantErr:         true,
+			wantCompensated: true,
+			wantVoided:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			mockPetRepo := new(mocks.MockPetRepository)
+			mockCache := new(mocks.MockCache)
+			mockPromotions := new(mocks.MockPromotionService)
+			mockPickups := new(mocks.MockPickupService)
+
+			var orderID uuid.UUID
+			var statuses []models.PaymentStatus
+			mockOrderRepo.On("Transaction", mock.Anything).Return(nil)
+			mockOrderRepo.On("EnsureStoreOpenWithTx", mock.Anything, mock.Anything, storeID).Return(nil)
+			mockPetRepo.On("LockAvailableWithTx", mock.Anything, mock.Anything, storeID, mock.Anything).Return(tt.lockedPets, nil)
+			// stored and items stand in for the order's rows, so locked reads see earlier writes
+			var stored models.Order
+			var items []*models.OrderItem
+			mockOrderRepo.On("CreateWithTx", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				stored = *args.Get(2).(*models.Order)
+			}).Return(nil).Maybe()
+			lockOrder := mockOrderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, mock.Anything).Maybe()
+			lockOrder.Run(func(mock.Arguments) {
+				order := stored
+				lockOrder.ReturnArguments = mock.Arguments{&order, nil}
+			})
+			getItems := mockOrderRepo.On("GetItemsWithTx", mock.Anything, mock.Anything, mock.Anything).Maybe()
+			getItems.Run(func(mock.Arguments) {
+				getItems.ReturnArguments = mock.Arguments{items, nil}
+			})
+			mockPromotions.On("ApplyDiscount", mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(nil, nil).Maybe()
+			mockPromotions.On("ReleaseDiscount", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockPetRepo.On("MarkAsReserved", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockPetRepo.On("MarkAsSold", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockPetRepo.On("MarkAsAvailable", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockOrderRepo.On("CreateItem", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				items = append(items, args.Get(2).(*models.OrderItem))
+			}).Return(nil).Maybe()
+			mockOrderRepo.On("ReleaseItemsWithTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockPickups.On("CancelPickup", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			recordUpdate := func(args mock.Arguments) {
+				orderID = args.Get(2).(*models.Order).ID
+				statuses = append(statuses, args.Get(2).(*models.Order).PaymentStatus)
+				stored = *args.Get(2).(*models.Order)
+			}
+			capturedOrder := mock.MatchedBy(func(order *models.Order) bool { return order.PaymentStatus == models.PaymentStatusCaptured })
+			mockOrderRepo.On("UpdateWithTx", mock.Anything, mock.Anything, capturedOrder).Run(func(args mock.Arguments) {
+				if tt.updateStatusErr == nil {
+					recordUpdate(args)
+				}
+			}).Return(tt.updateStatusErr).Maybe()
+			mockOrderRepo.On("UpdateWithTx", mock.Anything, mock.Anything, mock.Anything).Run(recordUpdate).Return(nil).Maybe()
+			webhookRepo := new(mocks.MockWebhookRepository)
+			webhookRepo.On("EnqueueDeliveriesWithTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+			mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockCache.On("InvalidatePattern", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events := testPetEvents()
+			changes, err := events.SubscribeAvailabilityChanged(ctx, storeID)
+			assert.NoError(t, err)
+
+			provider := payment.NewFakeProvider("secret")
+			webhooks := NewWebhookService(webhookRepo, new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+			service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, new(mocks.MockPetService), provider, mockPromotions, mockPickups, defaultSettingsService(), events, webhooks)
+
+			order, err := service.CreateOrder(ctx, models.CreateOrderInput{
+				CustomerID: "customer123",
+				StoreID:    storeID,
+				PetIDs:     []uuid.UUID{firstPet.ID, secondPet.ID},
+				CardNumber: tt.cardNumber,
+			})
+
+			if tt.wantUnavailable != nil {
+				var unavailable apperrors.PetsUnavailableError
+				assert.ErrorAs(t, err, &unavailable)
+				assert.Equal(t, tt.wantUnavailable, unavailable.PetIDs)
+				mockOrderRepo.AssertNotCalled(t, "CreateWithTx", mock.Anything, mock.Anything, mock.Anything)
+				mockPetRepo.AssertNotCalled(t, "MarkAsReserved", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, order.TotalPets)
+				assert.Equal(t, int64(8000), order.TotalCents)
+				assert.Equal(t, models.PaymentStatusCaptured, order.PaymentStatus)
+				mockPetRepo.AssertNumberOfCalls(t, "MarkAsReserved", 2)
+			}
+
+			if tt.wantErr || tt.updateStatusErr != nil {
+				// Pets are only sold once the captured payment is recorded
+				mockPetRepo.AssertNotCalled(t, "MarkAsSold", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				mockPetRepo.AssertCalled(t, "MarkAsSold", mock.Anything, mock.Anything, firstPet.ID)
+				mockPetRepo.AssertCalled(t, "MarkAsSold", mock.Anything, mock.Anything, secondPet.ID)
+			}
+
+			if tt.wantCompensated {
+				// The reservation commits as pending and is then marked failed
+				assert.Equal(t, models.PaymentStatusPending, statuses[0])
+				assert.Equal(t, models.PaymentStatusFailed, statuses[len(statuses)-1])
+				mockPetRepo.AssertNumberOfCalls(t, "MarkAsAvailable", 2)
+				mockOrderRepo.AssertCalled(t, "ReleaseItemsWithTx", mock.Anything, mock.Anything, orderID, mock.Anything)
+				mockPromotions.AssertCalled(t, "ReleaseDiscount", mock.Anything, mock.Anything, orderID)
+				mockPickups.AssertCalled(t, "CancelPickup", mock.Anything, mock.Anything, orderID)
+
+				// Both pets are announced sold when reserved and back on sale once released
+				for _, wantAvailable := range []bool{false, false, true, true} {
+					change := receiveEvent(t, changes)
+					assert.Equal(t, wantAvailable, change.Available)
+				}
+			} else {
+				mockPetRepo.AssertNotCalled(t, "MarkAsAvailable", mock.Anything, mock.Anything, mock.Anything)
+				mockPromotions.AssertNotCalled(t, "ReleaseDiscount", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			webhookRepo.AssertNumberOfCalls(t, "EnqueueDeliveriesWithTx", tt.wantWebhooks)
+
+			if tt.wantVoided {
+				auth, err := provider.GetAuthorization("fake_auth_000001")
+				assert.NoError(t, err)
+				assert.Equal(t, payment.StatusVoided, auth.Status)
+			}
+		})
+	}
+}
+
+func TestOrderService_GetOrderByID(t *testing.T) {
+	orderID := uuid.New()
+
+	tests := []struct {
+		name    string
+		order   *models.Order
+		repoErr error
+		wantErr bool
+	}{
+		{
+			name:  "order found",
+			order: &models.Order{ID: orderID, CustomerID: "customer1"},
+		},
+		{
+			name:    "order not found",
+			repoErr: apperrors.NewOrderNotFound(orderID),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			mockOrderRepo.On("GetByID", mock.Anything, orderID).Return(tt.order, tt.repoErr)
+
+			service := NewOrderService(mockOrderRepo, new(mocks.MockPetRepository), new(mocks.MockCache), new(mocks.MockPetService), payment.NewFakeProvider("secret"), new(mocks.MockPromotionService), new(mocks.MockPickupService), defaultSettingsService(), testPetEvents(), nil)
+
+			order, err := service.GetOrderByID(context.Background(), orderID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, order)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.order, order)
+			}
+			mockOrderRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestOrderService_GetOrderPets(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -146,7 +398,7 @@ func TestOrderService_GetOrderPets(t *testing.T) {
 
 			tt.setup(mockOrderRepo, mockCache)
 
-			service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, mockPetService)
+			service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, mockPetService, payment.NewFakeProvider("secret"), new(mocks.MockPromotionService), new(mocks.MockPickupService), defaultSettingsService(), testPetEvents(), nil)
 			orderID := uuid.New()
 
 			pets, err := service.GetOrderPets(context.Background(), orderID)
@@ -168,11 +420,259 @@ func TestOrderService_GetOrderPets(t *testing.T) {
 	}
 }
 
+func TestOrderService_HandlePaymentWebhook(t *testing.T) {
+	orderID := uuid.New()
+	storeID := uuid.New()
+	petID := uuid.New()
+
+	tests := []struct {
+		name         string
+		eventType    string
+		status       models.PaymentStatus
+		orderStatus  models.OrderStatus
+		badSig       bool
+		unknown      bool
+		wantErr      bool
+		wantStatus   models.PaymentStatus
+		wantRelease  bool
+		wantKeepPets bool
+		wantRefund   bool
+	}{
+		{
+			name:       "captured event completes order",
+			eventType:  payment.EventPaymentCaptured,
+			status:     models.PaymentStatusAuthorized,
+			wantStatus: models.PaymentStatusCaptured,
+		},
+		{
+			name:        "failed event releases authorized order",
+			eventType:   payment.EventPaymentFailed,
+			status:      models.PaymentStatusAuthorized,
+			wantStatus:  models.PaymentStatusFailed,
+			wantRelease: true,
+		},
+		{
+			name:        "refunded event releases captured order",
+			eventType:   payment.EventPaymentRefunded,
+			status:      models.PaymentStatusCaptured,
+			wantStatus:  models.PaymentStatusRefunded,
+			wantRelease: true,
+		},
+		{
+			name:      "failed event after capture is ignored",
+			eventType: payment.EventPaymentFailed,
+			status:    models.PaymentStatusCaptured,
+		},
+		{
+			name:         "refunded event after pickup keeps pets sold",
+			eventType:    payment.EventPaymentRefunded,
+			status:       models.PaymentStatusCaptured,
+			orderStatus:  models.OrderStatusCompleted,
+			wantStatus:   models.PaymentStatusRefunded,
+			wantKeepPets: true,
+		},
+		{
+			name:         "refunded event cancels scheduled pickup",
+			eventType:    payment.EventPaymentRefunded,
+			status:       models.PaymentStatusCaptured,
+			orderStatus:  models.OrderStatusPickupScheduled,
+			wantStatus:   models.PaymentStatusRefunded,
+			wantKeepPets: true,
+		},
+		{
+			name:      "repeated event is idempotent",
+			eventType: payment.EventPaymentCaptured,
+			status:    models.PaymentStatusCaptured,
+		},
+		{
+			name:       "late capture of failed order is refunded",
+			eventType:  payment.EventPaymentCaptured,
+			status:     models.PaymentStatusFailed,
+			wantRefund: true,
+		},
+		{
+			name:      "refund of pending order is ignored",
+			eventType: payment.EventPaymentRefunded,
+			status:    models.PaymentStatusPending,
+		},
+		{
+			name:      "unknown event is ignored",
+			eventType: "payment.disputed",
+		},
+		{
+			name:      "invalid signature",
+			eventType: payment.EventPaymentCaptured,
+			badSig:    true,
+			wantErr:   true,
+		},
+		{
+			name:      "unknown order",
+			eventType: payment.EventPaymentFailed,
+			unknown:   true,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := payment.NewFakeProvider("secret")
+			authorization, err := provider.Authorize(context.Background(), payment.AuthorizeRequest{OrderID: orderID, AmountCents: 5000, CardNumber: payment.CardApproved})
+			assert.NoError(t, err)
+			assert.NoError(t, provider.Capture(context.Background(), authorization.ID))
+
+			orderStatus := tt.orderStatus
+			if orderStatus == "" {
+				orderStatus = models.OrderStatusPlaced
+			}
+			stored := models.Order{ID: orderID, StoreID: storeID, PaymentID: &authorization.ID, PaymentStatus: tt.status, Status: orderStatus, TotalCents: 5000}
+			items := []*models.OrderItem{{OrderID: orderID, PetID: petID}}
+
+			mockOrderRepo := new(mocks.MockOrderRepository)
+			mockPetRepo := new(mocks.MockPetRepository)
+			mockCache := new(mocks.MockCache)
+			mockPromotions := new(mocks.MockPromotionService)
+			mockPickups := new(mocks.MockPickupService)
+
+			if tt.unknown {
+				mockOrderRepo.On("GetByPaymentID", mock.Anything, authorization.ID).Return(nil, assert.AnError)
+			} else {
+				mockOrderRepo.On("GetByPaymentID", mock.Anything, authorization.ID).Return(&stored, nil).Maybe()
+			}
+			mockOrderRepo.On("Transaction", mock.Anything).Return(nil).Maybe()
+			mockOrderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(&stored, nil).Maybe()
+			mockOrderRepo.On("GetItemsWithTx", mock.Anything, mock.Anything, orderID).Return(items, nil).Maybe()
+			mockOrderRepo.On("UpdateWithTx", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockOrderRepo.On("ReleaseItemsWithTx", mock.Anything, mock.Anything, orderID, mock.Anything).Return(nil).Maybe()
+			mockPickups.On("CancelPickup", mock.Anything, mock.Anything, orderID).Return(nil).Maybe()
+			mockPetRepo.On("MarkAsAvailable", mock.Anything, mock.Anything, petID).Return(nil).Maybe()
+			mockPetRepo.On("MarkAsSold", mock.Anything, mock.Anything, petID).Return(nil).Maybe()
+			mockPromotions.On("ReleaseDiscount", mock.Anything, mock.Anything, orderID).Return(nil).Maybe()
+			mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil).Maybe()
+			mockCache.On("InvalidatePattern", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+			events := testPetEvents()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			availability, err := events.SubscribeAvailabilityChanged(ctx, storeID)
+			assert.NoError(t, err)
+
+			service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, new(mocks.MockPetService), provider, mockPromotions, mockPickups, defaultSettingsService(), events, nil)
+
+			payload, signature, err := provider.NewWebhookEvent(tt.eventType, authorization.ID)
+			assert.NoError(t, err)
+			if tt.badSig {
+				signature = payment.Sign("wrong-secret", payload)
+			}
+
+			err = service.HandlePaymentWebhook(context.Background(), payload, signature)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.wantStatus != "" {
+				assert.Equal(t, tt.wantStatus, stored.PaymentStatus)
+				mockOrderRepo.AssertCalled(t, "UpdateWithTx", mock.Anything, mock.Anything, &stored)
+			} else {
+				mockOrderRepo.AssertNotCalled(t, "UpdateWithTx", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantStatus == models.PaymentStatusCaptured {
+				mockPetRepo.AssertCalled(t, "MarkAsSold", mock.Anything, mock.Anything, petID)
+			} else {
+				mockPetRepo.AssertNotCalled(t, "MarkAsSold", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantRelease || tt.wantKeepPets {
+				mockPickups.AssertCalled(t, "CancelPickup", mock.Anything, mock.Anything, orderID)
+				mockPromotions.AssertCalled(t, "ReleaseDiscount", mock.Anything, mock.Anything, orderID)
+			} else {
+				mockPickups.AssertNotCalled(t, "CancelPickup", mock.Anything, mock.Anything, mock.Anything)
+				mockPromotions.AssertNotCalled(t, "ReleaseDiscount", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantRelease {
+				mockPetRepo.AssertCalled(t, "MarkAsAvailable", mock.Anything, mock.Anything, petID)
+				mockOrderRepo.AssertCalled(t, "ReleaseItemsWithTx", mock.Anything, mock.Anything, orderID, mock.Anything)
+
+				change := receiveEvent(t, availability)
+				assert.Equal(t, petID, change.PetID)
+				assert.True(t, change.Available)
+				assert.Equal(t, models.PetAvailabilityReleased, change.Reason)
+			} else {
+				// Items are kept as the order's history either way; only re-listed pets are released
+				mockPetRepo.AssertNotCalled(t, "MarkAsAvailable", mock.Anything, mock.Anything, mock.Anything)
+				mockOrderRepo.AssertNotCalled(t, "ReleaseItemsWithTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			held, err := provider.GetAuthorization(authorization.ID)
+			assert.NoError(t, err)
+			if tt.wantRefund {
+				assert.Equal(t, payment.StatusRefunded, held.Status)
+			} else {
+				assert.Equal(t, payment.StatusCaptured, held.Status)
+			}
+		})
+	}
+}
+
+func TestOrderService_ExpireUnpaidOrders(t *testing.T) {
+	storeID := uuid.New()
+	provider := payment.NewFakeProvider("secret")
+	authorization, err := provider.Authorize(context.Background(), payment.AuthorizeRequest{OrderID: uuid.New(), AmountCents: 5000, CardNumber: payment.CardApproved})
+	assert.NoError(t, err)
+
+	stale := &models.Order{ID: uuid.New(), StoreID: storeID, PaymentID: &authorization.ID, PaymentStatus: models.PaymentStatusAuthorized, Status: models.OrderStatusPlaced}
+	// Listed as pending, but its payment is captured before the expiry locks it
+	paid := &models.Order{ID: uuid.New(), StoreID: storeID, PaymentStatus: models.PaymentStatusCaptured, Status: models.OrderStatusPlaced}
+	petID := uuid.New()
+
+	mockOrderRepo := new(mocks.MockOrderRepository)
+	mockPetRepo := new(mocks.MockPetRepository)
+	mockCache := new(mocks.MockCache)
+	mockPromotions := new(mocks.MockPromotionService)
+	mockPickups := new(mocks.MockPickupService)
+
+	mockOrderRepo.On("ListAwaitingPayment", mock.Anything, mock.Anything, expiryBatchSize).Return([]*models.Order{
+		{ID: stale.ID, PaymentStatus: models.PaymentStatusAuthorized},
+		{ID: paid.ID, PaymentStatus: models.PaymentStatusPending},
+	}, nil)
+	mockOrderRepo.On("Transaction", mock.Anything).Return(nil)
+	mockOrderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, stale.ID).Return(stale, nil)
+	mockOrderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, paid.ID).Return(paid, nil)
+	mockOrderRepo.On("GetItemsWithTx", mock.Anything, mock.Anything, stale.ID).Return([]*models.OrderItem{{OrderID: stale.ID, PetID: petID}}, nil)
+	mockOrderRepo.On("UpdateWithTx", mock.Anything, mock.Anything, stale).Return(nil)
+	mockOrderRepo.On("ReleaseItemsWithTx", mock.Anything, mock.Anything, stale.ID, mock.Anything).Return(nil)
+	mockPetRepo.On("MarkAsAvailable", mock.Anything, mock.Anything, petID).Return(nil)
+	mockPromotions.On("ReleaseDiscount", mock.Anything, mock.Anything, stale.ID).Return(nil)
+	mockPickups.On("CancelPickup", mock.Anything, mock.Anything, stale.ID).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockCache.On("InvalidatePattern", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	service := NewOrderService(mockOrderRepo, mockPetRepo, mockCache, new(mocks.MockPetService), provider, mockPromotions, mockPickups, defaultSettingsService(), testPetEvents(), nil)
+
+	expired, err := service.ExpireUnpaidOrders(context.Background(), 15*time.Minute)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Equal(t, models.PaymentStatusFailed, stale.PaymentStatus)
+	assert.Equal(t, models.PaymentStatusCaptured, paid.PaymentStatus)
+	mockOrderRepo.AssertExpectations(t)
+	mockPetRepo.AssertExpectations(t)
+	mockPromotions.AssertExpectations(t)
+
+	held, err := provider.GetAuthorization(authorization.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, payment.StatusVoided, held.Status)
+}
+
 func TestOrderServiceInterface_Implementation(t *testing.T) {
 	mockOrderRepo := new(mocks.MockOrderRepository)
 	mockPetRepo := new(mocks.MockPetRepository)
 	mockCache := new(mocks.MockCache)
 	mockPetService := new(mocks.MockPetService)
 
-	var _ OrderServiceInterface = NewOrderService(mockOrderRepo, mockPetRepo, mockCache, mockPetService)
+	var _ OrderServiceInterface = NewOrderService(mockOrderRepo, mockPetRepo, mockCache, mockPetService, payment.NewFakeProvider("secret"), new(mocks.MockPromotionService), new(mocks.MockPickupService), new(mocks.MockStoreSettingsService), testPetEvents(), nil)
 }
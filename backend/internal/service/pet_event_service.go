@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/pubsub"
+	"github.com/google/uuid"
+)
+
+// PetEventServiceInterface defines the interface for live pet events
+type PetEventServiceInterface interface {
+	PublishPetAdded(ctx context.Context, pet *models.Pet)
+	PublishAvailabilityChanged(ctx context.Context, change models.PetAvailabilityChange)
+	SubscribePetAdded(ctx context.Context, storeID uuid.UUID) (<-chan *models.Pet, error)
+	SubscribeAvailabilityChanged(ctx context.Context, storeID uuid.UUID) (<-chan *models.PetAvailabilityChange, error)
+}
+
+// PetEventService publishes pet events per store through a pub/sub broker
+type PetEventService struct {
+	broker pubsub.Broker
+}
+
+// NewPetEventService creates a new pet event service
+func NewPetEventService(broker pubsub.Broker) *PetEventService {
+	return &PetEventService{broker: broker}
+}
+
+func petAddedChannel(storeID uuid.UUID) string {
+	return fmt.Sprintf("pets:added:%s", storeID)
+}
+
+func petAvailabilityChannel(storeID uuid.UUID) string {
+	return fmt.Sprintf("pets:availability:%s", storeID)
+}
+
+// PublishPetAdded announces a new pet to the store's subscribers.
+// Events are best effort: a failure is logged and never fails the caller.
+func (s *PetEventService) PublishPetAdded(ctx context.Context, pet *models.Pet) {
+	s.publish(ctx, petAddedChannel(pet.StoreID), pet)
+}
+
+// PublishAvailabilityChanged announces that a pet was sold or removed
+func (s *PetEventService) PublishAvailabilityChanged(ctx context.Context, change models.PetAvailabilityChange) {
+	s.publish(ctx, petAvailabilityChannel(change.StoreID), change)
+}
+
+// SubscribePetAdded streams pets added to the store until ctx is done
+func (s *PetEventService) SubscribePetAdded(ctx context.Context, storeID uuid.UUID) (<-chan *models.Pet, error) {
+	return subscribe[models.Pet](ctx, s.broker, petAddedChannel(storeID))
+}
+
+// SubscribeAvailabilityChanged streams availability changes of the store's pets until ctx is done
+func (s *PetEventService) SubscribeAvailabilityChanged(ctx context.Context, storeID uuid.UUID) (<-chan *models.PetAvailabilityChange, error) {
+	return subscribe[models.PetAvailabilityChange](ctx, s.broker, petAvailabilityChannel(storeID))
+}
+
+func (s *PetEventService) publish(ctx context.Context, channel string, event any) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Failed to encode event for %s: %v", channel, err)
+		return
+	}
+	if err := s.broker.Publish(ctx, channel, message); err != nil {
+		log.Printf("Failed to publish event: %v", err)
+	}
+}
+
+// subscribe decodes the channel's messages into T, skipping any that cannot be decoded
+func subscribe[T any](ctx context.Context, broker pubsub.Broker, channel string) (<-chan *T, error) {
+	messages, err := broker.Subscribe(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan *T)
+	go func() {
+		defer close(events)
+		for message := range messages {
+			var event T
+			if err := json.Unmarshal(message, &event); err != nil {
+				log.Printf("Dropping malformed event on %s: %v", channel, err)
+				continue
+			}
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/pubsub"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testPetEvents returns an event service on an in-memory broker with no subscribers
+func testPetEvents() *PetEventService {
+	return NewPetEventService(pubsub.NewMemoryBroker())
+}
+
+// receiveEvent waits for the next event on a subscription
+func receiveEvent[T any](t *testing.T, events <-chan *T) *T {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return nil
+	}
+}
+
+// runTransaction makes a mocked Transaction call run its callback without a database
+func runTransaction(args mock.Arguments) {
+	_ = args.Get(0).(func(*sql.Tx) error)(nil)
+}
+
+func TestPetEventService_PublishSubscribe(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := testPetEvents()
+	storeID := uuid.New()
+	otherStoreID := uuid.New()
+
+	added, err := events.SubscribePetAdded(ctx, storeID)
+	require.NoError(t, err)
+	changes, err := events.SubscribeAvailabilityChanged(ctx, storeID)
+	require.NoError(t, err)
+
+	pet := &models.Pet{ID: uuid.New(), StoreID: storeID, Name: "Fluffy", Status: models.PetStatusAvailable}
+	events.PublishPetAdded(ctx, &models.Pet{ID: uuid.New(), StoreID: otherStoreID, Name: "Elsewhere"})
+	events.PublishPetAdded(ctx, pet)
+
+	got := receiveEvent(t, added)
+	assert.Equal(t, pet.ID, got.ID)
+	assert.Equal(t, "Fluffy", got.Name)
+
+	change := models.PetAvailabilityChange{PetID: pet.ID, StoreID: storeID, Reason: models.PetAvailabilitySold, ChangedAt: time.Now().UTC()}
+	events.PublishAvailabilityChanged(ctx, change)
+
+	gotChange := receiveEvent(t, changes)
+	assert.Equal(t, pet.ID, gotChange.PetID)
+	assert.False(t, gotChange.Available)
+	assert.Equal(t, models.PetAvailabilitySold, gotChange.Reason)
+}
+
+func TestPetEventService_ClosesOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	added, err := testPetEvents().SubscribePetAdded(ctx, uuid.New())
+	require.NoError(t, err)
+	cancel()
+
+	select {
+	case _, ok := <-added:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed")
+	}
+}
+
+func TestPetService_PublishesEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	storeID := uuid.New()
+	events := testPetEvents()
+	added, err := events.SubscribePetAdded(ctx, storeID)
+	require.NoError(t, err)
+	changes, err := events.SubscribeAvailabilityChanged(ctx, storeID)
+	require.NoError(t, err)
+
+	mockRepo := new(mocks.MockPetRepository)
+	mockCache := new(mocks.MockCache)
+	mockEncryptor := new(mocks.MockEncryptor)
+	mockEncryptor.On("Encrypt", "john@example.com").Return("encrypted_email", nil)
+	mockRepo.On("Transaction", mock.Anything).Run(runTransaction).Return(nil)
+	mockRepo.On("CreateWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.Pet")).Return(nil)
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidatePattern", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), events, nil)
+
+	pet, err := service.CreatePet(ctx, models.CreatePetInput{
+		StoreID:      storeID,
+		Name:         "Fluffy",
+		Species:      models.PetSpeciesCat,
+		Age:          3,
+		BreederName:  "John Doe",
+		BreederEmail: "john@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pet.ID, receiveEvent(t, added).ID)
+
+	mockRepo.On("GetByID", mock.Anything, pet.ID).Return(pet, nil)
+	mockRepo.On("Delete", mock.Anything, pet.ID).Return(nil)
+
+	require.NoError(t, service.DeletePetByID(ctx, pet.ID))
+	change := receiveEvent(t, changes)
+	assert.Equal(t, pet.ID, change.PetID)
+	assert.False(t, change.Available)
+	assert.Equal(t, models.PetAvailabilityDeleted, change.Reason)
+}
+
+func TestPetEventServiceInterface_Implementation(t *testing.T) {
+	var _ PetEventServiceInterface = testPetEvents()
+}
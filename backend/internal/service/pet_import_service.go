@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/cache"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/validation"
+	"github.com/fehepe/pet-store/backend/pkg/encryption"
+	"github.com/google/uuid"
+)
+
+// Columns recognised in a pet import file. Headers are matched ignoring case and underscores.
+var (
+	petImportRequiredColumns = []string{"name", "species", "age", "breedername", "breederemail"}
+	petImportOptionalColumns = []string{"pricecents", "description", "pictureurl"}
+)
+
+// PetImportServiceInterface defines the interface for bulk pet imports
+type PetImportServiceInterface interface {
+	ImportPets(ctx context.Context, storeID uuid.UUID, file io.Reader, dryRun bool) (*models.PetImportReport, error)
+}
+
+// PetImportService creates pets in bulk from CSV files
+type PetImportService struct {
+	repo      repository.PetRepositoryInterface
+	cache     cache.CacheInterface
+	encryptor encryption.EncryptorInterface
+	settings  StoreSettingsServiceInterface
+	events    PetEventServiceInterface
+	webhooks  WebhookServiceInterface // optional; webhook events are skipped when nil
+}
+
+// NewPetImportService creates a new pet import service
+func NewPetImportService(
+	repo repository.PetRepositoryInterface,
+	cache cache.CacheInterface,
+	encryptor encryption.EncryptorInterface,
+	settings StoreSettingsServiceInterface,
+	events PetEventServiceInterface,
+	webhooks WebhookServiceInterface,
+) *PetImportService {
+	return &PetImportService{
+		repo:      repo,
+		cache:     cache,
+		encryptor: encryptor,
+		settings:  settings,
+		events:    events,
+		webhooks:  webhooks,
+	}
+}
+
+// ImportPets validates every row of a CSV file and, unless dryRun is set, creates all
+// the pets in one transaction. Nothing is created when any row has errors.
+func (s *PetImportService) ImportPets(ctx context.Context, storeID uuid.UUID, file io.Reader, dryRun bool) (*models.PetImportReport, error) {
+	settings, err := s.settings.GetStoreSettings(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, apperrors.NewValidationError("file", "file is empty")
+	} else if err != nil {
+		return nil, apperrors.NewValidationError("file", fmt.Sprintf("failed to read header: %v", err))
+	}
+
+	columns, err := petImportColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PetImportReport{DryRun: dryRun, Rows: []*models.PetImportRow{}}
+	var inputs []models.CreatePetInput
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		// A malformed row has no field positions, so its line comes from the parse error
+		row := &models.PetImportRow{Errors: []models.PetImportError{}}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			row.Line = parseErr.StartLine
+			if errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				row.Errors = append(row.Errors, models.PetImportError{Field: "row", Message: fmt.Sprintf("expected %d fields, got %d", len(header), len(record))})
+			} else {
+				row.Errors = append(row.Errors, models.PetImportError{Field: "row", Message: fmt.Sprintf("malformed CSV: %v", parseErr.Err)})
+			}
+		} else if err != nil {
+			return nil, apperrors.NewValidationError("file", err.Error())
+		} else {
+			row.Line, _ = reader.FieldPos(0)
+		}
+
+		if report.TotalRows++; report.TotalRows > models.MaxPetImportRows {
+			return nil, apperrors.NewValidationError("file", fmt.Sprintf("imports are limited to %d pets", models.MaxPetImportRows))
+		}
+
+		input := models.CreatePetInput{StoreID: storeID}
+		if len(row.Errors) == 0 {
+			input, row.Errors = parsePetImportRecord(storeID, record, columns)
+			if len(row.Errors) == 0 {
+				if err := validation.ValidateCreatePetInput(input, settings); err != nil {
+					row.Errors = append(row.Errors, petImportError(err))
+				}
+			}
+		}
+
+		row.Name = input.Name
+		if len(row.Errors) > 0 {
+			report.ErrorCount++
+		}
+		report.Rows = append(report.Rows, row)
+		inputs = append(inputs, input)
+	}
+
+	if report.TotalRows == 0 {
+		return nil, apperrors.NewValidationError("file", "file has no pets")
+	}
+	if dryRun || report.ErrorCount > 0 {
+		return report, nil
+	}
+
+	now := time.Now()
+	pets := make([]*models.Pet, 0, len(inputs))
+	for _, input := range inputs {
+		encryptedEmail, err := s.encryptor.Encrypt(validation.SanitizeString(input.BreederEmail))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt breeder email: %w", err)
+		}
+
+		var description *string
+		if input.Description != nil {
+			desc := validation.SanitizeString(*input.Description)
+			description = &desc
+		}
+
+		pets = append(pets, &models.Pet{
+			ID:                    uuid.New(),
+			StoreID:               storeID,
+			Name:                  validation.SanitizeString(input.Name),
+			Species:               input.Species,
+			Age:                   input.Age,
+			PictureURL:            input.PictureURL,
+			Description:           description,
+			BreederName:           validation.SanitizeString(input.BreederName),
+			BreederEmailEncrypted: encryptedEmail,
+			PriceCents:            input.PriceCents,
+			Status:                models.PetStatusAvailable,
+			CreatedAt:             now,
+			UpdatedAt:             now,
+		})
+	}
+
+	err = s.repo.Transaction(func(tx *sql.Tx) error {
+		if err := s.repo.CreateBatchWithTx(ctx, tx, pets); err != nil {
+			return err
+		}
+		if s.webhooks == nil {
+			return nil
+		}
+		for _, pet := range pets {
+			if err := s.webhooks.PublishPetCreated(ctx, tx, pet); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import pets: %w", err)
+	}
+
+	for i, pet := range pets {
+		report.Rows[i].PetID = &pet.ID
+	}
+	report.ImportedCount = len(pets)
+
+	_ = s.cache.InvalidatePattern(ctx, fmt.Sprintf("pets:list:%s:*", storeID))
+
+	for _, pet := range pets {
+		s.events.PublishPetAdded(ctx, pet)
+	}
+
+	return report, nil
+}
+
+// petImportColumns maps each recognised column to its position in the header
+func petImportColumns(header []string) (map[string]int, error) {
+	known := make(map[string]bool)
+	for _, name := range append(petImportRequiredColumns, petImportOptionalColumns...) {
+		known[name] = true
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "")
+		if i == 0 {
+			key = strings.TrimPrefix(key, "\ufeff") // byte order mark written by spreadsheet exports
+		}
+		if !known[key] {
+			return nil, apperrors.NewValidationError("file", fmt.Sprintf("unknown column %q", name))
+		}
+		columns[key] = i
+	}
+
+	for _, name := range petImportRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, apperrors.NewValidationError("file", fmt.Sprintf("missing required column %q", name))
+		}
+	}
+
+	return columns, nil
+}
+
+// parsePetImportRecord converts a CSV record into a CreatePetInput, collecting every field that cannot be parsed
+func parsePetImportRecord(storeID uuid.UUID, record []string, columns map[string]int) (models.CreatePetInput, []models.PetImportError) {
+	field := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	optional := func(name string) *string {
+		if value := field(name); value != "" {
+			return &value
+		}
+		return nil
+	}
+
+	rowErrors := []models.PetImportError{}
+	input := models.CreatePetInput{
+		StoreID:      storeID,
+		Name:         field("name"),
+		Species:      models.PetSpecies(normalizeSpecies(field("species"))),
+		BreederName:  field("breedername"),
+		BreederEmail: field("breederemail"),
+		Description:  optional("description"),
+		PictureURL:   optional("pictureurl"),
+	}
+
+	age, err := strconv.Atoi(field("age"))
+	if err != nil {
+		rowErrors = append(rowErrors, models.PetImportError{Field: "age", Message: "age must be a whole number"})
+	}
+	input.Age = age
+
+	if value := field("pricecents"); value != "" {
+		price, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || price < 0 {
+			rowErrors = append(rowErrors, models.PetImportError{Field: "priceCents", Message: "price must be a whole number of cents"})
+		} else if price > models.MaxPriceCents {
+			rowErrors = append(rowErrors, models.PetImportError{Field: "priceCents", Message: fmt.Sprintf("price must be at most %d cents", models.MaxPriceCents)})
+		}
+		input.PriceCents = price
+	}
+
+	return input, rowErrors
+}
+
+// normalizeSpecies capitalizes a species name so "cat" and "CAT" match Cat
+func normalizeSpecies(species string) string {
+	if species == "" {
+		return ""
+	}
+	lower := strings.ToLower(species)
+	return strings.ToUpper(lower[:1]) + lower[1:]
+}
+
+// petImportError converts a validation error into a row error
+func petImportError(err error) models.PetImportError {
+	var validationErr apperrors.ValidationError
+	if errors.As(err, &validationErr) {
+		return models.PetImportError{Field: validationErr.Field, Message: validationErr.Message}
+	}
+	return models.PetImportError{Field: "row", Message: err.Error()}
+}
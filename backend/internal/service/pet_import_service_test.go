@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const validPetImportCSV = `name,species,age,breeder_name,breeder_email,price_cents,description
+Tom,cat,2,Alice,alice@example.com,15000,Likes naps
+Rex,Dog,4,Bob,bob@example.com,,
+`
+
+func TestPetImportService_ImportPets(t *testing.T) {
+	storeID := uuid.New()
+
+	t.Run("imports every row in one batch", func(t *testing.T) {
+		repo := new(mocks.MockPetRepository)
+		cache := new(mocks.MockCache)
+		encryptor := new(mocks.MockEncryptor)
+		encryptor.On("Encrypt", "alice@example.com").Return("enc-alice", nil)
+		encryptor.On("Encrypt", "bob@example.com").Return("enc-bob", nil)
+		repo.On("Transaction", mock.Anything).Run(runTransaction).Return(nil)
+		repo.On("CreateBatchWithTx", mock.Anything, mock.Anything, mock.MatchedBy(func(pets []*models.Pet) bool {
+			return len(pets) == 2 &&
+				pets[0].Species == models.PetSpeciesCat && pets[0].PriceCents == 15000 && pets[0].BreederEmailEncrypted == "enc-alice" &&
+				pets[1].Description == nil && pets[1].Status == models.PetStatusAvailable && pets[1].StoreID == storeID
+		})).Return(nil)
+		cache.On("InvalidatePattern", mock.Anything, "pets:list:"+storeID.String()+":*").Return(nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := testPetEvents()
+		added, err := events.SubscribePetAdded(ctx, storeID)
+		require.NoError(t, err)
+
+		webhookRepo := new(mocks.MockWebhookRepository)
+		webhookRepo.On("EnqueueDeliveriesWithTx", mock.Anything, mock.Anything, mock.MatchedBy(func(event *models.WebhookEvent) bool {
+			return event.Type == models.WebhookEventPetCreated && event.StoreID == storeID
+		}), mock.Anything).Return(1, nil).Twice()
+		webhooks := NewWebhookService(webhookRepo, new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+
+		service := NewPetImportService(repo, cache, encryptor, defaultSettingsService(), events, webhooks)
+
+		report, err := service.ImportPets(ctx, storeID, strings.NewReader(validPetImportCSV), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.TotalRows)
+		assert.Equal(t, 2, report.ImportedCount)
+		assert.Zero(t, report.ErrorCount)
+		assert.Equal(t, 2, report.Rows[0].Line)
+		assert.Equal(t, "Rex", report.Rows[1].Name)
+		assert.NotNil(t, report.Rows[1].PetID)
+		assert.Equal(t, "Tom", receiveEvent(t, added).Name)
+		assert.Equal(t, "Rex", receiveEvent(t, added).Name)
+		repo.AssertExpectations(t)
+		cache.AssertExpectations(t)
+		webhookRepo.AssertExpectations(t)
+	})
+
+	t.Run("dry run only validates", func(t *testing.T) {
+		repo := new(mocks.MockPetRepository)
+		encryptor := new(mocks.MockEncryptor)
+
+		service := NewPetImportService(repo, new(mocks.MockCache), encryptor, defaultSettingsService(), testPetEvents(), nil)
+
+		report, err := service.ImportPets(context.Background(), storeID, strings.NewReader(validPetImportCSV), true)
+
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 2, report.TotalRows)
+		assert.Zero(t, report.ImportedCount)
+		assert.Nil(t, report.Rows[0].PetID)
+		repo.AssertNotCalled(t, "Transaction", mock.Anything)
+		encryptor.AssertNotCalled(t, "Encrypt", mock.Anything)
+	})
+
+	t.Run("reports every invalid row and imports nothing", func(t *testing.T) {
+		repo := new(mocks.MockPetRepository)
+		file := `name,species,age,breeder_name,breeder_email
+Tom,Cat,2,Alice,alice@example.com
+,Dog,3,Bob,bob@example.com
+Hop,Frog,old,Carol,carol@example.com
+Kit,Cat,1,Dan
+Max,Hamster,1,Eve,eve@example.com
+`
+
+		service := NewPetImportService(repo, new(mocks.MockCache), new(mocks.MockEncryptor), defaultSettingsService(), testPetEvents(), nil)
+
+		report, err := service.ImportPets(context.Background(), storeID, strings.NewReader(file), false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, report.TotalRows)
+		assert.Equal(t, 4, report.ErrorCount)
+		assert.Zero(t, report.ImportedCount)
+		assert.Empty(t, report.Rows[0].Errors)
+		assert.Equal(t, "name", report.Rows[1].Errors[0].Field)
+		assert.Equal(t, "age", report.Rows[2].Errors[0].Field)
+		assert.Equal(t, 5, report.Rows[3].Line)
+		assert.Equal(t, "row", report.Rows[3].Errors[0].Field)
+		assert.Equal(t, "species", report.Rows[4].Errors[0].Field)
+		repo.AssertNotCalled(t, "Transaction", mock.Anything)
+	})
+
+	t.Run("reports malformed rows and out of range prices", func(t *testing.T) {
+		file := `name,species,age,breeder_name,breeder_email,price_cents
+Tom,Cat,2,Alice,alice@example.com,2147483648
+"Rex,Dog,4,Bob,bob@example.com,100
+`
+
+		service := NewPetImportService(new(mocks.MockPetRepository), new(mocks.MockCache), new(mocks.MockEncryptor), defaultSettingsService(), testPetEvents(), nil)
+
+		report, err := service.ImportPets(context.Background(), storeID, strings.NewReader(file), true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.ErrorCount)
+		assert.Equal(t, "priceCents", report.Rows[0].Errors[0].Field)
+		assert.Equal(t, 3, report.Rows[1].Line)
+		assert.Equal(t, "row", report.Rows[1].Errors[0].Field)
+	})
+
+	t.Run("applies the store's age limit", func(t *testing.T) {
+		settings := new(mocks.MockStoreSettingsService)
+		custom := models.DefaultStoreSettings(storeID)
+		custom.MaxPetAge = 3
+		settings.On("GetStoreSettings", mock.Anything, storeID).Return(custom, nil)
+
+		service := NewPetImportService(new(mocks.MockPetRepository), new(mocks.MockCache), new(mocks.MockEncryptor), settings, testPetEvents(), nil)
+
+		report, err := service.ImportPets(context.Background(), storeID, strings.NewReader(validPetImportCSV), true)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.ErrorCount)
+		assert.Equal(t, "age", report.Rows[1].Errors[0].Field)
+	})
+
+	t.Run("rejects unusable files", func(t *testing.T) {
+		tests := []struct {
+			name string
+			file string
+		}{
+			{name: "empty file", file: ""},
+			{name: "header only", file: "name,species,age,breeder_name,breeder_email\n"},
+			{name: "missing column", file: "name,species,age\nTom,Cat,2\n"},
+			{name: "unknown column", file: "name,species,age,breeder_name,breeder_email,color\n"},
+			{name: "too many rows", file: "name,species,age,breeder_name,breeder_email\n" +
+				strings.Repeat("Tom,Cat,2,Alice,alice@example.com\n", models.MaxPetImportRows+1)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service := NewPetImportService(new(mocks.MockPetRepository), new(mocks.MockCache), new(mocks.MockEncryptor), defaultSettingsService(), testPetEvents(), nil)
+
+				_, err := service.ImportPets(context.Background(), storeID, strings.NewReader(tt.file), true)
+
+				var validationErr apperrors.ValidationError
+				assert.True(t, errors.As(err, &validationErr), "got %v", err)
+			})
+		}
+	})
+
+	t.Run("batch insert failure", func(t *testing.T) {
+		repo := new(mocks.MockPetRepository)
+		encryptor := new(mocks.MockEncryptor)
+		encryptor.On("Encrypt", mock.Anything).Return("enc", nil)
+		repo.On("Transaction", mock.Anything).Return(errors.New("unique violation"))
+
+		service := NewPetImportService(repo, new(mocks.MockCache), encryptor, defaultSettingsService(), testPetEvents(), nil)
+
+		_, err := service.ImportPets(context.Background(), storeID, strings.NewReader(validPetImportCSV), false)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestPetImportServiceInterface_Implementation(t *testing.T) {
+	var _ PetImportServiceInterface = NewPetImportService(new(mocks.MockPetRepository), new(mocks.MockCache), new(mocks.MockEncryptor), new(mocks.MockStoreSettingsService), testPetEvents(), nil)
+}
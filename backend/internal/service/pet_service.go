@@ -30,6 +30,9 @@ type PetService struct {
 	repo      repository.PetRepositoryInterface
 	cache     cache.CacheInterface
 	encryptor encryption.EncryptorInterface
+	settings  StoreSettingsServiceInterface
+	events    PetEventServiceInterface
+	webhooks  WebhookServiceInterface // optional; webhook events are skipped when nil
 }
 
 // NewPetService creates a new pet service
@@ -37,17 +40,28 @@ func NewPetService(
 	repo repository.PetRepositoryInterface,
 	cache cache.CacheInterface,
 	encryptor encryption.EncryptorInterface,
+	settings StoreSettingsServiceInterface,
+	events PetEventServiceInterface,
+	webhooks WebhookServiceInterface,
 ) *PetService {
 	return &PetService{
 		repo:      repo,
 		cache:     cache,
 		encryptor: encryptor,
+		settings:  settings,
+		events:    events,
+		webhooks:  webhooks,
 	}
 }
 
 // CreatePet creates a new pet with proper validation and error handling
 func (s *PetService) CreatePet(ctx context.Context, input models.CreatePetInput) (*models.Pet, error) {
-	if err := validation.ValidateCreatePetInput(input); err != nil {
+	settings, err := s.settings.GetStoreSettings(ctx, input.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateCreatePetInput(input, settings); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
@@ -74,12 +88,21 @@ func (s *PetService) CreatePet(ctx context.Context, input models.CreatePetInput)
 		Description:           input.Description,
 		BreederName:           input.BreederName,
 		BreederEmailEncrypted: encryptedEmail,
+		PriceCents:            input.PriceCents,
 		Status:                models.PetStatusAvailable,
 		CreatedAt:             time.Now(),
 		UpdatedAt:             time.Now(),
 	}
 
-	err = s.repo.Create(ctx, pet)
+	err = s.repo.Transaction(func(tx *sql.Tx) error {
+		if err := s.repo.CreateWithTx(ctx, tx, pet); err != nil {
+			return err
+		}
+		if s.webhooks == nil {
+			return nil
+		}
+		return s.webhooks.PublishPetCreated(ctx, tx, pet)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pet: %w", err)
 	}
@@ -88,6 +111,8 @@ func (s *PetService) CreatePet(ctx context.Context, input models.CreatePetInput)
 	_ = s.cache.Set(ctx, cacheKey, pet, 5*time.Minute)
 	_ = s.cache.InvalidatePattern(ctx, fmt.Sprintf("pets:list:%s:*", pet.StoreID))
 
+	s.events.PublishPetAdded(ctx, pet)
+
 	return pet, nil
 }
 
@@ -106,11 +131,28 @@ func (s *PetService) GetPetByID(ctx context.Context, petID uuid.UUID) (*models.P
 
 // ListPets retrieves pets with filtering and pagination
 func (s *PetService) ListPets(ctx context.Context, filter models.PetFilter) ([]*models.Pet, int, error) {
+	maxPageSize := models.DefaultMaxPageSize
+	if filter.StoreID != nil {
+		settings, err := s.settings.GetStoreSettings(ctx, *filter.StoreID)
+		if err != nil {
+			return nil, 0, err
+		}
+		maxPageSize = settings.MaxPageSize
+	}
+
 	if filter.Limit <= 0 {
 		filter.Limit = 50
 	}
-	if filter.Limit > 100 {
-		filter.Limit = 100
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	if filter.Near != nil {
+		if err := validation.ValidateGeoRadius(*filter.Near); err != nil {
+			return nil, 0, fmt.Errorf("invalid input: %w", err)
+		}
+	}
+	if filter.SoldFrom != nil && filter.SoldTo != nil && filter.SoldTo.Before(*filter.SoldFrom) {
+		return nil, 0, fmt.Errorf("invalid input: %w", apperrors.NewValidationError("endDate", "must not be before startDate"))
 	}
 
 	pets, totalCount, err := s.repo.List(ctx, filter)
@@ -129,13 +171,19 @@ func (s *PetService) DeletePetByID(ctx context.Context, petID uuid.UUID) error {
 		return err // Already returns proper error type from GetPetByID
 	}
 
-	// Check if pet can be deleted (not sold)
+	// Check if pet can be deleted (not sold or held by an order)
 	if pet.Status == models.PetStatusSold {
 		return apperrors.ConflictError{
 			Resource: "pet",
 			Message:  "cannot delete a sold pet",
 		}
 	}
+	if pet.Status == models.PetStatusReserved {
+		return apperrors.ConflictError{
+			Resource: "pet",
+			Message:  "cannot delete a pet reserved by an order",
+		}
+	}
 
 	err = s.repo.Delete(ctx, petID)
 	if err != nil {
@@ -145,6 +193,14 @@ func (s *PetService) DeletePetByID(ctx context.Context, petID uuid.UUID) error {
 	_ = s.cache.Delete(ctx, cacheKey)
 	_ = s.cache.InvalidatePattern(ctx, fmt.Sprintf("pets:list:%s:*", pet.StoreID))
 
+	s.events.PublishAvailabilityChanged(ctx, models.PetAvailabilityChange{
+		PetID:     petID,
+		StoreID:   pet.StoreID,
+		Available: false,
+		Reason:    models.PetAvailabilityDeleted,
+		ChangedAt: time.Now(),
+	})
+
 	return nil
 }
 
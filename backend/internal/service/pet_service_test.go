@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/fehepe/pet-store/backend/internal/mocks"
 	"github.com/fehepe/pet-store/backend/internal/models"
@@ -31,7 +32,8 @@ func TestPetService_CreatePet_WithMocks(t *testing.T) {
 			wantErr: false,
 			setup: func(repo *mocks.MockPetRepository, cache *mocks.MockCache, encryptor *mocks.MockEncryptor) {
 				encryptor.On("Encrypt", "john@example.com").Return("encrypted_email", nil)
-				repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Pet")).Return(nil)
+				repo.On("Transaction", mock.Anything).Run(runTransaction).Return(nil)
+				repo.On("CreateWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.Pet")).Return(nil)
 				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 				cache.On("InvalidatePattern", mock.Anything, mock.Anything).Return(nil)
 			},
@@ -90,7 +92,7 @@ func TestPetService_CreatePet_WithMocks(t *testing.T) {
 			wantErr: true,
 			setup: func(repo *mocks.MockPetRepository, cache *mocks.MockCache, encryptor *mocks.MockEncryptor) {
 				encryptor.On("Encrypt", "john@example.com").Return("encrypted_email", nil)
-				repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Pet")).Return(assert.AnError)
+				repo.On("Transaction", mock.Anything).Return(assert.AnError)
 			},
 		},
 	}
@@ -103,7 +105,7 @@ func TestPetService_CreatePet_WithMocks(t *testing.T) {
 
 			tt.setup(mockRepo, mockCache, mockEncryptor)
 
-			service := NewPetService(mockRepo, mockCache, mockEncryptor)
+			service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), nil)
 
 			pet, err := service.CreatePet(context.Background(), tt.input)
 
@@ -163,7 +165,7 @@ func TestPetService_GetPetByID(t *testing.T) {
 
 			tt.setup(mockRepo, mockCache, petID)
 
-			service := NewPetService(mockRepo, mockCache, mockEncryptor)
+			service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), nil)
 
 			pet, err := service.GetPetByID(context.Background(), petID)
 
@@ -182,11 +184,18 @@ func TestPetService_GetPetByID(t *testing.T) {
 	}
 }
 
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
 func TestPetService_ListPets(t *testing.T) {
 	tests := []struct {
-		name    string
-		wantErr bool
-		setup   func(*mocks.MockPetRepository)
+		name     string
+		near     *models.GeoRadius
+		soldFrom *time.Time
+		soldTo   *time.Time
+		wantErr  bool
+		setup    func(*mocks.MockPetRepository)
 	}{
 		{
 			name:    "successful listing",
@@ -206,13 +215,58 @@ func TestPetService_ListPets(t *testing.T) {
 				repo.On("List", mock.Anything, mock.AnythingOfType("models.PetFilter")).Return([]*models.Pet(nil), 0, assert.AnError)
 			},
 		},
+		{
+			name:    "radius search",
+			near:    &models.GeoRadius{Latitude: 40.7128, Longitude: -74.0060, RadiusKm: 10},
+			wantErr: false,
+			setup: func(repo *mocks.MockPetRepository) {
+				expectedPets := []*models.Pet{
+					{ID: uuid.New(), Name: "Pet1"},
+					{ID: uuid.New(), Name: "Pet2"},
+				}
+				repo.On("List", mock.Anything, mock.MatchedBy(func(filter models.PetFilter) bool {
+					return filter.Near != nil && filter.Near.RadiusKm == 10
+				})).Return(expectedPets, 2, nil)
+			},
+		},
+		{
+			name:    "invalid radius",
+			near:    &models.GeoRadius{Latitude: 40.7128, Longitude: -74.0060, RadiusKm: 0},
+			wantErr: true,
+			setup:   func(repo *mocks.MockPetRepository) {}, // Rejected before reaching the repository
+		},
+		{
+			name:     "sold date range",
+			soldFrom: timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			soldTo:   timePtr(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			wantErr:  false,
+			setup: func(repo *mocks.MockPetRepository) {
+				expectedPets := []*models.Pet{
+					{ID: uuid.New(), Name: "Pet1", Status: models.PetStatusSold, Sale: &models.PetSale{OrderID: uuid.New(), PurchasedAt: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), CustomerID: "customer1"}},
+					{ID: uuid.New(), Name: "Pet2", Status: models.PetStatusSold, Sale: &models.PetSale{OrderID: uuid.New(), PurchasedAt: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), CustomerID: "customer2"}},
+				}
+				repo.On("List", mock.Anything, mock.MatchedBy(func(filter models.PetFilter) bool {
+					return filter.SoldFrom != nil && filter.SoldTo != nil
+				})).Return(expectedPets, 2, nil)
+			},
+		},
+		{
+			name:     "sold date range reversed",
+			soldFrom: timePtr(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+			soldTo:   timePtr(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			wantErr:  true,
+			setup:    func(repo *mocks.MockPetRepository) {}, // Rejected before reaching the repository
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			filter := models.PetFilter{
-				Limit:  10,
-				Offset: 0,
+				Near:     tt.near,
+				SoldFrom: tt.soldFrom,
+				SoldTo:   tt.soldTo,
+				Limit:    10,
+				Offset:   0,
 			}
 
 			mockRepo := new(mocks.MockPetRepository)
@@ -221,7 +275,7 @@ func TestPetService_ListPets(t *testing.T) {
 
 			tt.setup(mockRepo)
 
-			service := NewPetService(mockRepo, mockCache, mockEncryptor)
+			service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), nil)
 
 			pets, total, err := service.ListPets(context.Background(), filter)
 
@@ -296,7 +350,7 @@ func TestPetService_DeletePetByID(t *testing.T) {
 
 			tt.setup(mockRepo, mockCache, mockEncryptor)
 
-			service := NewPetService(mockRepo, mockCache, mockEncryptor)
+			service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), nil)
 			petID := uuid.New()
 
 			err := service.DeletePetByID(context.Background(), petID)
@@ -343,7 +397,7 @@ func TestPetService_MarkPetAsSold(t *testing.T) {
 
 			tt.setup(mockRepo)
 
-			service := NewPetService(mockRepo, mockCache, mockEncryptor)
+			service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), nil)
 			petID := uuid.New()
 
 			err := service.MarkPetAsSold(context.Background(), petID)
@@ -398,7 +452,7 @@ func TestPetService_DecryptBreederEmail(t *testing.T) {
 
 			tt.setup(mockEncryptor)
 
-			service := NewPetService(mockRepo, mockCache, mockEncryptor)
+			service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), nil)
 
 			email, err := service.DecryptBreederEmail(tt.encryptedText)
 
@@ -421,5 +475,5 @@ func TestPetServiceInterface_Implementation(t *testing.T) {
 	mockCache := new(mocks.MockCache)
 	mockEncryptor := new(mocks.MockEncryptor)
 
-	var _ PetServiceInterface = NewPetService(mockRepo, mockCache, mockEncryptor)
+	var _ PetServiceInterface = NewPetService(mockRepo, mockCache, mockEncryptor, new(mocks.MockStoreSettingsService), testPetEvents(), nil)
 }
\ No newline at end of file
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/validation"
+	"github.com/google/uuid"
+)
+
+// PickupServiceInterface defines the interface for pickup scheduling operations
+type PickupServiceInterface interface {
+	CreatePickupSlots(ctx context.Context, input models.CreatePickupSlotsInput) ([]*models.PickupSlot, error)
+	AvailablePickupSlots(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*models.PickupSlot, error)
+	PickupSchedule(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*models.PickupSlot, error)
+	BookPickup(ctx context.Context, customerID string, orderID, slotID uuid.UUID) (*models.PickupAppointment, error)
+	CompletePickup(ctx context.Context, storeID, orderID uuid.UUID) (*models.Order, error)
+	CancelPickup(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error
+}
+
+// PickupService implements PickupServiceInterface
+type PickupService struct {
+	repo      repository.PickupRepositoryInterface
+	orderRepo repository.OrderRepositoryInterface
+	settings  StoreSettingsServiceInterface
+}
+
+// NewPickupService creates a new pickup service
+func NewPickupService(repo repository.PickupRepositoryInterface, orderRepo repository.OrderRepositoryInterface, settings StoreSettingsServiceInterface) *PickupService {
+	return &PickupService{
+		repo:      repo,
+		orderRepo: orderRepo,
+		settings:  settings,
+	}
+}
+
+// CreatePickupSlots splits a day's opening hours into bookable slots
+func (s *PickupService) CreatePickupSlots(ctx context.Context, input models.CreatePickupSlotsInput) ([]*models.PickupSlot, error) {
+	if err := validation.ValidateCreatePickupSlotsInput(input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	opensAt, _ := validation.ParseTimeOfDay(input.OpensAt)
+	closesAt, _ := validation.ParseTimeOfDay(input.ClosesAt)
+	length := time.Duration(input.SlotMinutes) * time.Minute
+	day, err := s.storeDay(ctx, input.StoreID, input.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	var slots []*models.PickupSlot
+	for start := atTimeOfDay(day, opensAt); !start.Add(length).After(atTimeOfDay(day, closesAt)); start = start.Add(length) {
+		slots = append(slots, &models.PickupSlot{
+			ID:        uuid.New(),
+			StoreID:   input.StoreID,
+			StartsAt:  start,
+			EndsAt:    start.Add(length),
+			Capacity:  input.Capacity,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	err = s.repo.Transaction(func(tx *sql.Tx) error {
+		return s.repo.CreateSlotsWithTx(ctx, tx, slots)
+	})
+	if err != nil {
+		if repository.IsExclusionViolation(err, repository.PickupSlotOverlapConstraint) {
+			return nil, apperrors.ConflictError{
+				Resource: "pickup slot",
+				Message:  "pickup slots overlap with slots already defined for this day",
+			}
+		}
+		return nil, fmt.Errorf("failed to create pickup slots: %w", err)
+	}
+
+	return slots, nil
+}
+
+// AvailablePickupSlots lists the future slots of a day that can still be booked
+func (s *PickupService) AvailablePickupSlots(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*models.PickupSlot, error) {
+	day, err := s.storeDay(ctx, storeID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := s.repo.ListSlots(ctx, storeID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	available := []*models.PickupSlot{}
+	for _, slot := range slots {
+		if slot.Remaining() > 0 && slot.StartsAt.After(now) {
+			available = append(available, slot)
+		}
+	}
+
+	return available, nil
+}
+
+// PickupSchedule lists a day's slots together with their appointments
+func (s *PickupService) PickupSchedule(ctx context.Context, storeID uuid.UUID, date time.Time) ([]*models.PickupSlot, error) {
+	day, err := s.storeDay(ctx, storeID, date)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := s.repo.ListSlots(ctx, storeID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	appointments, err := s.repo.ListAppointments(ctx, storeID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	bySlot := make(map[uuid.UUID]*models.PickupSlot, len(slots))
+	for _, slot := range slots {
+		slot.Appointments = []*models.PickupAppointment{}
+		bySlot[slot.ID] = slot
+	}
+	for _, appointment := range appointments {
+		if slot, ok := bySlot[appointment.SlotID]; ok {
+			slot.Appointments = append(slot.Appointments, appointment)
+		}
+	}
+
+	return slots, nil
+}
+
+// BookPickup books (or moves) the pickup appointment of a customer's order.
+// The slot row is locked so concurrent bookings cannot exceed its capacity.
+func (s *PickupService) BookPickup(ctx context.Context, customerID string, orderID, slotID uuid.UUID) (*models.PickupAppointment, error) {
+	var appointment *models.PickupAppointment
+
+	err := s.repo.Transaction(func(tx *sql.Tx) error {
+		order, err := s.orderRepo.GetByIDForUpdateWithTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.CustomerID != customerID {
+			return apperrors.NewOrderNotFound(orderID)
+		}
+
+		if order.Status == models.OrderStatusCompleted {
+			return apperrors.NewBusinessRuleError("order has already been picked up")
+		}
+
+		if order.TotalPets == 0 {
+			return apperrors.NewBusinessRuleError("order has no pets to pick up")
+		}
+
+		if !order.IsPaid() {
+			return apperrors.NewBusinessRuleError("order must be paid before a pickup can be booked")
+		}
+
+		existing, err := s.repo.GetAppointmentByOrderWithTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil && existing.SlotID == slotID {
+			appointment = existing
+			return nil
+		}
+
+		slot, err := s.repo.GetSlotForUpdateWithTx(ctx, tx, slotID)
+		if err != nil {
+			return err
+		}
+
+		if slot.StoreID != order.StoreID {
+			return apperrors.NewValidationError("slotID", "pickup slot belongs to a different store")
+		}
+
+		if !slot.StartsAt.After(time.Now()) {
+			return apperrors.NewBusinessRuleError("pickup slot has already started")
+		}
+
+		if slot.Remaining() == 0 {
+			return apperrors.NewBusinessRuleError("pickup slot is fully booked")
+		}
+
+		settings, err := s.settings.GetStoreSettings(ctx, order.StoreID)
+		if err != nil {
+			return err
+		}
+
+		if slot.StartsAt.After(order.CreatedAt.Add(settings.ReservationHold())) {
+			return apperrors.NewBusinessRuleError(fmt.Sprintf("pets are held for %d hours after purchase; choose an earlier slot", settings.ReservationHoldHours))
+		}
+
+		if existing != nil {
+			if err := s.repo.AdjustBookedWithTx(ctx, tx, existing.SlotID, -1); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.AdjustBookedWithTx(ctx, tx, slot.ID, 1); err != nil {
+			return err
+		}
+
+		appointment = &models.PickupAppointment{
+			ID:         uuid.New(),
+			OrderID:    order.ID,
+			SlotID:     slot.ID,
+			CustomerID: order.CustomerID,
+			Status:     models.PickupStatusBooked,
+			CreatedAt:  time.Now(),
+		}
+		if err := s.repo.SaveAppointmentWithTx(ctx, tx, appointment); err != nil {
+			return fmt.Errorf("failed to save pickup appointment: %w", err)
+		}
+
+		return s.orderRepo.UpdateStatusWithTx(ctx, tx, order.ID, models.OrderStatusPickupScheduled)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return appointment, nil
+}
+
+// CompletePickup marks an order of the store as handed over to the customer
+func (s *PickupService) CompletePickup(ctx context.Context, storeID, orderID uuid.UUID) (*models.Order, error) {
+	var order *models.Order
+
+	err := s.repo.Transaction(func(tx *sql.Tx) error {
+		var err error
+		order, err = s.orderRepo.GetByIDForUpdateWithTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if order.StoreID != storeID {
+			return apperrors.NewOrderNotFound(orderID)
+		}
+
+		if order.Status == models.OrderStatusCompleted {
+			return nil
+		}
+
+		if !order.IsPaid() {
+			return apperrors.NewBusinessRuleError("order must be paid before its pets are handed over")
+		}
+
+		appointment, err := s.repo.GetAppointmentByOrderWithTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+
+		if appointment != nil {
+			now := time.Now()
+			appointment.Status = models.PickupStatusCompleted
+			appointment.CompletedAt = &now
+			if err := s.repo.SaveAppointmentWithTx(ctx, tx, appointment); err != nil {
+				return fmt.Errorf("failed to update pickup appointment: %w", err)
+			}
+		}
+
+		order.Status = models.OrderStatusCompleted
+		return s.orderRepo.UpdateStatusWithTx(ctx, tx, order.ID, models.OrderStatusCompleted)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// CancelPickup cancels the booked appointment of an order that is being undone and frees
+// its place in the slot. Orders without a booked appointment are left alone.
+func (s *PickupService) CancelPickup(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	appointment, err := s.repo.GetAppointmentByOrderWithTx(ctx, tx, orderID)
+	if err != nil {
+		return err
+	}
+
+	if appointment == nil || appointment.Status != models.PickupStatusBooked {
+		return nil
+	}
+
+	if err := s.repo.AdjustBookedWithTx(ctx, tx, appointment.SlotID, -1); err != nil {
+		return err
+	}
+
+	appointment.Status = models.PickupStatusCancelled
+	if err := s.repo.SaveAppointmentWithTx(ctx, tx, appointment); err != nil {
+		return fmt.Errorf("failed to cancel pickup appointment: %w", err)
+	}
+
+	return nil
+}
+
+// storeDay returns midnight of date's calendar day in the store's time zone
+func (s *PickupService) storeDay(ctx context.Context, storeID uuid.UUID, date time.Time) (time.Time, error) {
+	settings, err := s.settings.GetStoreSettings(ctx, storeID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return startOfDay(date, settings.Location()), nil
+}
+
+// startOfDay returns midnight in loc of the calendar day t falls on in UTC
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, loc)
+}
+
+// atTimeOfDay returns the wall-clock time offset after midnight of day, so
+// opening hours keep their meaning on days with a daylight saving change
+func atTimeOfDay(day time.Time, offset time.Duration) time.Time {
+	year, month, date := day.Date()
+	return time.Date(year, month, date, 0, 0, int(offset/time.Second), 0, day.Location())
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickupService_CreatePickupSlots(t *testing.T) {
+	storeID := uuid.New()
+	repo := new(mocks.MockPickupRepository)
+	repo.On("Transaction", mock.Anything).Return(nil)
+	repo.On("CreateSlotsWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("[]*models.PickupSlot")).Return(nil)
+
+	service := NewPickupService(repo, new(mocks.MockOrderRepository), defaultSettingsService())
+
+	slots, err := service.CreatePickupSlots(context.Background(), models.CreatePickupSlotsInput{
+		StoreID:     storeID,
+		Date:        time.Date(2025, 6, 2, 15, 0, 0, 0, time.UTC),
+		OpensAt:     "09:00",
+		ClosesAt:    "11:15",
+		SlotMinutes: 30,
+		Capacity:    2,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, slots, 4)
+	assert.Equal(t, time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC), slots[0].StartsAt)
+	assert.Equal(t, time.Date(2025, 6, 2, 11, 0, 0, 0, time.UTC), slots[3].EndsAt)
+	for _, slot := range slots {
+		assert.Equal(t, storeID, slot.StoreID)
+		assert.Equal(t, 2, slot.Capacity)
+	}
+}
+
+func TestPickupService_CreatePickupSlots_StoreTimezone(t *testing.T) {
+	storeID := uuid.New()
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	settings := new(mocks.MockStoreSettingsService)
+	storeSettings := models.DefaultStoreSettings(storeID)
+	storeSettings.Timezone = "America/New_York"
+	settings.On("GetStoreSettings", mock.Anything, storeID).Return(storeSettings, nil)
+
+	repo := new(mocks.MockPickupRepository)
+	repo.On("Transaction", mock.Anything).Return(nil)
+	repo.On("CreateSlotsWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("[]*models.PickupSlot")).Return(nil)
+
+	service := NewPickupService(repo, new(mocks.MockOrderRepository), settings)
+
+	// Clocks go forward in New York on 9 March 2025; opening hours stay in local time
+	slots, err := service.CreatePickupSlots(context.Background(), models.CreatePickupSlotsInput{
+		StoreID:     storeID,
+		Date:        time.Date(2025, 3, 9, 0, 0, 0, 0, time.UTC),
+		OpensAt:     "09:00",
+		ClosesAt:    "10:00",
+		SlotMinutes: 60,
+		Capacity:    1,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, slots, 1)
+	assert.True(t, slots[0].StartsAt.Equal(time.Date(2025, 3, 9, 9, 0, 0, 0, newYork)))
+	assert.True(t, slots[0].StartsAt.Equal(time.Date(2025, 3, 9, 13, 0, 0, 0, time.UTC)))
+}
+
+func TestPickupService_CreatePickupSlots_Overlap(t *testing.T) {
+	repo := new(mocks.MockPickupRepository)
+	repo.On("Transaction", mock.Anything).Return(nil)
+	repo.On("CreateSlotsWithTx", mock.Anything, mock.Anything, mock.Anything).
+		Return(&pq.Error{Code: "23P01", Constraint: repository.PickupSlotOverlapConstraint})
+
+	service := NewPickupService(repo, new(mocks.MockOrderRepository), defaultSettingsService())
+
+	_, err := service.CreatePickupSlots(context.Background(), models.CreatePickupSlotsInput{
+		StoreID:     uuid.New(),
+		Date:        time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC),
+		OpensAt:     "09:00",
+		ClosesAt:    "10:00",
+		SlotMinutes: 30,
+		Capacity:    1,
+	})
+
+	assert.IsType(t, apperrors.ConflictError{}, err)
+}
+
+func TestPickupService_BookPickup(t *testing.T) {
+	storeID := uuid.New()
+	orderID := uuid.New()
+	slotID := uuid.New()
+	previousSlotID := uuid.New()
+	future := time.Now().Add(24 * time.Hour)
+
+	order := func() *models.Order {
+		return &models.Order{ID: orderID, CustomerID: "customer1", StoreID: storeID, TotalPets: 1, Status: models.OrderStatusPlaced, PaymentStatus: models.PaymentStatusCaptured, CreatedAt: time.Now()}
+	}
+	slot := func() *models.PickupSlot {
+		return &models.PickupSlot{ID: slotID, StoreID: storeID, StartsAt: future, EndsAt: future.Add(30 * time.Minute), Capacity: 2, Booked: 1}
+	}
+
+	tests := []struct {
+		name     string
+		customer string
+		setup    func(*mocks.MockPickupRepository, *mocks.MockOrderRepository)
+		wantErr  interface{}
+	}{
+		{
+			name:     "books a free slot",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(order(), nil)
+				repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).Return(nil, nil)
+				repo.On("GetSlotForUpdateWithTx", mock.Anything, mock.Anything, slotID).Return(slot(), nil)
+				repo.On("AdjustBookedWithTx", mock.Anything, mock.Anything, slotID, 1).Return(nil)
+				repo.On("SaveAppointmentWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.PickupAppointment")).Return(nil)
+				orderRepo.On("UpdateStatusWithTx", mock.Anything, mock.Anything, orderID, models.OrderStatusPickupScheduled).Return(nil)
+			},
+		},
+		{
+			name:     "moves an existing appointment",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(order(), nil)
+				repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).
+					Return(&models.PickupAppointment{OrderID: orderID, SlotID: previousSlotID}, nil)
+				repo.On("GetSlotForUpdateWithTx", mock.Anything, mock.Anything, slotID).Return(slot(), nil)
+				repo.On("AdjustBookedWithTx", mock.Anything, mock.Anything, previousSlotID, -1).Return(nil)
+				repo.On("AdjustBookedWithTx", mock.Anything, mock.Anything, slotID, 1).Return(nil)
+				repo.On("SaveAppointmentWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.PickupAppointment")).Return(nil)
+				orderRepo.On("UpdateStatusWithTx", mock.Anything, mock.Anything, orderID, models.OrderStatusPickupScheduled).Return(nil)
+			},
+		},
+		{
+			name:     "rejects a full slot",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				full := slot()
+				full.Booked = full.Capacity
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(order(), nil)
+				repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).Return(nil, nil)
+				repo.On("GetSlotForUpdateWithTx", mock.Anything, mock.Anything, slotID).Return(full, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name:     "rejects a slot of another store",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				other := slot()
+				other.StoreID = uuid.New()
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(order(), nil)
+				repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).Return(nil, nil)
+				repo.On("GetSlotForUpdateWithTx", mock.Anything, mock.Anything, slotID).Return(other, nil)
+			},
+			wantErr: apperrors.ValidationError{},
+		},
+		{
+			name:     "rejects a slot after the reservation hold",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				late := slot()
+				late.StartsAt = time.Now().Add(time.Duration(models.DefaultReservationHoldHours+1) * time.Hour)
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(order(), nil)
+				repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).Return(nil, nil)
+				repo.On("GetSlotForUpdateWithTx", mock.Anything, mock.Anything, slotID).Return(late, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name:     "hides orders of other customers",
+			customer: "customer2",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(order(), nil)
+			},
+			wantErr: apperrors.OrderNotFoundError{},
+		},
+		{
+			name:     "rejects completed orders",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				completed := order()
+				completed.Status = models.OrderStatusCompleted
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(completed, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name:     "rejects orders whose payment is not captured",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				unpaid := order()
+				unpaid.PaymentStatus = models.PaymentStatusAuthorized
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(unpaid, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name:     "rejects refunded orders",
+			customer: "customer1",
+			setup: func(repo *mocks.MockPickupRepository, orderRepo *mocks.MockOrderRepository) {
+				refunded := order()
+				refunded.PaymentStatus = models.PaymentStatusRefunded
+				orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).Return(refunded, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockPickupRepository)
+			orderRepo := new(mocks.MockOrderRepository)
+			repo.On("Transaction", mock.Anything).Return(nil)
+			tt.setup(repo, orderRepo)
+
+			service := NewPickupService(repo, orderRepo, defaultSettingsService())
+
+			appointment, err := service.BookPickup(context.Background(), tt.customer, orderID, slotID)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+				assert.Nil(t, appointment)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, slotID, appointment.SlotID)
+				assert.Equal(t, models.PickupStatusBooked, appointment.Status)
+			}
+			repo.AssertExpectations(t)
+			orderRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPickupService_CompletePickup(t *testing.T) {
+	storeID := uuid.New()
+	orderID := uuid.New()
+
+	t.Run("completes the order and its appointment", func(t *testing.T) {
+		repo := new(mocks.MockPickupRepository)
+		orderRepo := new(mocks.MockOrderRepository)
+		repo.On("Transaction", mock.Anything).Return(nil)
+		orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).
+			Return(&models.Order{ID: orderID, StoreID: storeID, Status: models.OrderStatusPickupScheduled, PaymentStatus: models.PaymentStatusCaptured}, nil)
+		repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).
+			Return(&models.PickupAppointment{OrderID: orderID, Status: models.PickupStatusBooked}, nil)
+		repo.On("SaveAppointmentWithTx", mock.Anything, mock.Anything, mock.MatchedBy(func(a *models.PickupAppointment) bool {
+			return a.Status == models.PickupStatusCompleted && a.CompletedAt != nil
+		})).Return(nil)
+		orderRepo.On("UpdateStatusWithTx", mock.Anything, mock.Anything, orderID, models.OrderStatusCompleted).Return(nil)
+
+		service := NewPickupService(repo, orderRepo, defaultSettingsService())
+
+		order, err := service.CompletePickup(context.Background(), storeID, orderID)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.OrderStatusCompleted, order.Status)
+		repo.AssertExpectations(t)
+		orderRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects orders that are not paid", func(t *testing.T) {
+		for _, status := range []models.PaymentStatus{models.PaymentStatusPending, models.PaymentStatusAuthorized, models.PaymentStatusFailed, models.PaymentStatusRefunded} {
+			repo := new(mocks.MockPickupRepository)
+			orderRepo := new(mocks.MockOrderRepository)
+			repo.On("Transaction", mock.Anything).Return(nil)
+			orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).
+				Return(&models.Order{ID: orderID, StoreID: storeID, Status: models.OrderStatusPickupScheduled, PaymentStatus: status}, nil)
+
+			service := NewPickupService(repo, orderRepo, defaultSettingsService())
+
+			_, err := service.CompletePickup(context.Background(), storeID, orderID)
+
+			assert.IsType(t, apperrors.BusinessRuleError{}, err, status)
+			orderRepo.AssertNotCalled(t, "UpdateStatusWithTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("hides orders of other stores", func(t *testing.T) {
+		repo := new(mocks.MockPickupRepository)
+		orderRepo := new(mocks.MockOrderRepository)
+		repo.On("Transaction", mock.Anything).Return(nil)
+		orderRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, orderID).
+			Return(&models.Order{ID: orderID, StoreID: uuid.New()}, nil)
+
+		service := NewPickupService(repo, orderRepo, defaultSettingsService())
+
+		_, err := service.CompletePickup(context.Background(), storeID, orderID)
+
+		assert.IsType(t, apperrors.OrderNotFoundError{}, err)
+	})
+}
+
+func TestPickupService_CancelPickup(t *testing.T) {
+	orderID := uuid.New()
+	slotID := uuid.New()
+
+	t.Run("cancels a booked appointment and frees its slot", func(t *testing.T) {
+		repo := new(mocks.MockPickupRepository)
+		repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).
+			Return(&models.PickupAppointment{OrderID: orderID, SlotID: slotID, Status: models.PickupStatusBooked}, nil)
+		repo.On("AdjustBookedWithTx", mock.Anything, mock.Anything, slotID, -1).Return(nil)
+		repo.On("SaveAppointmentWithTx", mock.Anything, mock.Anything, mock.MatchedBy(func(a *models.PickupAppointment) bool {
+			return a.Status == models.PickupStatusCancelled
+		})).Return(nil)
+
+		service := NewPickupService(repo, new(mocks.MockOrderRepository), defaultSettingsService())
+
+		require.NoError(t, service.CancelPickup(context.Background(), nil, orderID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("leaves completed appointments alone", func(t *testing.T) {
+		repo := new(mocks.MockPickupRepository)
+		repo.On("GetAppointmentByOrderWithTx", mock.Anything, mock.Anything, orderID).
+			Return(&models.PickupAppointment{OrderID: orderID, SlotID: slotID, Status: models.PickupStatusCompleted}, nil)
+
+		service := NewPickupService(repo, new(mocks.MockOrderRepository), defaultSettingsService())
+
+		require.NoError(t, service.CancelPickup(context.Background(), nil, orderID))
+		repo.AssertNotCalled(t, "AdjustBookedWithTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPickupService_PickupSchedule(t *testing.T) {
+	storeID := uuid.New()
+	date := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	first := &models.PickupSlot{ID: uuid.New(), StoreID: storeID, Capacity: 2}
+	second := &models.PickupSlot{ID: uuid.New(), StoreID: storeID, Capacity: 2}
+
+	repo := new(mocks.MockPickupRepository)
+	repo.On("ListSlots", mock.Anything, storeID, date, date.AddDate(0, 0, 1)).Return([]*models.PickupSlot{first, second}, nil)
+	repo.On("ListAppointments", mock.Anything, storeID, date, date.AddDate(0, 0, 1)).Return([]*models.PickupAppointment{
+		{OrderID: uuid.New(), SlotID: second.ID},
+	}, nil)
+
+	service := NewPickupService(repo, new(mocks.MockOrderRepository), defaultSettingsService())
+
+	slots, err := service.PickupSchedule(context.Background(), storeID, date.Add(13*time.Hour))
+
+	require.NoError(t, err)
+	assert.Empty(t, slots[0].Appointments)
+	assert.Len(t, slots[1].Appointments, 1)
+}
+
+func TestPickupService_PickupSchedule_StoreTimezone(t *testing.T) {
+	storeID := uuid.New()
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	dayStart := time.Date(2025, 6, 2, 0, 0, 0, 0, tokyo)
+	dayEnd := time.Date(2025, 6, 3, 0, 0, 0, 0, tokyo)
+
+	settings := new(mocks.MockStoreSettingsService)
+	storeSettings := models.DefaultStoreSettings(storeID)
+	storeSettings.Timezone = "Asia/Tokyo"
+	settings.On("GetStoreSettings", mock.Anything, storeID).Return(storeSettings, nil)
+
+	matches := func(want time.Time) interface{} {
+		return mock.MatchedBy(func(got time.Time) bool { return got.Equal(want) })
+	}
+	repo := new(mocks.MockPickupRepository)
+	repo.On("ListSlots", mock.Anything, storeID, matches(dayStart), matches(dayEnd)).Return([]*models.PickupSlot{}, nil)
+	repo.On("ListAppointments", mock.Anything, storeID, matches(dayStart), matches(dayEnd)).Return([]*models.PickupAppointment{}, nil)
+
+	service := NewPickupService(repo, new(mocks.MockOrderRepository), settings)
+
+	_, err = service.PickupSchedule(context.Background(), storeID, time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC))
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestPickupServiceInterface_Implementation(t *testing.T) {
+	var _ PickupServiceInterface = NewPickupService(new(mocks.MockPickupRepository), new(mocks.MockOrderRepository), new(mocks.MockStoreSettingsService))
+}
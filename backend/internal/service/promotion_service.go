@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/validation"
+	"github.com/google/uuid"
+)
+
+// PromotionServiceInterface defines the interface for promotion operations
+type PromotionServiceInterface interface {
+	CreatePromotion(ctx context.Context, input models.CreatePromotionInput) (*models.Promotion, error)
+	ListPromotions(ctx context.Context, storeID uuid.UUID) ([]*models.Promotion, error)
+	SetPromotionActive(ctx context.Context, storeID, promotionID uuid.UUID, active bool) error
+	ApplyDiscount(ctx context.Context, tx *sql.Tx, order *models.Order, pets []*models.Pet, code string) ([]*models.OrderDiscount, error)
+	ReleaseDiscount(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error
+	GetOrderDiscounts(ctx context.Context, orderID uuid.UUID) ([]*models.OrderDiscount, error)
+}
+
+// PromotionService implements PromotionServiceInterface
+type PromotionService struct {
+	repo repository.PromotionRepositoryInterface
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(repo repository.PromotionRepositoryInterface) *PromotionService {
+	return &PromotionService{
+		repo: repo,
+	}
+}
+
+// CreatePromotion creates a new store-scoped promotion code
+func (s *PromotionService) CreatePromotion(ctx context.Context, input models.CreatePromotionInput) (*models.Promotion, error) {
+	if err := validation.ValidateCreatePromotionInput(input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if input.Description != nil {
+		desc := validation.SanitizeString(*input.Description)
+		input.Description = &desc
+	}
+
+	minQuantity := input.MinQuantity
+	if minQuantity == 0 {
+		minQuantity = 1
+	}
+
+	startsAt := time.Now()
+	if input.StartsAt != nil {
+		startsAt = *input.StartsAt
+	}
+
+	promotion := &models.Promotion{
+		ID:               uuid.New(),
+		StoreID:          input.StoreID,
+		Code:             strings.ToUpper(validation.SanitizeString(input.Code)),
+		Description:      input.Description,
+		DiscountType:     input.DiscountType,
+		DiscountValue:    input.DiscountValue,
+		Species:          input.Species,
+		MinAge:           input.MinAge,
+		MaxAge:           input.MaxAge,
+		MinQuantity:      minQuantity,
+		StartsAt:         startsAt,
+		EndsAt:           input.EndsAt,
+		UsageLimit:       input.UsageLimit,
+		PerCustomerLimit: input.PerCustomerLimit,
+		Active:           true,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := s.repo.Create(ctx, promotion); err != nil {
+		if repository.IsUniqueViolation(err, repository.PromotionCodeConstraint) {
+			return nil, apperrors.ConflictError{
+				Resource: "promotion",
+				Message:  "a promotion with this code already exists for the store",
+			}
+		}
+		return nil, fmt.Errorf("failed to create promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// ListPromotions retrieves all promotions of a store
+func (s *PromotionService) ListPromotions(ctx context.Context, storeID uuid.UUID) ([]*models.Promotion, error) {
+	return s.repo.ListByStore(ctx, storeID)
+}
+
+// SetPromotionActive enables or disables a promotion
+func (s *PromotionService) SetPromotionActive(ctx context.Context, storeID, promotionID uuid.UUID, active bool) error {
+	return s.repo.SetActive(ctx, storeID, promotionID, active)
+}
+
+// ApplyDiscount validates a promotion code against an order being checked out,
+// records its usage and discount lines in the transaction, and returns the lines.
+func (s *PromotionService) ApplyDiscount(ctx context.Context, tx *sql.Tx, order *models.Order, pets []*models.Pet, code string) ([]*models.OrderDiscount, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, nil
+	}
+
+	promotion, err := s.repo.GetByCodeWithTx(ctx, tx, order.StoreID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !promotion.IsRunning(time.Now()) {
+		return nil, apperrors.NewBusinessRuleError(fmt.Sprintf("promotion code %s is not active", promotion.Code))
+	}
+
+	if promotion.PerCustomerLimit != nil {
+		used, err := s.repo.CountCustomerUsageWithTx(ctx, tx, promotion.ID, order.CustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if used >= *promotion.PerCustomerLimit {
+			return nil, apperrors.NewBusinessRuleError(fmt.Sprintf("promotion code %s has already been used the maximum number of times", promotion.Code))
+		}
+	}
+
+	discounts, err := CalculateDiscount(promotion, pets)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.IncrementUsageWithTx(ctx, tx, promotion.ID); err != nil {
+		return nil, err
+	}
+
+	for _, discount := range discounts {
+		discount.ID = uuid.New()
+		discount.OrderID = order.ID
+		discount.CreatedAt = time.Now()
+		if err := s.repo.CreateDiscountWithTx(ctx, tx, discount); err != nil {
+			return nil, fmt.Errorf("failed to record discount: %w", err)
+		}
+	}
+
+	return discounts, nil
+}
+
+// ReleaseDiscount reverses ApplyDiscount for an order that is being undone, so its
+// promotion code can be used again
+func (s *PromotionService) ReleaseDiscount(ctx context.Context, tx *sql.Tx, orderID uuid.UUID) error {
+	return s.repo.ReleaseOrderDiscountsWithTx(ctx, tx, orderID)
+}
+
+// GetOrderDiscounts retrieves the discount lines recorded on an order
+func (s *PromotionService) GetOrderDiscounts(ctx context.Context, orderID uuid.UUID) ([]*models.OrderDiscount, error) {
+	return s.repo.GetOrderDiscounts(ctx, orderID)
+}
+
+// CalculateDiscount computes the discount lines a promotion grants for a set of pets.
+// It does not check validity windows or usage limits.
+func CalculateDiscount(promotion *models.Promotion, pets []*models.Pet) ([]*models.OrderDiscount, error) {
+	var qualifying []*models.Pet
+	for _, pet := range pets {
+		if promotion.Qualifies(pet) {
+			qualifying = append(qualifying, pet)
+		}
+	}
+
+	minQuantity := promotion.MinQuantity
+	if minQuantity < 1 {
+		minQuantity = 1
+	}
+	if len(qualifying) < minQuantity {
+		return nil, apperrors.NewBusinessRuleError(fmt.Sprintf("order does not meet the conditions of promotion %s", promotion.Code))
+	}
+
+	var discounts []*models.OrderDiscount
+	line := func(pet *models.Pet, amount int64, description string) {
+		if amount <= 0 {
+			return
+		}
+		discount := &models.OrderDiscount{
+			PromotionID: promotion.ID,
+			Code:        promotion.Code,
+			AmountCents: amount,
+			Description: description,
+		}
+		if pet != nil {
+			petID := pet.ID
+			discount.PetID = &petID
+		}
+		discounts = append(discounts, discount)
+	}
+
+	switch promotion.DiscountType {
+	case models.DiscountTypePercentage:
+		for _, pet := range qualifying {
+			line(pet, pet.PriceCents*promotion.DiscountValue/100, fmt.Sprintf("%d%% off %s", promotion.DiscountValue, pet.Name))
+		}
+	case models.DiscountTypeNthItemPercentage:
+		// Discount the cheaper pets so every Nth pet in price order gets the reduction
+		sorted := make([]*models.Pet, len(qualifying))
+		copy(sorted, qualifying)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].PriceCents > sorted[j].PriceCents })
+		for i := minQuantity - 1; i < len(sorted); i += minQuantity {
+			pet := sorted[i]
+			line(pet, pet.PriceCents*promotion.DiscountValue/100, fmt.Sprintf("%d%% off %s", promotion.DiscountValue, pet.Name))
+		}
+	case models.DiscountTypeFixedAmount:
+		var subtotal int64
+		for _, pet := range qualifying {
+			subtotal += pet.PriceCents
+		}
+		amount := promotion.DiscountValue
+		if amount > subtotal {
+			amount = subtotal
+		}
+		line(nil, amount, fmt.Sprintf("%s order discount", promotion.Code))
+	default:
+		return nil, fmt.Errorf("unsupported discount type: %s", promotion.DiscountType)
+	}
+
+	if len(discounts) == 0 {
+		return nil, apperrors.NewBusinessRuleError(fmt.Sprintf("promotion %s does not reduce the price of this order", promotion.Code))
+	}
+
+	return discounts, nil
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateDiscount(t *testing.T) {
+	cat := models.PetSpeciesCat
+	minAge := 2
+	kitten := &models.Pet{ID: uuid.New(), Name: "Kitten", Species: models.PetSpeciesCat, Age: 1, PriceCents: 10000}
+	adultCat := &models.Pet{ID: uuid.New(), Name: "Tom", Species: models.PetSpeciesCat, Age: 4, PriceCents: 20000}
+	dog := &models.Pet{ID: uuid.New(), Name: "Rex", Species: models.PetSpeciesDog, Age: 3, PriceCents: 30000}
+
+	tests := []struct {
+		name      string
+		promotion *models.Promotion
+		pets      []*models.Pet
+		want      []int64
+		wantErr   bool
+	}{
+		{
+			name:      "10% off all cats",
+			promotion: &models.Promotion{Code: "CATS10", DiscountType: models.DiscountTypePercentage, DiscountValue: 10, Species: &cat},
+			pets:      []*models.Pet{kitten, adultCat, dog},
+			want:      []int64{1000, 2000},
+		},
+		{
+			name:      "second pet half price discounts the cheaper pet",
+			promotion: &models.Promotion{Code: "BOGOHALF", DiscountType: models.DiscountTypeNthItemPercentage, DiscountValue: 50, MinQuantity: 2},
+			pets:      []*models.Pet{kitten, dog},
+			want:      []int64{5000},
+		},
+		{
+			name:      "second pet half price needs two pets",
+			promotion: &models.Promotion{Code: "BOGOHALF", DiscountType: models.DiscountTypeNthItemPercentage, DiscountValue: 50, MinQuantity: 2},
+			pets:      []*models.Pet{dog},
+			wantErr:   true,
+		},
+		{
+			name:      "fixed amount is capped at qualifying subtotal",
+			promotion: &models.Promotion{Code: "FIVEOFF", DiscountType: models.DiscountTypeFixedAmount, DiscountValue: 50000, Species: &cat},
+			pets:      []*models.Pet{kitten, dog},
+			want:      []int64{10000},
+		},
+		{
+			name:      "age condition excludes young pets",
+			promotion: &models.Promotion{Code: "ADULTS", DiscountType: models.DiscountTypePercentage, DiscountValue: 20, Species: &cat, MinAge: &minAge},
+			pets:      []*models.Pet{kitten, adultCat},
+			want:      []int64{4000},
+		},
+		{
+			name:      "no qualifying pets",
+			promotion: &models.Promotion{Code: "CATS10", DiscountType: models.DiscountTypePercentage, DiscountValue: 10, Species: &cat},
+			pets:      []*models.Pet{dog},
+			wantErr:   true,
+		},
+		{
+			name:      "free pets get no discount",
+			promotion: &models.Promotion{Code: "CATS10", DiscountType: models.DiscountTypePercentage, DiscountValue: 10},
+			pets:      []*models.Pet{{ID: uuid.New(), Name: "Free", Species: models.PetSpeciesFrog}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounts, err := CalculateDiscount(tt.promotion, tt.pets)
+
+			if tt.wantErr {
+				assert.IsType(t, apperrors.BusinessRuleError{}, err)
+				return
+			}
+
+			require.NoError(t, err)
+			var amounts []int64
+			for _, discount := range discounts {
+				assert.Equal(t, tt.promotion.Code, discount.Code)
+				amounts = append(amounts, discount.AmountCents)
+			}
+			assert.Equal(t, tt.want, amounts)
+		})
+	}
+}
+
+func TestPromotionService_ApplyDiscount(t *testing.T) {
+	storeID := uuid.New()
+	order := &models.Order{ID: uuid.New(), StoreID: storeID, CustomerID: "customer1"}
+	pets := []*models.Pet{{ID: uuid.New(), Name: "Luna", Species: models.PetSpeciesCat, PriceCents: 10000}}
+	limit := 1
+	expired := time.Now().Add(-time.Hour)
+
+	running := func() *models.Promotion {
+		return &models.Promotion{
+			ID:            uuid.New(),
+			StoreID:       storeID,
+			Code:          "SPRING10",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 10,
+			MinQuantity:   1,
+			StartsAt:      time.Now().Add(-24 * time.Hour),
+			Active:        true,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		setup   func(*mocks.MockPromotionRepository)
+		want    int64
+		wantErr interface{}
+	}{
+		{
+			name:  "no code applies nothing",
+			code:  "",
+			setup: func(*mocks.MockPromotionRepository) {},
+		},
+		{
+			name: "valid code records usage and lines",
+			code: "spring10",
+			setup: func(repo *mocks.MockPromotionRepository) {
+				promotion := running()
+				repo.On("GetByCodeWithTx", mock.Anything, mock.Anything, storeID, "spring10").Return(promotion, nil)
+				repo.On("IncrementUsageWithTx", mock.Anything, mock.Anything, promotion.ID).Return(nil)
+				repo.On("CreateDiscountWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.OrderDiscount")).Return(nil)
+			},
+			want: 1000,
+		},
+		{
+			name: "unknown code",
+			code: "NOPE",
+			setup: func(repo *mocks.MockPromotionRepository) {
+				repo.On("GetByCodeWithTx", mock.Anything, mock.Anything, storeID, "NOPE").
+					Return(nil, apperrors.NotFoundError{Resource: "promotion", ID: "NOPE"})
+			},
+			wantErr: apperrors.NotFoundError{},
+		},
+		{
+			name: "expired code",
+			code: "SPRING10",
+			setup: func(repo *mocks.MockPromotionRepository) {
+				promotion := running()
+				promotion.EndsAt = &expired
+				repo.On("GetByCodeWithTx", mock.Anything, mock.Anything, storeID, "SPRING10").Return(promotion, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name: "per-customer limit reached",
+			code: "SPRING10",
+			setup: func(repo *mocks.MockPromotionRepository) {
+				promotion := running()
+				promotion.PerCustomerLimit = &limit
+				repo.On("GetByCodeWithTx", mock.Anything, mock.Anything, storeID, "SPRING10").Return(promotion, nil)
+				repo.On("CountCustomerUsageWithTx", mock.Anything, mock.Anything, promotion.ID, "customer1").Return(1, nil)
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name: "global usage limit reached",
+			code: "SPRING10",
+			setup: func(repo *mocks.MockPromotionRepository) {
+				promotion := running()
+				repo.On("GetByCodeWithTx", mock.Anything, mock.Anything, storeID, "SPRING10").Return(promotion, nil)
+				repo.On("IncrementUsageWithTx", mock.Anything, mock.Anything, promotion.ID).
+					Return(apperrors.NewBusinessRuleError("promotion usage limit has been reached"))
+			},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockPromotionRepository)
+			tt.setup(repo)
+
+			service := NewPromotionService(repo)
+
+			discounts, err := service.ApplyDiscount(context.Background(), nil, order, pets, tt.code)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+			} else {
+				require.NoError(t, err)
+				var total int64
+				for _, discount := range discounts {
+					assert.Equal(t, order.ID, discount.OrderID)
+					total += discount.AmountCents
+				}
+				assert.Equal(t, tt.want, total)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPromotionService_CreatePromotion(t *testing.T) {
+	storeID := uuid.New()
+
+	t.Run("normalizes code and defaults", func(t *testing.T) {
+		repo := new(mocks.MockPromotionRepository)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Promotion")).Return(nil)
+
+		service := NewPromotionService(repo)
+
+		promotion, err := service.CreatePromotion(context.Background(), models.CreatePromotionInput{
+			StoreID:       storeID,
+			Code:          " weekend-cats ",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 10,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "WEEKEND-CATS", promotion.Code)
+		assert.Equal(t, 1, promotion.MinQuantity)
+		assert.True(t, promotion.Active)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		service := NewPromotionService(new(mocks.MockPromotionRepository))
+
+		_, err := service.CreatePromotion(context.Background(), models.CreatePromotionInput{
+			StoreID:       storeID,
+			Code:          "BAD",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 150,
+		})
+
+		var validationErr apperrors.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("duplicate code", func(t *testing.T) {
+		repo := new(mocks.MockPromotionRepository)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Promotion")).
+			Return(&pq.Error{Code: "23505", Constraint: repository.PromotionCodeConstraint})
+
+		service := NewPromotionService(repo)
+
+		_, err := service.CreatePromotion(context.Background(), models.CreatePromotionInput{
+			StoreID:       storeID,
+			Code:          "WEEKEND",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 10,
+		})
+
+		assert.IsType(t, apperrors.ConflictError{}, err)
+	})
+
+	t.Run("other unique violations are not a duplicate code", func(t *testing.T) {
+		repo := new(mocks.MockPromotionRepository)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Promotion")).
+			Return(&pq.Error{Code: "23505", Constraint: "promotions_pkey"})
+
+		service := NewPromotionService(repo)
+
+		_, err := service.CreatePromotion(context.Background(), models.CreatePromotionInput{
+			StoreID:       storeID,
+			Code:          "WEEKEND",
+			DiscountType:  models.DiscountTypePercentage,
+			DiscountValue: 10,
+		})
+
+		var conflictErr apperrors.ConflictError
+		require.Error(t, err)
+		assert.False(t, errors.As(err, &conflictErr))
+	})
+}
+
+func TestPromotionServiceInterface_Implementation(t *testing.T) {
+	var _ PromotionServiceInterface = NewPromotionService(new(mocks.MockPromotionRepository))
+}
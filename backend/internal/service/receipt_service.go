@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/receipt"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/google/uuid"
+)
+
+// ReceiptServiceInterface defines the interface for order receipt operations
+type ReceiptServiceInterface interface {
+	GetOrderReceipt(ctx context.Context, orderID uuid.UUID, viewer string, isMerchant bool, format receipt.Format) (*receipt.Document, error)
+}
+
+// ReceiptService renders receipts for orders
+type ReceiptService struct {
+	orderRepo     repository.OrderRepositoryInterface
+	storeRepo     repository.StoreRepositoryInterface
+	promotionRepo repository.PromotionRepositoryInterface
+}
+
+// NewReceiptService creates a new receipt service
+func NewReceiptService(
+	orderRepo repository.OrderRepositoryInterface,
+	storeRepo repository.StoreRepositoryInterface,
+	promotionRepo repository.PromotionRepositoryInterface,
+) *ReceiptService {
+	return &ReceiptService{
+		orderRepo:     orderRepo,
+		storeRepo:     storeRepo,
+		promotionRepo: promotionRepo,
+	}
+}
+
+// GetOrderReceipt renders the receipt of an order. Customers may only access
+// their own orders and merchants only orders placed at their store; any other
+// order is reported as not found.
+func (s *ReceiptService) GetOrderReceipt(ctx context.Context, orderID uuid.UUID, viewer string, isMerchant bool, format receipt.Format) (*receipt.Document, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := s.storeRepo.GetByID(ctx, order.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canViewOrder(order, store, viewer, isMerchant) {
+		return nil, apperrors.NewOrderNotFound(orderID)
+	}
+
+	pets, err := s.orderRepo.GetOrderPets(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, err := s.promotionRepo.GetOrderDiscounts(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return receipt.Render(buildReceipt(order, store, pets, discounts), format)
+}
+
+// canViewOrder reports whether a user may read an order
+func canViewOrder(order *models.Order, store *models.Store, viewer string, isMerchant bool) bool {
+	if isMerchant {
+		return store.OwnerID == viewer
+	}
+	return order.CustomerID == viewer
+}
+
+func buildReceipt(order *models.Order, store *models.Store, pets []*models.Pet, discounts []*models.OrderDiscount) *receipt.Receipt {
+	result := &receipt.Receipt{
+		OrderID:       order.ID,
+		StoreName:     store.Name,
+		CustomerID:    order.CustomerID,
+		PaymentStatus: string(order.PaymentStatus),
+		OrderedAt:     order.CreatedAt,
+		IssuedAt:      time.Now(),
+		SubtotalCents: order.SubtotalCents(),
+		DiscountCents: order.DiscountCents,
+		TotalCents:    order.TotalCents,
+	}
+
+	for _, pet := range pets {
+		result.Items = append(result.Items, receipt.Item{
+			Name:       pet.Name,
+			Species:    string(pet.Species),
+			Age:        pet.Age,
+			PriceCents: pet.PriceCents,
+		})
+	}
+
+	for _, discount := range discounts {
+		result.Discounts = append(result.Discounts, receipt.Discount{
+			Code:        discount.Code,
+			Description: discount.Description,
+			AmountCents: discount.AmountCents,
+		})
+	}
+
+	return result
+}
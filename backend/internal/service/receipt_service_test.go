@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/receipt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReceiptService_GetOrderReceipt(t *testing.T) {
+	store := &models.Store{ID: uuid.New(), Name: "Happy Tails", OwnerID: "merchant1"}
+	order := &models.Order{
+		ID:            uuid.New(),
+		CustomerID:    "customer1",
+		StoreID:       store.ID,
+		TotalPets:     1,
+		TotalCents:    9000,
+		DiscountCents: 1000,
+		PaymentStatus: models.PaymentStatusCaptured,
+		CreatedAt:     time.Now(),
+	}
+	pets := []*models.Pet{{ID: uuid.New(), Name: "Biscuit", Species: models.PetSpeciesDog, Age: 3, PriceCents: 10000}}
+	discounts := []*models.OrderDiscount{{Code: "TENOFF", Description: "TENOFF order discount", AmountCents: 1000}}
+
+	tests := []struct {
+		name       string
+		viewer     string
+		isMerchant bool
+		wantErr    bool
+	}{
+		{name: "customer who placed the order", viewer: "customer1"},
+		{name: "merchant who owns the store", viewer: "merchant1", isMerchant: true},
+		{name: "another customer", viewer: "customer2", wantErr: true},
+		{name: "merchant of another store", viewer: "merchant2", isMerchant: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderRepo := new(mocks.MockOrderRepository)
+			storeRepo := new(mocks.MockStoreRepository)
+			promotionRepo := new(mocks.MockPromotionRepository)
+
+			orderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+			storeRepo.On("GetByID", mock.Anything, store.ID).Return(store, nil)
+			if !tt.wantErr {
+				orderRepo.On("GetOrderPets", mock.Anything, order.ID).Return(pets, nil)
+				promotionRepo.On("GetOrderDiscounts", mock.Anything, order.ID).Return(discounts, nil)
+			}
+
+			service := NewReceiptService(orderRepo, storeRepo, promotionRepo)
+
+			doc, err := service.GetOrderReceipt(context.Background(), order.ID, tt.viewer, tt.isMerchant, receipt.FormatHTML)
+
+			if tt.wantErr {
+				assert.IsType(t, apperrors.OrderNotFoundError{}, err)
+				assert.Nil(t, doc)
+			} else {
+				require.NoError(t, err)
+				content := string(doc.Content)
+				assert.Contains(t, content, "Happy Tails")
+				assert.Contains(t, content, "Biscuit")
+				assert.Contains(t, content, "$100.00")
+				assert.Contains(t, content, "$90.00")
+			}
+			orderRepo.AssertExpectations(t)
+			storeRepo.AssertExpectations(t)
+			promotionRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReceiptService_GetOrderReceipt_OrderNotFound(t *testing.T) {
+	orderID := uuid.New()
+	orderRepo := new(mocks.MockOrderRepository)
+	orderRepo.On("GetByID", mock.Anything, orderID).Return(nil, apperrors.NewOrderNotFound(orderID))
+
+	service := NewReceiptService(orderRepo, new(mocks.MockStoreRepository), new(mocks.MockPromotionRepository))
+
+	_, err := service.GetOrderReceipt(context.Background(), orderID, "customer1", false, receipt.FormatPDF)
+
+	assert.IsType(t, apperrors.OrderNotFoundError{}, err)
+}
+
+func TestReceiptServiceInterface_Implementation(t *testing.T) {
+	var _ ReceiptServiceInterface = NewReceiptService(new(mocks.MockOrderRepository), new(mocks.MockStoreRepository), new(mocks.MockPromotionRepository))
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/validation"
+)
+
+// SalesServiceInterface defines the interface for sales analytics
+type SalesServiceInterface interface {
+	SalesReport(ctx context.Context, input models.SalesReportInput) (*models.SalesReport, error)
+}
+
+// SalesService implements SalesServiceInterface
+type SalesService struct {
+	repo repository.SalesRepositoryInterface
+}
+
+// NewSalesService creates a new sales service
+func NewSalesService(repo repository.SalesRepositoryInterface) *SalesService {
+	return &SalesService{
+		repo: repo,
+	}
+}
+
+// SalesReport aggregates a store's sales by the date the pets were purchased
+func (s *SalesService) SalesReport(ctx context.Context, input models.SalesReportInput) (*models.SalesReport, error) {
+	if err := validation.ValidateSalesReportInput(input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	input.From = input.From.UTC()
+	input.To = input.To.UTC()
+
+	buckets, err := s.repo.SalesBuckets(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.repo.SalesSummary(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SalesReport{
+		SalesReportInput: input,
+		SalesSummary:     *summary,
+		Buckets:          buckets,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSalesService_SalesReport(t *testing.T) {
+	storeID := uuid.New()
+	est := time.FixedZone("EST", -5*60*60)
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, est)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("combines buckets and summary", func(t *testing.T) {
+		repo := new(mocks.MockSalesRepository)
+		day := time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)
+		cat := models.PetSpeciesCat
+		avgDays := 12.5
+
+		utcInput := models.SalesReportInput{StoreID: storeID, From: from.UTC(), To: to.UTC(), GroupBy: models.SalesGroupByDay}
+		repo.On("SalesBuckets", mock.Anything, utcInput).Return([]*models.SalesBucket{
+			{PeriodStart: &day, PetsSold: 3, RevenueCents: 45000},
+		}, nil)
+		repo.On("SalesSummary", mock.Anything, utcInput).Return(&models.SalesSummary{
+			PetsSold:           3,
+			RevenueCents:       45000,
+			AverageDaysOnShelf: &avgDays,
+			TopSpecies:         &cat,
+			TopSpeciesPetsSold: 2,
+		}, nil)
+
+		service := NewSalesService(repo)
+
+		report, err := service.SalesReport(context.Background(), models.SalesReportInput{
+			StoreID: storeID, From: from, To: to, GroupBy: models.SalesGroupByDay,
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, report.Buckets, 1)
+		assert.Equal(t, 3, report.PetsSold)
+		assert.Equal(t, int64(45000), report.RevenueCents)
+		assert.Equal(t, models.PetSpeciesCat, *report.TopSpecies)
+		assert.Equal(t, time.UTC, report.From.Location())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an inverted range", func(t *testing.T) {
+		repo := new(mocks.MockSalesRepository)
+		service := NewSalesService(repo)
+
+		report, err := service.SalesReport(context.Background(), models.SalesReportInput{
+			StoreID: storeID, From: to, To: from, GroupBy: models.SalesGroupByMonth,
+		})
+
+		assert.IsType(t, apperrors.ValidationError{}, errors.Unwrap(err))
+		assert.Nil(t, report)
+		repo.AssertNotCalled(t, "SalesBuckets", mock.Anything, mock.Anything)
+	})
+
+	t.Run("propagates repository errors", func(t *testing.T) {
+		repo := new(mocks.MockSalesRepository)
+		repo.On("SalesBuckets", mock.Anything, mock.Anything).Return(nil, assert.AnError)
+
+		service := NewSalesService(repo)
+
+		report, err := service.SalesReport(context.Background(), models.SalesReportInput{
+			StoreID: storeID, From: from, To: to, GroupBy: models.SalesGroupBySpecies,
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, report)
+	})
+}
+
+func TestSalesServiceInterface_Implementation(t *testing.T) {
+	var _ SalesServiceInterface = NewSalesService(new(mocks.MockSalesRepository))
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
@@ -17,8 +18,14 @@ import (
 // StoreServiceInterface defines the interface for store operations
 type StoreServiceInterface interface {
 	CreateStore(ctx context.Context, input models.CreateStoreInput) (*models.Store, error)
-	GetStoreByOwnerID(ctx context.Context, ownerID string) (*models.Store, error)
+	GetStoreByID(ctx context.Context, storeID uuid.UUID) (*models.Store, error)
+	GetStoreForOwner(ctx context.Context, ownerID string, storeID uuid.UUID) (*models.Store, error)
+	ListStoresByOwner(ctx context.Context, ownerID string) ([]*models.Store, error)
 	ListAllStores(ctx context.Context) ([]*models.Store, error)
+	ListOpenStores(ctx context.Context) ([]*models.Store, error)
+	StoresNear(ctx context.Context, near models.GeoRadius, limit, offset int) ([]*models.NearbyStore, int, error)
+	UpdateStore(ctx context.Context, ownerID string, storeID uuid.UUID, input models.UpdateStoreInput) (*models.Store, error)
+	CloseStore(ctx context.Context, ownerID string, storeID uuid.UUID) error
 }
 
 // StoreService implements StoreServiceInterface with improved error handling and validation
@@ -47,24 +54,22 @@ func (s *StoreService) CreateStore(ctx context.Context, input models.CreateStore
 	input.Name = validation.SanitizeString(input.Name)
 	input.OwnerID = validation.SanitizeString(input.OwnerID)
 
-	// Check if store already exists for this owner (direct repository call to avoid recursion)
-	existingStore, err := s.repo.GetByOwnerID(ctx, input.OwnerID)
-	if err == nil && existingStore != nil {
-		return nil, apperrors.ConflictError{
-			Resource: "store",
-			Message:  "store already exists for this owner",
-		}
-	}
-
 	store := &models.Store{
-		ID:        uuid.New(),
-		Name:      input.Name,
-		OwnerID:   input.OwnerID,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+		ID:           uuid.New(),
+		Name:         input.Name,
+		OwnerID:      input.OwnerID,
+		Slug:         validation.Slugify(input.Name),
+		OpeningHours: []models.OpeningHours{},
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
 	}
 
-	err = s.repo.Create(ctx, store)
+	err := s.repo.Create(ctx, store)
+	if err != nil && isDuplicateSlug(err) {
+		// Another store already uses the name; disambiguate with the store ID
+		store.Slug = fmt.Sprintf("%s-%s", store.Slug, store.ID.String()[:8])
+		err = s.repo.Create(ctx, store)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create store: %w", err)
 	}
@@ -72,25 +77,18 @@ func (s *StoreService) CreateStore(ctx context.Context, input models.CreateStore
 	cacheKey := cache.StoreCacheKey(store.ID.String())
 	_ = s.cache.Set(ctx, cacheKey, store, 10*time.Minute)
 
-	ownerCacheKey := fmt.Sprintf("store:owner:%s", store.OwnerID)
-	_ = s.cache.Set(ctx, ownerCacheKey, store, 10*time.Minute)
-
 	return store, nil
 }
 
-// GetStoreByOwnerID retrieves a store by owner ID with caching
-func (s *StoreService) GetStoreByOwnerID(ctx context.Context, ownerID string) (*models.Store, error) {
-	if strings.TrimSpace(ownerID) == "" {
-		return nil, apperrors.NewValidationError("ownerID", "owner ID cannot be empty")
-	}
-
-	cacheKey := fmt.Sprintf("store:owner:%s", ownerID)
+// GetStoreByID retrieves a store by ID with caching
+func (s *StoreService) GetStoreByID(ctx context.Context, storeID uuid.UUID) (*models.Store, error) {
+	cacheKey := cache.StoreCacheKey(storeID.String())
 	var store models.Store
 	if err := s.cache.Get(ctx, cacheKey, &store); err == nil {
 		return &store, nil
 	}
 
-	storePtr, err := s.repo.GetByOwnerID(ctx, ownerID)
+	storePtr, err := s.repo.GetByID(ctx, storeID)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +98,35 @@ func (s *StoreService) GetStoreByOwnerID(ctx context.Context, ownerID string) (*
 	return storePtr, nil
 }
 
+// GetStoreForOwner retrieves a store and checks that ownerID owns it. Stores
+// of other owners are reported as not found.
+func (s *StoreService) GetStoreForOwner(ctx context.Context, ownerID string, storeID uuid.UUID) (*models.Store, error) {
+	if strings.TrimSpace(ownerID) == "" {
+		return nil, apperrors.NewValidationError("ownerID", "owner ID cannot be empty")
+	}
+
+	store, err := s.GetStoreByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
 
+	if store.OwnerID != ownerID {
+		return nil, apperrors.NewStoreNotFound(storeID)
+	}
+
+	return store, nil
+}
+
+// ListStoresByOwner retrieves all stores operated by an owner
+func (s *StoreService) ListStoresByOwner(ctx context.Context, ownerID string) ([]*models.Store, error) {
+	if strings.TrimSpace(ownerID) == "" {
+		return nil, apperrors.NewValidationError("ownerID", "owner ID cannot be empty")
+	}
+
+	return s.repo.ListByOwner(ctx, ownerID)
+}
+
+// ListAllStores retrieves every store, including closed ones
 func (s *StoreService) ListAllStores(ctx context.Context) ([]*models.Store, error) {
 	stores, err := s.repo.ListAll(ctx)
 	if err != nil {
@@ -110,3 +136,139 @@ func (s *StoreService) ListAllStores(ctx context.Context) ([]*models.Store, erro
 	return stores, nil
 }
 
+// ListOpenStores retrieves the stores that have not been closed
+func (s *StoreService) ListOpenStores(ctx context.Context) ([]*models.Store, error) {
+	return s.repo.ListOpen(ctx)
+}
+
+// StoresNear lists open stores within the radius, nearest first
+func (s *StoreService) StoresNear(ctx context.Context, near models.GeoRadius, limit, offset int) ([]*models.NearbyStore, int, error) {
+	if err := validation.ValidateGeoRadius(near); err != nil {
+		return nil, 0, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	return s.repo.ListNear(ctx, near, limit, offset)
+}
+
+// UpdateStore changes the profile of a store owned by ownerID
+func (s *StoreService) UpdateStore(ctx context.Context, ownerID string, storeID uuid.UUID, input models.UpdateStoreInput) (*models.Store, error) {
+	if err := validation.ValidateUpdateStoreInput(input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	store, err := s.repo.GetByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if store.OwnerID != ownerID {
+		return nil, apperrors.NewStoreNotFound(storeID)
+	}
+
+	if !store.IsOpen() {
+		return nil, apperrors.NewBusinessRuleError("closed stores cannot be updated")
+	}
+
+	if input.Name != nil {
+		store.Name = validation.SanitizeString(*input.Name)
+	}
+	if input.Slug != nil {
+		store.Slug = validation.SanitizeString(*input.Slug)
+	}
+	store.Description = optionalProfileField(store.Description, input.Description)
+	store.Address = optionalProfileField(store.Address, input.Address)
+	store.Phone = optionalProfileField(store.Phone, input.Phone)
+	store.LogoURL = optionalProfileField(store.LogoURL, input.LogoURL)
+	if input.OpeningHours != nil {
+		store.OpeningHours = input.OpeningHours
+	}
+	if input.Latitude != nil {
+		store.Latitude = input.Latitude
+		store.Longitude = input.Longitude
+	}
+
+	if err := s.repo.Update(ctx, store); err != nil {
+		if isDuplicateSlug(err) {
+			return nil, apperrors.ConflictError{
+				Resource: "store",
+				Message:  "slug is already used by another store",
+			}
+		}
+		return nil, err
+	}
+
+	s.invalidateStore(ctx, store)
+
+	return store, nil
+}
+
+// CloseStore soft-closes a store owned by ownerID. Stores with open orders
+// (orders whose pets have not been picked up yet) cannot be closed.
+func (s *StoreService) CloseStore(ctx context.Context, ownerID string, storeID uuid.UUID) error {
+	var store *models.Store
+
+	err := s.repo.Transaction(func(tx *sql.Tx) error {
+		var err error
+		store, err = s.repo.GetByIDForUpdateWithTx(ctx, tx, storeID)
+		if err != nil {
+			return err
+		}
+
+		if store.OwnerID != ownerID {
+			return apperrors.NewStoreNotFound(storeID)
+		}
+
+		if !store.IsOpen() {
+			return nil
+		}
+
+		openOrders, err := s.repo.CountOpenOrdersWithTx(ctx, tx, storeID)
+		if err != nil {
+			return err
+		}
+
+		if openOrders > 0 {
+			return apperrors.NewBusinessRuleError(fmt.Sprintf("store has %d open orders that must be completed before closing", openOrders))
+		}
+
+		return s.repo.CloseWithTx(ctx, tx, storeID, time.Now())
+	})
+	if err != nil {
+		return err
+	}
+
+	s.invalidateStore(ctx, store)
+
+	return nil
+}
+
+// invalidateStore removes the cached copy of a store
+func (s *StoreService) invalidateStore(ctx context.Context, store *models.Store) {
+	_ = s.cache.Delete(ctx, cache.StoreCacheKey(store.ID.String()))
+}
+
+// optionalProfileField applies an optional profile update; an empty value clears the field
+func optionalProfileField(current, update *string) *string {
+	if update == nil {
+		return current
+	}
+	value := validation.SanitizeString(*update)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func isDuplicateSlug(err error) bool {
+	return repository.IsUniqueViolation(err, repository.StoreSlugConstraint)
+}
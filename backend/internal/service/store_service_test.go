@@ -3,11 +3,16 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
 	"github.com/fehepe/pet-store/backend/internal/mocks"
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -27,11 +32,8 @@ func TestStoreService_CreateStore(t *testing.T) {
 			},
 			wantErr: false,
 			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
-				// No cache mocking needed for CreateStore - it calls repo directly
-				// Mock GetByOwnerID to return not found (sql.ErrNoRows)
-				repo.On("GetByOwnerID", mock.Anything, "owner123").Return(nil, sql.ErrNoRows)
 				repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Store")).Return(nil)
-				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(2)
+				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
 		},
 		{
@@ -53,20 +55,16 @@ func TestStoreService_CreateStore(t *testing.T) {
 			setup: func(*mocks.MockStoreRepository, *mocks.MockCache) {}, // No mocking needed for validation errors
 		},
 		{
-			name: "store already exists",
+			name: "owner of another store can open a second one",
 			input: models.CreateStoreInput{
-				Name:    "Pet Paradise",
+				Name:    "Pet Paradise North",
 				OwnerID: "owner123",
 			},
-			wantErr: true,
+			wantErr: false,
 			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
-				existingStore := &models.Store{
-					ID:      uuid.New(),
-					Name:    "Existing Store",
-					OwnerID: "owner123",
-				}
-				// Mock direct repository call during CreateStore
-				repo.On("GetByOwnerID", mock.Anything, "owner123").Return(existingStore, nil)
+				// Merchants may operate several stores, so no existing-store lookup happens
+				repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Store")).Return(nil)
+				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 			},
 		},
 		{
@@ -77,8 +75,6 @@ func TestStoreService_CreateStore(t *testing.T) {
 			},
 			wantErr: true,
 			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
-				// Mock GetByOwnerID to return not found
-				repo.On("GetByOwnerID", mock.Anything, "owner123").Return(nil, sql.ErrNoRows)
 				// Mock Create to return error
 				repo.On("Create", mock.Anything, mock.AnythingOfType("*models.Store")).Return(assert.AnError)
 			},
@@ -112,7 +108,9 @@ func TestStoreService_CreateStore(t *testing.T) {
 	}
 }
 
-func TestStoreService_GetStoreByOwnerID(t *testing.T) {
+func TestStoreService_GetStoreForOwner(t *testing.T) {
+	storeID := uuid.New()
+
 	tests := []struct {
 		name    string
 		ownerID string
@@ -127,7 +125,7 @@ func TestStoreService_GetStoreByOwnerID(t *testing.T) {
 				// Mock cache hit with actual store data
 				cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
 					store := args[2].(*models.Store)
-					store.ID = uuid.New()
+					store.ID = storeID
 					store.Name = "Cached Store"
 					store.OwnerID = "owner123"
 				}).Return(nil)
@@ -139,22 +137,32 @@ func TestStoreService_GetStoreByOwnerID(t *testing.T) {
 			wantErr: false,
 			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
 				expectedStore := &models.Store{
-					ID:      uuid.New(),
+					ID:      storeID,
 					Name:    "Pet Paradise",
 					OwnerID: "owner123",
 				}
 				cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows) // Cache miss
-				repo.On("GetByOwnerID", mock.Anything, "owner123").Return(expectedStore, nil)
+				repo.On("GetByID", mock.Anything, storeID).Return(expectedStore, nil)
+				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:    "store owned by another merchant",
+			ownerID: "owner456",
+			wantErr: true,
+			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
+				cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows) // Cache miss
+				repo.On("GetByID", mock.Anything, storeID).Return(&models.Store{ID: storeID, OwnerID: "owner123"}, nil)
 				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 			},
 		},
 		{
 			name:    "store not found",
-			ownerID: "newowner",
+			ownerID: "owner123",
 			wantErr: true,
 			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
 				cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows) // Cache miss
-				repo.On("GetByOwnerID", mock.Anything, "newowner").Return(nil, sql.ErrNoRows)
+				repo.On("GetByID", mock.Anything, storeID).Return(nil, apperrors.NewStoreNotFound(storeID))
 			},
 		},
 		{
@@ -169,15 +177,6 @@ func TestStoreService_GetStoreByOwnerID(t *testing.T) {
 			wantErr: true,
 			setup:   func(*mocks.MockStoreRepository, *mocks.MockCache) {}, // No mocking needed for validation errors
 		},
-		{
-			name:    "repository error",
-			ownerID: "owner123",
-			wantErr: true,
-			setup: func(repo *mocks.MockStoreRepository, cache *mocks.MockCache) {
-				cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(sql.ErrNoRows) // Cache miss
-				repo.On("GetByOwnerID", mock.Anything, "owner123").Return(nil, assert.AnError)
-			},
-		},
 	}
 
 	for _, tt := range tests {
@@ -189,7 +188,7 @@ func TestStoreService_GetStoreByOwnerID(t *testing.T) {
 
 			service := NewStoreService(mockRepo, mockCache)
 
-			store, err := service.GetStoreByOwnerID(context.Background(), tt.ownerID)
+			store, err := service.GetStoreForOwner(context.Background(), tt.ownerID, storeID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -206,6 +205,70 @@ func TestStoreService_GetStoreByOwnerID(t *testing.T) {
 	}
 }
 
+func TestStoreService_ListStoresByOwner(t *testing.T) {
+	mockRepo := new(mocks.MockStoreRepository)
+	stores := []*models.Store{
+		{ID: uuid.New(), Name: "Pet Paradise North", OwnerID: "owner123"},
+		{ID: uuid.New(), Name: "Pet Paradise South", OwnerID: "owner123"},
+	}
+	mockRepo.On("ListByOwner", mock.Anything, "owner123").Return(stores, nil)
+
+	service := NewStoreService(mockRepo, new(mocks.MockCache))
+
+	result, err := service.ListStoresByOwner(context.Background(), "owner123")
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	_, err = service.ListStoresByOwner(context.Background(), " ")
+	assert.IsType(t, apperrors.ValidationError{}, err)
+}
+
+func TestStoreService_StoresNear(t *testing.T) {
+	near := models.GeoRadius{Latitude: 40.7128, Longitude: -74.0060, RadiusKm: 25}
+
+	tests := []struct {
+		name      string
+		near      models.GeoRadius
+		limit     int
+		wantLimit int
+		wantErr   bool
+	}{
+		{name: "default page size", near: near, limit: 0, wantLimit: 20},
+		{name: "page size is capped", near: near, limit: 500, wantLimit: 100},
+		{name: "radius too large", near: models.GeoRadius{Latitude: 40.7128, Longitude: -74.0060, RadiusKm: 5000}, wantErr: true},
+		{name: "invalid latitude", near: models.GeoRadius{Latitude: 123, Longitude: -74.0060, RadiusKm: 25}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockStoreRepository)
+			nearby := []*models.NearbyStore{
+				{Store: &models.Store{ID: uuid.New(), Name: "Close"}, DistanceKm: 1.2},
+				{Store: &models.Store{ID: uuid.New(), Name: "Far"}, DistanceKm: 18.5},
+			}
+			if !tt.wantErr {
+				mockRepo.On("ListNear", mock.Anything, tt.near, tt.wantLimit, 0).Return(nearby, 2, nil)
+			}
+
+			service := NewStoreService(mockRepo, new(mocks.MockCache))
+
+			stores, total, err := service.StoresNear(context.Background(), tt.near, tt.limit, 0)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, stores)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, total)
+				assert.Equal(t, "Close", stores[0].Store.Name)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestStoreService_ListAllStores(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -273,6 +336,179 @@ func TestStoreService_ListAllStores(t *testing.T) {
 	}
 }
 
+func TestStoreService_CreateStore_Slug(t *testing.T) {
+	mockRepo := new(mocks.MockStoreRepository)
+	mockCache := new(mocks.MockCache)
+
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *models.Store) bool { return s.Slug == "pet-paradise" })).
+		Return(&pq.Error{Code: "23505", Constraint: repository.StoreSlugConstraint}).Once()
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Store")).Return(nil).Once()
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	service := NewStoreService(mockRepo, mockCache)
+
+	store, err := service.CreateStore(context.Background(), models.CreateStoreInput{Name: "Pet Paradise!", OwnerID: "owner123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "pet-paradise-"+store.ID.String()[:8], store.Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStoreService_UpdateStore(t *testing.T) {
+	storeID := uuid.New()
+	name := "Pet Paradise Downtown"
+	phone := "+1 (555) 010-2030"
+	badSlug := "Not A Slug"
+	empty := ""
+	description := "Old description"
+	closedAt := time.Now()
+	lat, lng := 40.7128, -74.0060
+
+	tests := []struct {
+		name    string
+		owner   string
+		input   models.UpdateStoreInput
+		store   *models.Store
+		wantErr interface{}
+	}{
+		{
+			name:  "updates profile fields",
+			owner: "owner123",
+			input: models.UpdateStoreInput{
+				Name:         &name,
+				Phone:        &phone,
+				Description:  &empty,
+				OpeningHours: []models.OpeningHours{{Day: models.WeekdayMonday, Opens: "09:00", Closes: "17:00"}},
+				Latitude:     &lat,
+				Longitude:    &lng,
+			},
+			store: &models.Store{ID: storeID, Name: "Pet Paradise", OwnerID: "owner123", Description: &description},
+		},
+		{
+			name:    "rejects another merchant",
+			owner:   "owner456",
+			input:   models.UpdateStoreInput{Name: &name},
+			store:   &models.Store{ID: storeID, OwnerID: "owner123"},
+			wantErr: apperrors.StoreNotFoundError{},
+		},
+		{
+			name:    "rejects closed stores",
+			owner:   "owner123",
+			input:   models.UpdateStoreInput{Name: &name},
+			store:   &models.Store{ID: storeID, OwnerID: "owner123", ClosedAt: &closedAt},
+			wantErr: apperrors.BusinessRuleError{},
+		},
+		{
+			name:    "rejects invalid slug",
+			owner:   "owner123",
+			input:   models.UpdateStoreInput{Slug: &badSlug},
+			wantErr: apperrors.ValidationError{},
+		},
+		{
+			name:    "rejects latitude without longitude",
+			owner:   "owner123",
+			input:   models.UpdateStoreInput{Latitude: &lat},
+			wantErr: apperrors.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockStoreRepository)
+			mockCache := new(mocks.MockCache)
+
+			if tt.store != nil {
+				mockRepo.On("GetByID", mock.Anything, storeID).Return(tt.store, nil)
+			}
+			if tt.wantErr == nil {
+				mockRepo.On("Update", mock.Anything, tt.store).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			service := NewStoreService(mockRepo, mockCache)
+
+			store, err := service.UpdateStore(context.Background(), tt.owner, storeID, tt.input)
+
+			if tt.wantErr != nil {
+				cause := err
+				for errors.Unwrap(cause) != nil {
+					cause = errors.Unwrap(cause)
+				}
+				assert.IsType(t, tt.wantErr, cause)
+				assert.Nil(t, store)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, name, store.Name)
+				assert.Equal(t, phone, *store.Phone)
+				assert.Nil(t, store.Description)
+				assert.Len(t, store.OpeningHours, 1)
+				assert.Equal(t, lat, *store.Latitude)
+				assert.Equal(t, lng, *store.Longitude)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStoreService_UpdateStore_SlugTaken(t *testing.T) {
+	storeID := uuid.New()
+	slug := "pet-paradise"
+	mockRepo := new(mocks.MockStoreRepository)
+	mockRepo.On("GetByID", mock.Anything, storeID).Return(&models.Store{ID: storeID, OwnerID: "owner123", Slug: "old-slug"}, nil)
+	mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*models.Store")).
+		Return(&pq.Error{Code: "23505", Constraint: repository.StoreSlugConstraint})
+
+	service := NewStoreService(mockRepo, new(mocks.MockCache))
+
+	_, err := service.UpdateStore(context.Background(), "owner123", storeID, models.UpdateStoreInput{Slug: &slug})
+
+	assert.IsType(t, apperrors.ConflictError{}, err)
+}
+
+func TestStoreService_CloseStore(t *testing.T) {
+	storeID := uuid.New()
+
+	tests := []struct {
+		name       string
+		owner      string
+		openOrders int
+		wantErr    interface{}
+	}{
+		{name: "closes a store without open orders", owner: "owner123"},
+		{name: "refuses while orders are open", owner: "owner123", openOrders: 2, wantErr: apperrors.BusinessRuleError{}},
+		{name: "rejects another merchant", owner: "owner456", wantErr: apperrors.StoreNotFoundError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockStoreRepository)
+			mockCache := new(mocks.MockCache)
+
+			mockRepo.On("Transaction", mock.Anything).Return(nil)
+			mockRepo.On("GetByIDForUpdateWithTx", mock.Anything, mock.Anything, storeID).
+				Return(&models.Store{ID: storeID, OwnerID: "owner123"}, nil)
+			if tt.owner == "owner123" {
+				mockRepo.On("CountOpenOrdersWithTx", mock.Anything, mock.Anything, storeID).Return(tt.openOrders, nil)
+			}
+			if tt.wantErr == nil {
+				mockRepo.On("CloseWithTx", mock.Anything, mock.Anything, storeID, mock.AnythingOfType("time.Time")).Return(nil)
+				mockCache.On("Delete", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			service := NewStoreService(mockRepo, mockCache)
+
+			err := service.CloseStore(context.Background(), tt.owner, storeID)
+
+			if tt.wantErr != nil {
+				assert.IsType(t, tt.wantErr, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
 func TestStoreServiceInterface_Implementation(t *testing.T) {
 	// Test that StoreService implements StoreServiceInterface
 	mockRepo := new(mocks.MockStoreRepository)
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/cache"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/validation"
+	"github.com/google/uuid"
+)
+
+// StoreSettingsServiceInterface defines the interface for store settings operations
+type StoreSettingsServiceInterface interface {
+	GetStoreSettings(ctx context.Context, storeID uuid.UUID) (*models.StoreSettings, error)
+	UpdateStoreSettings(ctx context.Context, ownerID string, storeID uuid.UUID, input models.UpdateStoreSettingsInput) (*models.StoreSettings, error)
+}
+
+// StoreSettingsService implements StoreSettingsServiceInterface
+type StoreSettingsService struct {
+	repo      repository.StoreSettingsRepositoryInterface
+	storeRepo repository.StoreRepositoryInterface
+	cache     cache.CacheInterface
+}
+
+// NewStoreSettingsService creates a new store settings service
+func NewStoreSettingsService(
+	repo repository.StoreSettingsRepositoryInterface,
+	storeRepo repository.StoreRepositoryInterface,
+	cache cache.CacheInterface,
+) *StoreSettingsService {
+	return &StoreSettingsService{
+		repo:      repo,
+		storeRepo: storeRepo,
+		cache:     cache,
+	}
+}
+
+// GetStoreSettings retrieves the business rules of a store with caching
+func (s *StoreSettingsService) GetStoreSettings(ctx context.Context, storeID uuid.UUID) (*models.StoreSettings, error) {
+	cacheKey := cache.StoreSettingsCacheKey(storeID.String())
+	var settings models.StoreSettings
+	if err := s.cache.Get(ctx, cacheKey, &settings); err == nil {
+		return &settings, nil
+	}
+
+	result, err := s.repo.Get(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cacheKey, result, 10*time.Minute)
+
+	return result, nil
+}
+
+// UpdateStoreSettings changes the business rules of a store owned by ownerID
+func (s *StoreSettingsService) UpdateStoreSettings(ctx context.Context, ownerID string, storeID uuid.UUID, input models.UpdateStoreSettingsInput) (*models.StoreSettings, error) {
+	if err := validation.ValidateUpdateStoreSettingsInput(input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	store, err := s.storeRepo.GetByID(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if store.OwnerID != ownerID {
+		return nil, apperrors.NewStoreNotFound(storeID)
+	}
+
+	settings, err := s.repo.Get(ctx, storeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.MaxPetsPerOrder != nil {
+		settings.MaxPetsPerOrder = *input.MaxPetsPerOrder
+	}
+	if input.MaxPetAge != nil {
+		settings.MaxPetAge = *input.MaxPetAge
+	}
+	if input.MaxPageSize != nil {
+		settings.MaxPageSize = *input.MaxPageSize
+	}
+	if input.MinCustomerAge != nil {
+		settings.MinCustomerAge = *input.MinCustomerAge
+	}
+	if input.ReservationHoldHours != nil {
+		settings.ReservationHoldHours = *input.ReservationHoldHours
+	}
+	if input.ShowBreederNames != nil {
+		settings.ShowBreederNames = *input.ShowBreederNames
+	}
+	if input.StaleAfterDays != nil {
+		settings.StaleAfterDays = *input.StaleAfterDays
+	}
+	if input.Timezone != nil {
+		settings.Timezone = *input.Timezone
+	}
+
+	if err := s.repo.Save(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Delete(ctx, cache.StoreSettingsCacheKey(storeID.String()))
+
+	return settings, nil
+}
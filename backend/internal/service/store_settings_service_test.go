@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// defaultSettingsService returns a settings mock serving the default rules for any store
+func defaultSettingsService() *mocks.MockStoreSettingsService {
+	settings := new(mocks.MockStoreSettingsService)
+	settings.On("GetStoreSettings", mock.Anything, mock.Anything).Return(models.DefaultStoreSettings(uuid.Nil), nil).Maybe()
+	return settings
+}
+
+func TestStoreSettingsService_GetStoreSettings(t *testing.T) {
+	storeID := uuid.New()
+
+	t.Run("served from cache", func(t *testing.T) {
+		repo := new(mocks.MockStoreSettingsRepository)
+		cache := new(mocks.MockCache)
+		cache.On("Get", mock.Anything, "store_settings:"+storeID.String(), mock.Anything).Run(func(args mock.Arguments) {
+			settings := args[2].(*models.StoreSettings)
+			settings.StoreID = storeID
+			settings.MaxPetsPerOrder = 3
+		}).Return(nil)
+
+		service := NewStoreSettingsService(repo, new(mocks.MockStoreRepository), cache)
+
+		settings, err := service.GetStoreSettings(context.Background(), storeID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, settings.MaxPetsPerOrder)
+		repo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("loaded from repository on cache miss", func(t *testing.T) {
+		repo := new(mocks.MockStoreSettingsRepository)
+		cache := new(mocks.MockCache)
+		cache.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("cache miss"))
+		repo.On("Get", mock.Anything, storeID).Return(models.DefaultStoreSettings(storeID), nil)
+		cache.On("Set", mock.Anything, "store_settings:"+storeID.String(), mock.Anything, mock.Anything).Return(nil)
+
+		service := NewStoreSettingsService(repo, new(mocks.MockStoreRepository), cache)
+
+		settings, err := service.GetStoreSettings(context.Background(), storeID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.DefaultMaxPetsPerOrder, settings.MaxPetsPerOrder)
+		repo.AssertExpectations(t)
+		cache.AssertExpectations(t)
+	})
+}
+
+func TestStoreSettingsService_UpdateStoreSettings(t *testing.T) {
+	storeID := uuid.New()
+	five := 5
+	zero := 0
+	hideBreeders := false
+
+	tests := []struct {
+		name    string
+		owner   string
+		input   models.UpdateStoreSettingsInput
+		wantErr interface{}
+	}{
+		{
+			name:  "updates the given fields",
+			owner: "owner123",
+			input: models.UpdateStoreSettingsInput{MaxPetsPerOrder: &five, ShowBreederNames: &hideBreeders},
+		},
+		{
+			name:    "rejects another merchant",
+			owner:   "owner456",
+			input:   models.UpdateStoreSettingsInput{MaxPetsPerOrder: &five},
+			wantErr: apperrors.StoreNotFoundError{},
+		},
+		{
+			name:    "rejects invalid limits",
+			owner:   "owner123",
+			input:   models.UpdateStoreSettingsInput{MaxPetsPerOrder: &zero},
+			wantErr: apperrors.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockStoreSettingsRepository)
+			storeRepo := new(mocks.MockStoreRepository)
+			cache := new(mocks.MockCache)
+
+			if tt.wantErr != (apperrors.ValidationError{}) {
+				storeRepo.On("GetByID", mock.Anything, storeID).Return(&models.Store{ID: storeID, OwnerID: "owner123"}, nil)
+			}
+			if tt.wantErr == nil {
+				repo.On("Get", mock.Anything, storeID).Return(models.DefaultStoreSettings(storeID), nil)
+				repo.On("Save", mock.Anything, mock.MatchedBy(func(settings *models.StoreSettings) bool {
+					return settings.MaxPetsPerOrder == 5 && !settings.ShowBreederNames &&
+						settings.MaxPetAge == models.DefaultMaxPetAge
+				})).Return(nil)
+				cache.On("Delete", mock.Anything, "store_settings:"+storeID.String()).Return(nil)
+			}
+
+			service := NewStoreSettingsService(repo, storeRepo, cache)
+
+			settings, err := service.UpdateStoreSettings(context.Background(), tt.owner, storeID, tt.input)
+
+			if tt.wantErr != nil {
+				cause := err
+				for errors.Unwrap(cause) != nil {
+					cause = errors.Unwrap(cause)
+				}
+				assert.IsType(t, tt.wantErr, cause)
+				assert.Nil(t, settings)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 5, settings.MaxPetsPerOrder)
+			}
+
+			repo.AssertExpectations(t)
+			storeRepo.AssertExpectations(t)
+			cache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStoreSettingsServiceInterface_Implementation(t *testing.T) {
+	var _ StoreSettingsServiceInterface = NewStoreSettingsService(new(mocks.MockStoreSettingsRepository), new(mocks.MockStoreRepository), new(mocks.MockCache))
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/cache"
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/storefront"
+	"github.com/google/uuid"
+)
+
+// errSitemapFull stops streaming pets once the sitemap holds the maximum number of URLs
+var errSitemapFull = errors.New("sitemap is full")
+
+// sitemapTTL is how long a rendered sitemap is served before it is rebuilt
+const sitemapTTL = time.Hour
+
+// StorefrontServiceInterface defines the interface for the public storefront pages
+type StorefrontServiceInterface interface {
+	StorePage(ctx context.Context, slug string, page int) ([]byte, error)
+	PetPage(ctx context.Context, petID uuid.UUID) ([]byte, error)
+	Sitemap(ctx context.Context) ([]byte, error)
+}
+
+// StorefrontService renders the public HTML pages of open stores and their available pets.
+// It applies the same access rules as the availablePets query.
+type StorefrontService struct {
+	storeRepo repository.StoreRepositoryInterface
+	petRepo   repository.PetRepositoryInterface
+	cache     cache.CacheInterface
+	settings  StoreSettingsServiceInterface
+	baseURL   string
+}
+
+// NewStorefrontService creates a new storefront service; baseURL is the public origin used in links
+func NewStorefrontService(
+	storeRepo repository.StoreRepositoryInterface,
+	petRepo repository.PetRepositoryInterface,
+	cache cache.CacheInterface,
+	settings StoreSettingsServiceInterface,
+	baseURL string,
+) *StorefrontService {
+	return &StorefrontService{
+		storeRepo: storeRepo,
+		petRepo:   petRepo,
+		cache:     cache,
+		settings:  settings,
+		baseURL:   baseURL,
+	}
+}
+
+// StorePage renders one page of an open store's available pets
+func (s *StorefrontService) StorePage(ctx context.Context, slug string, page int) ([]byte, error) {
+	if page < 1 {
+		return nil, apperrors.NewValidationError("page", "page must be at least 1")
+	}
+
+	store, err := s.storeRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if !store.IsOpen() {
+		return nil, apperrors.NotFoundError{Resource: "store", ID: slug}
+	}
+
+	showBreederNames, err := s.showBreederNames(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.PetStatusAvailable
+	filter := models.PetFilter{
+		StoreID: &store.ID,
+		Status:  &status,
+		Limit:   storefront.PetsPerPage,
+		Offset:  (page - 1) * storefront.PetsPerPage,
+	}
+	pets, totalCount, err := s.petRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(pets) == 0 && page > 1 {
+		return nil, apperrors.NotFoundError{Resource: "store page", ID: slug}
+	}
+
+	view := &storefront.StorePage{
+		BaseURL: s.baseURL,
+		Store:   s.storeView(store),
+		Page:    page,
+		HasNext: filter.Offset+len(pets) < totalCount,
+		Pets:    make([]storefront.Pet, 0, len(pets)),
+	}
+	for _, pet := range pets {
+		view.Pets = append(view.Pets, s.petView(pet, showBreederNames))
+	}
+
+	return storefront.RenderStorePage(view)
+}
+
+// PetPage renders the page of an available pet in an open store
+func (s *StorefrontService) PetPage(ctx context.Context, petID uuid.UUID) ([]byte, error) {
+	pet, err := s.petRepo.GetByID(ctx, petID)
+	if err != nil {
+		return nil, err
+	}
+	if pet.Status != models.PetStatusAvailable {
+		return nil, apperrors.NewPetNotFound(petID)
+	}
+
+	store, err := s.storeRepo.GetByID(ctx, pet.StoreID)
+	if err != nil {
+		return nil, err
+	}
+	if !store.IsOpen() {
+		return nil, apperrors.NewPetNotFound(petID)
+	}
+
+	showBreederNames, err := s.showBreederNames(ctx, store.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return storefront.RenderPetPage(&storefront.PetPage{
+		BaseURL: s.baseURL,
+		Store:   s.storeView(store),
+		Pet:     s.petView(pet, showBreederNames),
+	})
+}
+
+// Sitemap returns sitemap.xml with every open store and its available pets, up to
+// storefront.MaxSitemapURLs entries. Building it reads every available pet, so the
+// rendered document is cached for sitemapTTL.
+func (s *StorefrontService) Sitemap(ctx context.Context) ([]byte, error) {
+	var cached []byte
+	if err := s.cache.Get(ctx, cache.SitemapCacheKey(), &cached); err == nil {
+		return cached, nil
+	}
+
+	body, err := s.buildSitemap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Set(ctx, cache.SitemapCacheKey(), body, sitemapTTL)
+	return body, nil
+}
+
+// buildSitemap renders sitemap.xml from the open stores and their available pets
+func (s *StorefrontService) buildSitemap(ctx context.Context) ([]byte, error) {
+	stores, err := s.storeRepo.ListOpen(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []storefront.SitemapURL
+	for _, store := range stores {
+		if len(urls) >= storefront.MaxSitemapURLs {
+			break
+		}
+		urls = append(urls, storefront.SitemapURL{
+			Loc:     storefront.StoreURL(s.baseURL, store.Slug),
+			LastMod: store.UpdatedAt,
+		})
+	}
+
+	status := models.PetStatusAvailable
+	for _, store := range stores {
+		filter := models.PetFilter{StoreID: &store.ID, Status: &status}
+		err := s.petRepo.Stream(ctx, filter, func(pet *models.Pet) error {
+			if len(urls) >= storefront.MaxSitemapURLs {
+				return errSitemapFull
+			}
+			urls = append(urls, storefront.SitemapURL{
+				Loc:     storefront.PetURL(s.baseURL, pet.ID),
+				LastMod: pet.UpdatedAt,
+			})
+			return nil
+		})
+		if errors.Is(err, errSitemapFull) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	return storefront.RenderSitemap(urls)
+}
+
+func (s *StorefrontService) showBreederNames(ctx context.Context, storeID uuid.UUID) (bool, error) {
+	settings, err := s.settings.GetStoreSettings(ctx, storeID)
+	if err != nil {
+		return false, err
+	}
+	return settings.ShowBreederNames, nil
+}
+
+func (s *StorefrontService) storeView(store *models.Store) storefront.Store {
+	view := storefront.Store{
+		Name:      store.Name,
+		Slug:      store.Slug,
+		Latitude:  store.Latitude,
+		Longitude: store.Longitude,
+	}
+	if store.Description != nil {
+		view.Description = *store.Description
+	}
+	if store.Address != nil {
+		view.Address = *store.Address
+	}
+	if store.Phone != nil {
+		view.Phone = *store.Phone
+	}
+	if store.LogoURL != nil {
+		view.LogoURL = storefront.AbsoluteURL(s.baseURL, *store.LogoURL)
+	}
+	for _, hours := range store.OpeningHours {
+		view.OpeningHours = append(view.OpeningHours, storefront.OpeningHours{
+			Day:    string(hours.Day),
+			Opens:  hours.Opens,
+			Closes: hours.Closes,
+		})
+	}
+	return view
+}
+
+func (s *StorefrontService) petView(pet *models.Pet, showBreederName bool) storefront.Pet {
+	view := storefront.Pet{
+		ID:         pet.ID,
+		Name:       pet.Name,
+		Species:    string(pet.Species),
+		Age:        pet.Age,
+		PriceCents: pet.PriceCents,
+		ListedAt:   pet.CreatedAt,
+	}
+	if pet.Description != nil {
+		view.Description = *pet.Description
+	}
+	if pet.PictureURL != nil {
+		view.PictureURL = storefront.AbsoluteURL(s.baseURL, *pet.PictureURL)
+	}
+	if showBreederName {
+		view.BreederName = pet.BreederName
+	}
+	return view
+}
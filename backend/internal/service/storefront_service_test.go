@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/storefront"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const storefrontBaseURL = "https://pets.example.com"
+
+func TestStorefrontService_StorePage(t *testing.T) {
+	storeID := uuid.New()
+	openStore := &models.Store{ID: storeID, Name: "Paws", Slug: "paws"}
+	closedAt := time.Now()
+	closedStore := &models.Store{ID: storeID, Name: "Paws", Slug: "paws", ClosedAt: &closedAt}
+	pet := &models.Pet{ID: uuid.New(), StoreID: storeID, Name: "Tom", Species: models.PetSpeciesCat, Age: 2,
+		BreederName: "Alice", Status: models.PetStatusAvailable, PriceCents: 15000}
+
+	tests := []struct {
+		name       string
+		page       int
+		setupMocks func(*mocks.MockStoreRepository, *mocks.MockPetRepository, *mocks.MockStoreSettingsService)
+		contains   []string
+		wantErr    error
+	}{
+		{
+			name: "lists available pets with pagination",
+			page: 1,
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				storeRepo.On("GetBySlug", mock.Anything, "paws").Return(openStore, nil)
+				settings.On("GetStoreSettings", mock.Anything, storeID).Return(models.DefaultStoreSettings(storeID), nil)
+				petRepo.On("List", mock.Anything, mock.MatchedBy(func(f models.PetFilter) bool {
+					return *f.StoreID == storeID && *f.Status == models.PetStatusAvailable &&
+						f.Limit == storefront.PetsPerPage && f.Offset == 0
+				})).Return([]*models.Pet{pet}, 30, nil)
+			},
+			contains: []string{"<h1>Paws</h1>", "Tom", `<link rel="next" href="https://pets.example.com/stores/paws?page=2">`},
+		},
+		{
+			name: "closed store is not found",
+			page: 1,
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				storeRepo.On("GetBySlug", mock.Anything, "paws").Return(closedStore, nil)
+			},
+			wantErr: apperrors.NotFoundError{Resource: "store", ID: "paws"},
+		},
+		{
+			name: "page past the end is not found",
+			page: 5,
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				storeRepo.On("GetBySlug", mock.Anything, "paws").Return(openStore, nil)
+				settings.On("GetStoreSettings", mock.Anything, storeID).Return(models.DefaultStoreSettings(storeID), nil)
+				petRepo.On("List", mock.Anything, mock.AnythingOfType("models.PetFilter")).Return([]*models.Pet{}, 30, nil)
+			},
+			wantErr: apperrors.NotFoundError{Resource: "store page", ID: "paws"},
+		},
+		{
+			name:       "invalid page",
+			page:       0,
+			setupMocks: func(*mocks.MockStoreRepository, *mocks.MockPetRepository, *mocks.MockStoreSettingsService) {},
+			wantErr:    apperrors.NewValidationError("page", "page must be at least 1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storeRepo := new(mocks.MockStoreRepository)
+			petRepo := new(mocks.MockPetRepository)
+			settings := new(mocks.MockStoreSettingsService)
+			tt.setupMocks(storeRepo, petRepo, settings)
+
+			service := NewStorefrontService(storeRepo, petRepo, new(mocks.MockCache), settings, storefrontBaseURL)
+			html, err := service.StorePage(context.Background(), "paws", tt.page)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, string(html), s)
+			}
+			storeRepo.AssertExpectations(t)
+			petRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStorefrontService_PetPage(t *testing.T) {
+	storeID := uuid.New()
+	petID := uuid.New()
+	openStore := &models.Store{ID: storeID, Name: "Paws", Slug: "paws"}
+	closedAt := time.Now()
+	closedStore := &models.Store{ID: storeID, Name: "Paws", Slug: "paws", ClosedAt: &closedAt}
+	picture := "/uploads/tom.jpg"
+	available := &models.Pet{ID: petID, StoreID: storeID, Name: "Tom", Species: models.PetSpeciesCat, Age: 2,
+		BreederName: "Alice", Status: models.PetStatusAvailable, PriceCents: 15000, PictureURL: &picture}
+	sold := &models.Pet{ID: petID, StoreID: storeID, Name: "Tom", Status: models.PetStatusSold}
+
+	hidden := models.DefaultStoreSettings(storeID)
+	hidden.ShowBreederNames = false
+
+	tests := []struct {
+		name       string
+		setupMocks func(*mocks.MockStoreRepository, *mocks.MockPetRepository, *mocks.MockStoreSettingsService)
+		contains   []string
+		excludes   []string
+		wantErr    error
+	}{
+		{
+			name: "renders available pet",
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				petRepo.On("GetByID", mock.Anything, petID).Return(available, nil)
+				storeRepo.On("GetByID", mock.Anything, storeID).Return(openStore, nil)
+				settings.On("GetStoreSettings", mock.Anything, storeID).Return(models.DefaultStoreSettings(storeID), nil)
+			},
+			contains: []string{
+				"<title>Tom the Cat | Paws</title>",
+				"bred by Alice",
+				`<meta property="og:image" content="https://pets.example.com/uploads/tom.jpg">`,
+			},
+		},
+		{
+			name: "hides breeder name when the store does",
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				petRepo.On("GetByID", mock.Anything, petID).Return(available, nil)
+				storeRepo.On("GetByID", mock.Anything, storeID).Return(openStore, nil)
+				settings.On("GetStoreSettings", mock.Anything, storeID).Return(hidden, nil)
+			},
+			excludes: []string{"Alice"},
+		},
+		{
+			name: "sold pet is not found",
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				petRepo.On("GetByID", mock.Anything, petID).Return(sold, nil)
+			},
+			wantErr: apperrors.NewPetNotFound(petID),
+		},
+		{
+			name: "pet of a closed store is not found",
+			setupMocks: func(storeRepo *mocks.MockStoreRepository, petRepo *mocks.MockPetRepository, settings *mocks.MockStoreSettingsService) {
+				petRepo.On("GetByID", mock.Anything, petID).Return(available, nil)
+				storeRepo.On("GetByID", mock.Anything, storeID).Return(closedStore, nil)
+			},
+			wantErr: apperrors.NewPetNotFound(petID),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storeRepo := new(mocks.MockStoreRepository)
+			petRepo := new(mocks.MockPetRepository)
+			settings := new(mocks.MockStoreSettingsService)
+			tt.setupMocks(storeRepo, petRepo, settings)
+
+			service := NewStorefrontService(storeRepo, petRepo, new(mocks.MockCache), settings, storefrontBaseURL)
+			html, err := service.PetPage(context.Background(), petID)
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, string(html), s)
+			}
+			for _, s := range tt.excludes {
+				assert.NotContains(t, string(html), s)
+			}
+		})
+	}
+}
+
+func TestStorefrontService_Sitemap(t *testing.T) {
+	storeID := uuid.New()
+	store := &models.Store{ID: storeID, Name: "Paws", Slug: "paws", UpdatedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)}
+	pet := &models.Pet{ID: uuid.New(), StoreID: storeID, Status: models.PetStatusAvailable, UpdatedAt: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)}
+
+	storeRepo := new(mocks.MockStoreRepository)
+	petRepo := new(mocks.MockPetRepository)
+	storeRepo.On("ListOpen", mock.Anything).Return([]*models.Store{store}, nil)
+	petRepo.On("Stream", mock.Anything, mock.MatchedBy(func(f models.PetFilter) bool {
+		return *f.StoreID == storeID && *f.Status == models.PetStatusAvailable
+	}), mock.Anything).Run(streamPets(pet)).Return(nil)
+
+	cacheMock := new(mocks.MockCache)
+	cacheMock.On("Get", mock.Anything, "sitemap", mock.Anything).Return(errors.New("key not found"))
+	cacheMock.On("Set", mock.Anything, "sitemap", mock.AnythingOfType("[]uint8"), sitemapTTL).Return(nil)
+
+	service := NewStorefrontService(storeRepo, petRepo, cacheMock, defaultSettingsService(), storefrontBaseURL)
+	xml, err := service.Sitemap(context.Background())
+
+	require.NoError(t, err)
+	body := string(xml)
+	assert.Contains(t, body, "<loc>https://pets.example.com/stores/paws</loc>\n    <lastmod>2025-03-01</lastmod>")
+	assert.Contains(t, body, "<loc>https://pets.example.com/pets/"+pet.ID.String()+"</loc>\n    <lastmod>2025-03-02</lastmod>")
+	assert.Equal(t, 2, strings.Count(body, "<url>"))
+	cacheMock.AssertExpectations(t)
+}
+
+func TestStorefrontService_SitemapFromCache(t *testing.T) {
+	cached := []byte("<urlset></urlset>")
+	cacheMock := new(mocks.MockCache)
+	cacheMock.On("Get", mock.Anything, "sitemap", mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(2).(*[]byte) = cached
+	}).Return(nil)
+	storeRepo := new(mocks.MockStoreRepository)
+
+	service := NewStorefrontService(storeRepo, new(mocks.MockPetRepository), cacheMock, defaultSettingsService(), storefrontBaseURL)
+	xml, err := service.Sitemap(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, cached, xml)
+	storeRepo.AssertNotCalled(t, "ListOpen", mock.Anything)
+}
+
+func TestStorefrontServiceInterface_Implementation(t *testing.T) {
+	var _ StorefrontServiceInterface = NewStorefrontService(nil, nil, nil, nil, "")
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/repository"
+	"github.com/fehepe/pet-store/backend/internal/validation"
+	"github.com/fehepe/pet-store/backend/internal/webhook"
+	"github.com/fehepe/pet-store/backend/pkg/encryption"
+	"github.com/google/uuid"
+)
+
+const (
+	// maxWebhookAttempts is how often a delivery is tried before it is marked failed
+	maxWebhookAttempts = 8
+	// webhookRetryBase is the wait after the first failed attempt; it doubles with every retry
+	webhookRetryBase = 30 * time.Second
+	// webhookRetryMax caps the wait between attempts
+	webhookRetryMax = 6 * time.Hour
+	// webhookBatchSize is how many deliveries are claimed at once
+	webhookBatchSize = 20
+	// webhookLease keeps claimed deliveries from other dispatchers while a batch is sent
+	webhookLease = 5 * time.Minute
+)
+
+// WebhookServiceInterface defines the interface for outgoing webhook operations
+type WebhookServiceInterface interface {
+	CreateEndpoint(ctx context.Context, input models.CreateWebhookEndpointInput) (*models.WebhookEndpoint, string, error)
+	ListEndpoints(ctx context.Context, storeID uuid.UUID) ([]*models.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, storeID, endpointID uuid.UUID) error
+	ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int, error)
+	PublishPetCreated(ctx context.Context, tx *sql.Tx, pet *models.Pet) error
+	PublishOrderCreated(ctx context.Context, tx *sql.Tx, order *models.Order, items []*models.OrderItem) error
+	DispatchDue(ctx context.Context) (int, error)
+}
+
+// WebhookService queues store events for merchants' endpoints and delivers them with retries
+type WebhookService struct {
+	repo         repository.WebhookRepositoryInterface
+	encryptor    encryption.EncryptorInterface
+	sender       webhook.Sender
+	allowPrivate bool // accepts http and local endpoint URLs, for development
+	now          func() time.Time
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(repo repository.WebhookRepositoryInterface, encryptor encryption.EncryptorInterface, sender webhook.Sender, allowPrivate bool) *WebhookService {
+	return &WebhookService{
+		repo:         repo,
+		encryptor:    encryptor,
+		sender:       sender,
+		allowPrivate: allowPrivate,
+		now:          time.Now,
+	}
+}
+
+// CreateEndpoint registers an endpoint and returns it with its signing secret.
+// Only the encrypted secret is stored, so this is the one time it is shown.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, input models.CreateWebhookEndpointInput) (*models.WebhookEndpoint, string, error) {
+	input.URL = strings.TrimSpace(input.URL)
+	if err := validation.ValidateCreateWebhookEndpointInput(input, s.allowPrivate); err != nil {
+		return nil, "", fmt.Errorf("invalid input: %w", err)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	encryptedSecret, err := s.encryptor.Encrypt(secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	endpoint := &models.WebhookEndpoint{
+		ID:              uuid.New(),
+		StoreID:         input.StoreID,
+		URL:             input.URL,
+		SecretEncrypted: encryptedSecret,
+		Active:          true,
+		CreatedAt:       s.now().UTC(),
+	}
+	for _, eventType := range input.EventTypes {
+		if !endpoint.Subscribes(eventType) {
+			endpoint.EventTypes = append(endpoint.EventTypes, eventType)
+		}
+	}
+
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, "", err
+	}
+
+	return endpoint, secret, nil
+}
+
+// ListEndpoints lists a store's webhook endpoints
+func (s *WebhookService) ListEndpoints(ctx context.Context, storeID uuid.UUID) ([]*models.WebhookEndpoint, error) {
+	return s.repo.ListEndpoints(ctx, storeID)
+}
+
+// DeleteEndpoint removes a store's webhook endpoint; its pending deliveries are dropped
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, storeID, endpointID uuid.UUID) error {
+	return s.repo.DeleteEndpoint(ctx, storeID, endpointID)
+}
+
+// ListDeliveries returns a page of a store's delivery log
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter models.WebhookDeliveryFilter) ([]*models.WebhookDelivery, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = 20
+	}
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	return s.repo.ListDeliveries(ctx, filter)
+}
+
+// PublishPetCreated queues a pet.created event for the pet's store in the transaction that creates the pet
+func (s *WebhookService) PublishPetCreated(ctx context.Context, tx *sql.Tx, pet *models.Pet) error {
+	return s.publish(ctx, tx, pet.StoreID, models.WebhookEventPetCreated, models.WebhookPetData{
+		ID:          pet.ID,
+		StoreID:     pet.StoreID,
+		Name:        pet.Name,
+		Species:     pet.Species,
+		Age:         pet.Age,
+		PictureURL:  pet.PictureURL,
+		Description: pet.Description,
+		BreederName: pet.BreederName,
+		PriceCents:  pet.PriceCents,
+		Status:      pet.Status,
+		CreatedAt:   pet.CreatedAt,
+	})
+}
+
+// PublishOrderCreated queues an order.created event and a pet.sold event per purchased pet
+// in the transaction that completes the order
+func (s *WebhookService) PublishOrderCreated(ctx context.Context, tx *sql.Tx, order *models.Order, items []*models.OrderItem) error {
+	petIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		petIDs = append(petIDs, item.PetID)
+	}
+
+	if err := s.publish(ctx, tx, order.StoreID, models.WebhookEventOrderCreated, models.WebhookOrderData{
+		ID:            order.ID,
+		StoreID:       order.StoreID,
+		CustomerID:    order.CustomerID,
+		PetIDs:        petIDs,
+		TotalPets:     order.TotalPets,
+		SubtotalCents: order.SubtotalCents(),
+		DiscountCents: order.DiscountCents,
+		TotalCents:    order.TotalCents,
+		PaymentStatus: order.PaymentStatus,
+		CreatedAt:     order.CreatedAt,
+	}); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if err := s.publish(ctx, tx, order.StoreID, models.WebhookEventPetSold, models.WebhookPetSoldData{
+			PetID:   item.PetID,
+			StoreID: order.StoreID,
+			OrderID: order.ID,
+			SoldAt:  item.PurchasedAt,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DispatchDue sends every delivery whose next attempt is due and returns how many were attempted
+func (s *WebhookService) DispatchDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		now := s.now().UTC()
+		deliveries, err := s.repo.ClaimDueDeliveries(ctx, now, now.Add(webhookLease), webhookBatchSize)
+		if err != nil {
+			return attempted, err
+		}
+
+		for _, delivery := range deliveries {
+			if ctx.Err() != nil {
+				// Unsent deliveries are retried once their lease runs out
+				return attempted, ctx.Err()
+			}
+			if err := s.deliver(ctx, delivery); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+
+		if len(deliveries) < webhookBatchSize {
+			return attempted, nil
+		}
+	}
+}
+
+// RunDispatcher sends due deliveries immediately and then every interval until ctx is cancelled
+func (s *WebhookService) RunDispatcher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if attempted, err := s.DispatchDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Webhook dispatch failed: %v", err)
+		} else if attempted > 0 {
+			log.Printf("Webhook dispatch attempted %d deliveries", attempted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliver makes one attempt at a claimed delivery and records the outcome. Failed attempts
+// are retried with exponential backoff until maxWebhookAttempts is reached.
+func (s *WebhookService) deliver(ctx context.Context, delivery *models.WebhookDelivery) error {
+	var status int
+	secret, err := s.encryptor.Decrypt(delivery.SecretEncrypted)
+	if err != nil {
+		err = fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	} else {
+		status, err = s.sender.Send(ctx, webhook.Request{
+			URL:        delivery.URL,
+			Secret:     secret,
+			DeliveryID: delivery.ID.String(),
+			EventType:  string(delivery.EventType),
+			Payload:    delivery.Payload,
+		})
+	}
+
+	attemptedAt := s.now().UTC()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &attemptedAt
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &attemptedAt
+		delivery.LastError = nil
+	case delivery.Attempts >= maxWebhookAttempts:
+		message := err.Error()
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.LastError = &message
+	default:
+		message := err.Error()
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.LastError = &message
+		delivery.NextAttemptAt = attemptedAt.Add(webhookRetryDelay(delivery.Attempts))
+	}
+
+	return s.repo.UpdateDelivery(ctx, delivery)
+}
+
+// publish queues an event for the store's subscribed endpoints within tx
+func (s *WebhookService) publish(ctx context.Context, tx *sql.Tx, storeID uuid.UUID, eventType models.WebhookEventType, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook data: %w", eventType, err)
+	}
+
+	event := &models.WebhookEvent{
+		ID:        uuid.New(),
+		Type:      eventType,
+		StoreID:   storeID,
+		CreatedAt: s.now().UTC(),
+		Data:      encoded,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s webhook event: %w", eventType, err)
+	}
+
+	if _, err := s.repo.EnqueueDeliveriesWithTx(ctx, tx, event, payload); err != nil {
+		return fmt.Errorf("failed to queue %s webhook event: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// webhookRetryDelay is the wait before the next attempt after the given number of failed ones
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+	if delay > webhookRetryMax {
+		delay = webhookRetryMax
+	}
+	return delay
+}
+
+// newWebhookSecret generates a random signing secret
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
@@ -0,0 +1,484 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
+	"github.com/fehepe/pet-store/backend/internal/mocks"
+	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testWebhookSecret = "whsec_test"
+
+// webhookReceiver is an httptest endpoint that records signed deliveries and answers
+// with the next queued status, or 200 once the queue is empty
+type webhookReceiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	received []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header   http.Header
+	body     []byte
+	verified bool
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	receiver := &webhookReceiver{statuses: statuses}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhook.HeaderTimestamp), 10, 64)
+
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		receiver.received = append(receiver.received, receivedWebhook{
+			header:   r.Header.Clone(),
+			body:     body,
+			verified: webhook.Verify(testWebhookSecret, timestamp, body, r.Header.Get(webhook.HeaderSignature)),
+		})
+
+		status := http.StatusOK
+		if len(receiver.statuses) > 0 {
+			status, receiver.statuses = receiver.statuses[0], receiver.statuses[1:]
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *webhookReceiver) requests() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.received...)
+}
+
+func TestWebhookService_CreateEndpoint(t *testing.T) {
+	storeID := uuid.New()
+
+	tests := []struct {
+		name      string
+		input     models.CreateWebhookEndpointInput
+		wantError bool
+	}{
+		{
+			name: "registers the endpoint",
+			input: models.CreateWebhookEndpointInput{
+				StoreID:    storeID,
+				URL:        "  https://hooks.example.com/pets  ",
+				EventTypes: []models.WebhookEventType{models.WebhookEventPetCreated, models.WebhookEventPetSold, models.WebhookEventPetCreated},
+			},
+		},
+		{
+			name: "rejects a relative URL",
+			input: models.CreateWebhookEndpointInput{
+				StoreID:    storeID,
+				URL:        "/hooks",
+				EventTypes: []models.WebhookEventType{models.WebhookEventPetCreated},
+			},
+			wantError: true,
+		},
+		{
+			name: "rejects a private address",
+			input: models.CreateWebhookEndpointInput{
+				StoreID:    storeID,
+				URL:        "https://192.168.1.10/hooks",
+				EventTypes: []models.WebhookEventType{models.WebhookEventPetCreated},
+			},
+			wantError: true,
+		},
+		{
+			name:      "rejects an endpoint without event types",
+			input:     models.CreateWebhookEndpointInput{StoreID: storeID, URL: "https://hooks.example.com"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockWebhookRepository)
+			encryptor := new(mocks.MockEncryptor)
+			encryptor.On("Encrypt", mock.MatchedBy(func(secret string) bool {
+				return strings.HasPrefix(secret, "whsec_")
+			})).Return("encrypted_secret", nil).Maybe()
+			repo.On("CreateEndpoint", mock.Anything, mock.AnythingOfType("*models.WebhookEndpoint")).Return(nil).Maybe()
+
+			service := NewWebhookService(repo, encryptor, new(mocks.MockWebhookSender), false)
+
+			endpoint, secret, err := service.CreateEndpoint(context.Background(), tt.input)
+
+			if tt.wantError {
+				assert.IsType(t, apperrors.ValidationError{}, errors.Unwrap(err))
+				repo.AssertNotCalled(t, "CreateEndpoint", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(secret, "whsec_"))
+			assert.Len(t, secret, len("whsec_")+64)
+			assert.Equal(t, "https://hooks.example.com/pets", endpoint.URL)
+			assert.Equal(t, "encrypted_secret", endpoint.SecretEncrypted)
+			assert.Equal(t, []models.WebhookEventType{models.WebhookEventPetCreated, models.WebhookEventPetSold}, endpoint.EventTypes)
+			assert.True(t, endpoint.Active)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_PublishOrderCreated(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := &models.Order{
+		ID:            uuid.New(),
+		CustomerID:    "customer1",
+		StoreID:       uuid.New(),
+		TotalPets:     2,
+		CreatedAt:     now,
+		TotalCents:    9000,
+		DiscountCents: 1000,
+		PaymentStatus: models.PaymentStatusCaptured,
+	}
+	items := []*models.OrderItem{
+		{ID: uuid.New(), OrderID: order.ID, PetID: uuid.New(), PurchasedAt: now},
+		{ID: uuid.New(), OrderID: order.ID, PetID: uuid.New(), PurchasedAt: now},
+	}
+
+	repo := new(mocks.MockWebhookRepository)
+	var events []*models.WebhookEvent
+	repo.On("EnqueueDeliveriesWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.WebhookEvent"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			event := args.Get(2).(*models.WebhookEvent)
+			var decoded models.WebhookEvent
+			require.NoError(t, json.Unmarshal(args.Get(3).([]byte), &decoded))
+			assert.Equal(t, event.ID, decoded.ID)
+			events = append(events, event)
+		}).Return(1, nil)
+
+	service := NewWebhookService(repo, new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+	service.now = func() time.Time { return now }
+
+	require.NoError(t, service.PublishOrderCreated(context.Background(), nil, order, items))
+
+	require.Len(t, events, 3)
+	assert.Equal(t, models.WebhookEventOrderCreated, events[0].Type)
+	assert.Equal(t, order.StoreID, events[0].StoreID)
+	var orderData models.WebhookOrderData
+	require.NoError(t, json.Unmarshal(events[0].Data, &orderData))
+	assert.Equal(t, order.ID, orderData.ID)
+	assert.Equal(t, []uuid.UUID{items[0].PetID, items[1].PetID}, orderData.PetIDs)
+	assert.Equal(t, int64(10000), orderData.SubtotalCents)
+	assert.Equal(t, int64(9000), orderData.TotalCents)
+
+	for i, item := range items {
+		event := events[i+1]
+		assert.Equal(t, models.WebhookEventPetSold, event.Type)
+		var sold models.WebhookPetSoldData
+		require.NoError(t, json.Unmarshal(event.Data, &sold))
+		assert.Equal(t, item.PetID, sold.PetID)
+		assert.Equal(t, order.ID, sold.OrderID)
+	}
+}
+
+func TestWebhookService_PublishFailsTheTransaction(t *testing.T) {
+	repo := new(mocks.MockWebhookRepository)
+	repo.On("EnqueueDeliveriesWithTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, errors.New("database unavailable"))
+
+	service := NewWebhookService(repo, new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+
+	err := service.PublishPetCreated(context.Background(), nil, &models.Pet{ID: uuid.New(), StoreID: uuid.New(), Name: "Rex"})
+
+	assert.ErrorContains(t, err, "database unavailable")
+	repo.AssertExpectations(t)
+}
+
+func TestPetService_CreatePetQueuesWebhook(t *testing.T) {
+	storeID := uuid.New()
+
+	webhookRepo := new(mocks.MockWebhookRepository)
+	var event *models.WebhookEvent
+	var payload []byte
+	webhookRepo.On("EnqueueDeliveriesWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.WebhookEvent"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			event = args.Get(2).(*models.WebhookEvent)
+			payload = args.Get(3).([]byte)
+		}).Return(1, nil)
+	webhooks := NewWebhookService(webhookRepo, new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+
+	mockRepo := new(mocks.MockPetRepository)
+	mockCache := new(mocks.MockCache)
+	mockEncryptor := new(mocks.MockEncryptor)
+	mockEncryptor.On("Encrypt", "john@example.com").Return("encrypted_email", nil)
+	mockRepo.On("Transaction", mock.Anything).Run(runTransaction).Return(nil)
+	mockRepo.On("CreateWithTx", mock.Anything, mock.Anything, mock.AnythingOfType("*models.Pet")).Return(nil)
+	mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockCache.On("InvalidatePattern", mock.Anything, mock.Anything).Return(nil)
+
+	service := NewPetService(mockRepo, mockCache, mockEncryptor, defaultSettingsService(), testPetEvents(), webhooks)
+
+	pet, err := service.CreatePet(context.Background(), models.CreatePetInput{
+		StoreID:      storeID,
+		Name:         "Fluffy",
+		Species:      models.PetSpeciesCat,
+		Age:          3,
+		BreederName:  "John Doe",
+		BreederEmail: "john@example.com",
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, event)
+	assert.Equal(t, models.WebhookEventPetCreated, event.Type)
+	assert.Equal(t, storeID, event.StoreID)
+	var data models.WebhookPetData
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	assert.Equal(t, pet.ID, data.ID)
+	assert.NotContains(t, string(payload), "john@example.com")
+	assert.NotContains(t, string(payload), "encrypted_email")
+}
+
+func TestWebhookService_DispatchDue(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		respond        int
+		priorAttempts  int
+		wantStatus     models.WebhookDeliveryStatus
+		wantNextWait   time.Duration
+		wantDelivered  bool
+		wantLastError  bool
+		wantRespStatus int
+	}{
+		{
+			name:           "accepted delivery succeeds",
+			respond:        http.StatusNoContent,
+			wantStatus:     models.WebhookDeliverySucceeded,
+			wantDelivered:  true,
+			wantRespStatus: http.StatusNoContent,
+		},
+		{
+			name:           "first failure is retried after the base delay",
+			respond:        http.StatusInternalServerError,
+			wantStatus:     models.WebhookDeliveryPending,
+			wantNextWait:   30 * time.Second,
+			wantLastError:  true,
+			wantRespStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "later failures back off exponentially",
+			respond:        http.StatusServiceUnavailable,
+			priorAttempts:  3,
+			wantStatus:     models.WebhookDeliveryPending,
+			wantNextWait:   4 * time.Minute,
+			wantLastError:  true,
+			wantRespStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "last attempt marks the delivery failed",
+			respond:        http.StatusBadRequest,
+			priorAttempts:  maxWebhookAttempts - 1,
+			wantStatus:     models.WebhookDeliveryFailed,
+			wantLastError:  true,
+			wantRespStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			receiver := newWebhookReceiver(t, tt.respond)
+			payload := []byte(`{"id":"evt","type":"pet.created","data":{}}`)
+			delivery := &models.WebhookDelivery{
+				ID:              uuid.New(),
+				EndpointID:      uuid.New(),
+				EventID:         uuid.New(),
+				EventType:       models.WebhookEventPetCreated,
+				Payload:         payload,
+				Status:          models.WebhookDeliveryPending,
+				Attempts:        tt.priorAttempts,
+				NextAttemptAt:   now.Add(webhookLease),
+				URL:             receiver.URL + "/hooks",
+				SecretEncrypted: "encrypted_secret",
+			}
+
+			repo := new(mocks.MockWebhookRepository)
+			encryptor := new(mocks.MockEncryptor)
+			encryptor.On("Decrypt", "encrypted_secret").Return(testWebhookSecret, nil)
+			repo.On("ClaimDueDeliveries", mock.Anything, now, now.Add(webhookLease), webhookBatchSize).
+				Return([]*models.WebhookDelivery{delivery}, nil)
+			var updated models.WebhookDelivery
+			repo.On("UpdateDelivery", mock.Anything, delivery).Run(func(args mock.Arguments) {
+				updated = *args.Get(1).(*models.WebhookDelivery)
+			}).Return(nil)
+
+			service := NewWebhookService(repo, encryptor, webhook.NewClient(time.Second, true), false)
+			service.now = func() time.Time { return now }
+
+			attempted, err := service.DispatchDue(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, attempted)
+
+			requests := receiver.requests()
+			require.Len(t, requests, 1)
+			assert.True(t, requests[0].verified, "signature should verify with the endpoint secret")
+			assert.Equal(t, payload, requests[0].body)
+			assert.Equal(t, "pet.created", requests[0].header.Get(webhook.HeaderEvent))
+			assert.Equal(t, delivery.ID.String(), requests[0].header.Get(webhook.HeaderDelivery))
+
+			assert.Equal(t, tt.wantStatus, updated.Status)
+			assert.Equal(t, tt.priorAttempts+1, updated.Attempts)
+			require.NotNil(t, updated.LastAttemptAt)
+			assert.Equal(t, now, *updated.LastAttemptAt)
+			require.NotNil(t, updated.ResponseStatus)
+			assert.Equal(t, tt.wantRespStatus, *updated.ResponseStatus)
+			assert.Equal(t, tt.wantDelivered, updated.DeliveredAt != nil)
+			assert.Equal(t, tt.wantLastError, updated.LastError != nil)
+			if tt.wantNextWait > 0 {
+				assert.Equal(t, now.Add(tt.wantNextWait), updated.NextAttemptAt)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_DispatchDueUnreachableEndpoint(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	receiver := newWebhookReceiver(t)
+	url := receiver.URL
+	receiver.Close()
+
+	delivery := &models.WebhookDelivery{
+		ID:              uuid.New(),
+		EventType:       models.WebhookEventOrderCreated,
+		Payload:         []byte(`{}`),
+		Status:          models.WebhookDeliveryPending,
+		URL:             url,
+		SecretEncrypted: "encrypted_secret",
+	}
+
+	repo := new(mocks.MockWebhookRepository)
+	encryptor := new(mocks.MockEncryptor)
+	encryptor.On("Decrypt", "encrypted_secret").Return(testWebhookSecret, nil)
+	repo.On("ClaimDueDeliveries", mock.Anything, now, now.Add(webhookLease), webhookBatchSize).
+		Return([]*models.WebhookDelivery{delivery}, nil)
+	repo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+	service := NewWebhookService(repo, encryptor, webhook.NewClient(time.Second, true), false)
+	service.now = func() time.Time { return now }
+
+	_, err := service.DispatchDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Nil(t, delivery.ResponseStatus)
+	require.NotNil(t, delivery.LastError)
+	assert.Equal(t, now.Add(webhookRetryBase), delivery.NextAttemptAt)
+}
+
+func TestWebhookService_DispatchDueDrainsFullBatches(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	receiver := newWebhookReceiver(t)
+
+	batch := make([]*models.WebhookDelivery, webhookBatchSize)
+	for i := range batch {
+		batch[i] = &models.WebhookDelivery{
+			ID:              uuid.New(),
+			EventType:       models.WebhookEventPetSold,
+			Payload:         []byte(`{}`),
+			Status:          models.WebhookDeliveryPending,
+			URL:             receiver.URL,
+			SecretEncrypted: "encrypted_secret",
+		}
+	}
+
+	repo := new(mocks.MockWebhookRepository)
+	encryptor := new(mocks.MockEncryptor)
+	encryptor.On("Decrypt", "encrypted_secret").Return(testWebhookSecret, nil)
+	repo.On("ClaimDueDeliveries", mock.Anything, now, now.Add(webhookLease), webhookBatchSize).Return(batch, nil).Once()
+	repo.On("ClaimDueDeliveries", mock.Anything, now, now.Add(webhookLease), webhookBatchSize).Return([]*models.WebhookDelivery(nil), nil).Once()
+	repo.On("UpdateDelivery", mock.Anything, mock.AnythingOfType("*models.WebhookDelivery")).Return(nil)
+
+	service := NewWebhookService(repo, encryptor, webhook.NewClient(time.Second, true), false)
+	service.now = func() time.Time { return now }
+
+	attempted, err := service.DispatchDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, webhookBatchSize, attempted)
+	assert.Len(t, receiver.requests(), webhookBatchSize)
+	repo.AssertExpectations(t)
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 5, want: 8 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 20, want: webhookRetryMax},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			assert.Equal(t, tt.want, webhookRetryDelay(tt.attempts))
+		})
+	}
+}
+
+func TestWebhookService_ListDeliveries(t *testing.T) {
+	storeID := uuid.New()
+
+	tests := []struct {
+		name   string
+		filter models.WebhookDeliveryFilter
+		want   models.WebhookDeliveryFilter
+	}{
+		{
+			name:   "defaults the page size",
+			filter: models.WebhookDeliveryFilter{StoreID: storeID},
+			want:   models.WebhookDeliveryFilter{StoreID: storeID, Limit: 20},
+		},
+		{
+			name:   "caps the page size",
+			filter: models.WebhookDeliveryFilter{StoreID: storeID, Limit: 500, Offset: -5},
+			want:   models.WebhookDeliveryFilter{StoreID: storeID, Limit: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.MockWebhookRepository)
+			repo.On("ListDeliveries", mock.Anything, tt.want).Return([]*models.WebhookDelivery{}, 0, nil)
+
+			service := NewWebhookService(repo, new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+
+			_, _, err := service.ListDeliveries(context.Background(), tt.filter)
+
+			require.NoError(t, err)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookServiceInterface_Implementation(t *testing.T) {
+	var _ WebhookServiceInterface = NewWebhookService(new(mocks.MockWebhookRepository), new(mocks.MockEncryptor), new(mocks.MockWebhookSender), false)
+}
@@ -0,0 +1,336 @@
+package storefront
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//go:embed templates/*
+var templateFS embed.FS
+
+// PetsPerPage is how many pets a store page lists
+const PetsPerPage = 24
+
+// MaxSitemapURLs is the most URLs a single sitemap may hold
+const MaxSitemapURLs = 50000
+
+// Currency is the currency pet prices are published in
+const Currency = "USD"
+
+// OpeningHours is a day a store is open
+type OpeningHours struct {
+	Day    string
+	Opens  string
+	Closes string
+}
+
+// Store is the public profile of an open store
+type Store struct {
+	Name         string
+	Slug         string
+	Description  string
+	Address      string
+	Phone        string
+	LogoURL      string
+	Latitude     *float64
+	Longitude    *float64
+	OpeningHours []OpeningHours
+}
+
+// Pet is an available pet as shown to the public; BreederName is empty when the store hides it
+type Pet struct {
+	ID          uuid.UUID
+	Name        string
+	Species     string
+	Age         int
+	Description string
+	PictureURL  string
+	BreederName string
+	PriceCents  int64
+	ListedAt    time.Time
+}
+
+// StorePage is one page of a store's available pets
+type StorePage struct {
+	BaseURL string
+	Store   Store
+	Pets    []Pet
+	Page    int // 1-based
+	HasNext bool
+}
+
+// PetPage is the page of a single available pet
+type PetPage struct {
+	BaseURL string
+	Store   Store
+	Pet     Pet
+}
+
+// SitemapURL is one entry of sitemap.xml
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time
+}
+
+// Meta holds the SEO metadata rendered in the page head
+type Meta struct {
+	Title       string
+	Description string
+	URL         string
+	PrevURL     string
+	NextURL     string
+	Image       string
+	Type        string
+	JSONLD      template.JS
+}
+
+var funcs = template.FuncMap{
+	"money":    FormatPrice,
+	"storeURL": StoreURL,
+	"petURL":   PetURL,
+	"years": func(age int) string {
+		if age == 1 {
+			return "1 year old"
+		}
+		return fmt.Sprintf("%d years old", age)
+	},
+}
+
+var (
+	storeTemplate = template.Must(template.New("store").Funcs(funcs).ParseFS(templateFS, "templates/layout.html.tmpl", "templates/store.html.tmpl"))
+	petTemplate   = template.Must(template.New("pet").Funcs(funcs).ParseFS(templateFS, "templates/layout.html.tmpl", "templates/pet.html.tmpl"))
+)
+
+// StoreURL returns the public URL of a store page
+func StoreURL(baseURL, slug string) string {
+	return strings.TrimRight(baseURL, "/") + "/stores/" + url.PathEscape(slug)
+}
+
+// PetURL returns the public URL of a pet page
+func PetURL(baseURL string, petID uuid.UUID) string {
+	return strings.TrimRight(baseURL, "/") + "/pets/" + petID.String()
+}
+
+// AbsoluteURL resolves a possibly relative link, such as an uploaded picture, against baseURL
+func AbsoluteURL(baseURL, link string) string {
+	if link == "" {
+		return ""
+	}
+	base, err := url.Parse(strings.TrimRight(baseURL, "/") + "/")
+	if err != nil {
+		return link
+	}
+	ref, err := url.Parse(link)
+	if err != nil {
+		return link
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// FormatPrice formats an amount in cents as dollars
+func FormatPrice(cents int64) string {
+	return fmt.Sprintf("$%d.%02d", cents/100, cents%100)
+}
+
+// RenderStorePage renders a store page as HTML
+func RenderStorePage(page *StorePage) ([]byte, error) {
+	pageURL := func(n int) string {
+		if n <= 1 {
+			return StoreURL(page.BaseURL, page.Store.Slug)
+		}
+		return fmt.Sprintf("%s?page=%d", StoreURL(page.BaseURL, page.Store.Slug), n)
+	}
+
+	description := page.Store.Description
+	if description == "" {
+		description = fmt.Sprintf("Browse the pets available for adoption at %s.", page.Store.Name)
+	}
+
+	meta := Meta{
+		Title:       page.Store.Name,
+		Description: description,
+		URL:         pageURL(page.Page),
+		Image:       AbsoluteURL(page.BaseURL, page.Store.LogoURL),
+		Type:        "website",
+	}
+	if page.Page > 1 {
+		meta.Title = fmt.Sprintf("%s (page %d)", page.Store.Name, page.Page)
+		meta.PrevURL = pageURL(page.Page - 1)
+	}
+	if page.HasNext {
+		meta.NextURL = pageURL(page.Page + 1)
+	}
+
+	items := make([]any, 0, len(page.Pets))
+	for i, pet := range page.Pets {
+		items = append(items, map[string]any{
+			"@type":    "ListItem",
+			"position": (page.Page-1)*PetsPerPage + i + 1,
+			"url":      PetURL(page.BaseURL, pet.ID),
+			"name":     pet.Name,
+		})
+	}
+	storeLD := storeJSONLD(page.BaseURL, page.Store)
+	storeLD["@context"] = "https://schema.org"
+	storeLD["makesOffer"] = map[string]any{"@type": "ItemList", "itemListElement": items}
+
+	var err error
+	if meta.JSONLD, err = encodeJSONLD(storeLD); err != nil {
+		return nil, err
+	}
+
+	return render(storeTemplate, struct {
+		*StorePage
+		Meta Meta
+	}{page, meta})
+}
+
+// RenderPetPage renders a pet page as HTML
+func RenderPetPage(page *PetPage) ([]byte, error) {
+	pet := page.Pet
+	description := pet.Description
+	if description == "" {
+		description = fmt.Sprintf("%s is a %d year old %s available at %s for %s.",
+			pet.Name, pet.Age, strings.ToLower(pet.Species), page.Store.Name, FormatPrice(pet.PriceCents))
+	}
+
+	meta := Meta{
+		Title:       fmt.Sprintf("%s the %s | %s", pet.Name, pet.Species, page.Store.Name),
+		Description: description,
+		URL:         PetURL(page.BaseURL, pet.ID),
+		Image:       AbsoluteURL(page.BaseURL, pet.PictureURL),
+		Type:        "product",
+	}
+
+	product := map[string]any{
+		"@context":    "https://schema.org",
+		"@type":       "Product",
+		"name":        pet.Name,
+		"description": description,
+		"category":    pet.Species,
+		"url":         meta.URL,
+		"offers": map[string]any{
+			"@type":         "Offer",
+			"price":         fmt.Sprintf("%d.%02d", pet.PriceCents/100, pet.PriceCents%100),
+			"priceCurrency": Currency,
+			"availability":  "https://schema.org/InStock",
+			"url":           meta.URL,
+			"seller":        storeJSONLD(page.BaseURL, page.Store),
+		},
+	}
+	if meta.Image != "" {
+		product["image"] = meta.Image
+	}
+
+	var err error
+	if meta.JSONLD, err = encodeJSONLD(product); err != nil {
+		return nil, err
+	}
+
+	return render(petTemplate, struct {
+		*PetPage
+		Meta Meta
+	}{page, meta})
+}
+
+// RenderSitemap renders a sitemap.xml document listing the given URLs
+func RenderSitemap(urls []SitemapURL) ([]byte, error) {
+	type entry struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+	set := struct {
+		XMLName xml.Name `xml:"urlset"`
+		XMLNS   string   `xml:"xmlns,attr"`
+		URLs    []entry  `xml:"url"`
+	}{XMLNS: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+
+	for _, u := range urls {
+		e := entry{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			e.LastMod = u.LastMod.UTC().Format("2006-01-02")
+		}
+		set.URLs = append(set.URLs, e)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(set); err != nil {
+		return nil, fmt.Errorf("failed to render sitemap: %w", err)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// storeJSONLD describes a store as a schema.org PetStore
+func storeJSONLD(baseURL string, store Store) map[string]any {
+	ld := map[string]any{
+		"@type": "PetStore",
+		"name":  store.Name,
+		"url":   StoreURL(baseURL, store.Slug),
+	}
+	if store.Description != "" {
+		ld["description"] = store.Description
+	}
+	if store.Address != "" {
+		ld["address"] = store.Address
+	}
+	if store.Phone != "" {
+		ld["telephone"] = store.Phone
+	}
+	if store.LogoURL != "" {
+		ld["logo"] = AbsoluteURL(baseURL, store.LogoURL)
+	}
+	if store.Latitude != nil && store.Longitude != nil {
+		ld["geo"] = map[string]any{"@type": "GeoCoordinates", "latitude": *store.Latitude, "longitude": *store.Longitude}
+	}
+	if len(store.OpeningHours) > 0 {
+		hours := make([]any, 0, len(store.OpeningHours))
+		for _, h := range store.OpeningHours {
+			hours = append(hours, map[string]any{
+				"@type":     "OpeningHoursSpecification",
+				"dayOfWeek": "https://schema.org/" + capitalize(h.Day),
+				"opens":     h.Opens,
+				"closes":    h.Closes,
+			})
+		}
+		ld["openingHoursSpecification"] = hours
+	}
+	return ld
+}
+
+// encodeJSONLD encodes structured data for a script tag. json.Marshal escapes <, > and &,
+// so the result cannot close the script element early.
+func encodeJSONLD(data map[string]any) (template.JS, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode structured data: %w", err)
+	}
+	return template.JS(encoded), nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func render(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, "layout", data); err != nil {
+		return nil, fmt.Errorf("failed to render page: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,132 @@
+package storefront
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jsonLDPattern = regexp.MustCompile(`(?s)<script type="application/ld\+json">(.*?)</script>`)
+
+func extractJSONLD(t *testing.T, html []byte) map[string]any {
+	t.Helper()
+	match := jsonLDPattern.FindSubmatch(html)
+	require.NotNil(t, match, "page has no JSON-LD block")
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(match[1], &data))
+	return data
+}
+
+func TestAbsoluteURL(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{name: "empty", link: "", want: ""},
+		{name: "relative", link: "/uploads/tom.jpg", want: "https://pets.example.com/uploads/tom.jpg"},
+		{name: "absolute", link: "https://cdn.example.com/tom.jpg", want: "https://cdn.example.com/tom.jpg"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, AbsoluteURL("https://pets.example.com/", tt.link))
+		})
+	}
+}
+
+func TestFormatPrice(t *testing.T) {
+	assert.Equal(t, "$0.00", FormatPrice(0))
+	assert.Equal(t, "$150.05", FormatPrice(15005))
+}
+
+func TestRenderStorePage(t *testing.T) {
+	petID := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+	page := &StorePage{
+		BaseURL: "https://pets.example.com",
+		Store: Store{
+			Name:         "Paws & Claws",
+			Slug:         "paws-claws",
+			Address:      "1 Main St",
+			OpeningHours: []OpeningHours{{Day: "monday", Opens: "09:00", Closes: "17:00"}},
+		},
+		Pets:    []Pet{{ID: petID, Name: "Tom", Species: "Cat", Age: 2, PriceCents: 15000}},
+		Page:    2,
+		HasNext: true,
+	}
+
+	html, err := RenderStorePage(page)
+	require.NoError(t, err)
+
+	body := string(html)
+	assert.Contains(t, body, "<title>Paws &amp; Claws (page 2)</title>")
+	assert.Contains(t, body, `<link rel="canonical" href="https://pets.example.com/stores/paws-claws?page=2">`)
+	assert.Contains(t, body, `<link rel="prev" href="https://pets.example.com/stores/paws-claws">`)
+	assert.Contains(t, body, `<link rel="next" href="https://pets.example.com/stores/paws-claws?page=3">`)
+	assert.Contains(t, body, `<meta property="og:type" content="website">`)
+	assert.Contains(t, body, `href="https://pets.example.com/pets/`+petID.String()+`"`)
+	assert.Contains(t, body, "$150.00")
+
+	data := extractJSONLD(t, html)
+	assert.Equal(t, "PetStore", data["@type"])
+	assert.Equal(t, "Paws & Claws", data["name"])
+	items := data["makesOffer"].(map[string]any)["itemListElement"].([]any)
+	require.Len(t, items, 1)
+	assert.Equal(t, float64(PetsPerPage+1), items[0].(map[string]any)["position"])
+}
+
+func TestRenderPetPage(t *testing.T) {
+	petID := uuid.MustParse("7b7c6f0e-4a38-4f43-9a3f-2a6c1f1d9b1e")
+	page := &PetPage{
+		BaseURL: "https://pets.example.com",
+		Store:   Store{Name: "Paws", Slug: "paws"},
+		Pet: Pet{
+			ID:          petID,
+			Name:        "Tom",
+			Species:     "Cat",
+			Age:         1,
+			Description: "Likes naps </script><script>alert(1)</script>",
+			PictureURL:  "https://pets.example.com/uploads/tom.jpg",
+			PriceCents:  15005,
+		},
+	}
+
+	html, err := RenderPetPage(page)
+	require.NoError(t, err)
+
+	body := string(html)
+	assert.Contains(t, body, "<title>Tom the Cat | Paws</title>")
+	assert.Contains(t, body, `<meta property="og:image" content="https://pets.example.com/uploads/tom.jpg">`)
+	assert.Contains(t, body, "1 year old")
+	assert.NotContains(t, body, "bred by")
+	assert.NotContains(t, body, "<script>alert(1)</script>")
+
+	data := extractJSONLD(t, html)
+	assert.Equal(t, "Product", data["@type"])
+	assert.Equal(t, page.Pet.Description, data["description"])
+	offer := data["offers"].(map[string]any)
+	assert.Equal(t, "150.05", offer["price"])
+	assert.Equal(t, "USD", offer["priceCurrency"])
+	assert.Equal(t, "https://schema.org/InStock", offer["availability"])
+}
+
+func TestRenderSitemap(t *testing.T) {
+	xml, err := RenderSitemap([]SitemapURL{
+		{Loc: "https://pets.example.com/stores/paws"},
+		{Loc: "https://pets.example.com/pets/1?a=1&b=2"},
+	})
+	require.NoError(t, err)
+
+	body := string(xml)
+	assert.True(t, strings.HasPrefix(body, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, body, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, body, "<loc>https://pets.example.com/stores/paws</loc>")
+	assert.Contains(t, body, "<loc>https://pets.example.com/pets/1?a=1&amp;b=2</loc>")
+	assert.NotContains(t, body, "<lastmod>")
+}
@@ -1,19 +1,32 @@
 package validation
 
 import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	apperrors "github.com/fehepe/pet-store/backend/internal/errors"
 	"github.com/fehepe/pet-store/backend/internal/models"
+	"github.com/fehepe/pet-store/backend/internal/webhook"
 )
 
 var (
-	emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	emailRegex         = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	promotionCodeRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	slugRegex          = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	phoneRegex         = regexp.MustCompile(`^\+?[0-9 ().-]{5,30}$`)
+	nonSlugCharsRegex  = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
-// ValidateCreatePetInput validates the input for creating a pet
-func ValidateCreatePetInput(input models.CreatePetInput) error {
+// MaxSearchRadiusKm is the largest radius accepted by proximity searches
+const MaxSearchRadiusKm = 500
+
+// ValidateCreatePetInput validates the input for creating a pet in a store with the given settings
+func ValidateCreatePetInput(input models.CreatePetInput, settings *models.StoreSettings) error {
 	if strings.TrimSpace(input.Name) == "" {
 		return apperrors.NewValidationError("name", "pet name is required and cannot be empty")
 	}
@@ -26,8 +39,8 @@ func ValidateCreatePetInput(input models.CreatePetInput) error {
 		return apperrors.NewValidationError("age", "pet age cannot be negative")
 	}
 
-	if input.Age > 50 {
-		return apperrors.NewValidationError("age", "pet age cannot exceed 50 years")
+	if input.Age > settings.MaxPetAge {
+		return apperrors.NewValidationError("age", fmt.Sprintf("pet age cannot exceed %d years", settings.MaxPetAge))
 	}
 
 	if !IsValidSpecies(string(input.Species)) {
@@ -54,6 +67,14 @@ func ValidateCreatePetInput(input models.CreatePetInput) error {
 		return apperrors.NewValidationError("pictureURL", "picture URL cannot exceed 500 characters")
 	}
 
+	if input.PriceCents < 0 {
+		return apperrors.NewValidationError("priceCents", "price cannot be negative")
+	}
+
+	if input.PriceCents > models.MaxPriceCents {
+		return apperrors.NewValidationError("priceCents", fmt.Sprintf("price cannot exceed %d cents", models.MaxPriceCents))
+	}
+
 	return nil
 }
 
@@ -108,8 +129,181 @@ func ValidateCreateStoreInput(input models.CreateStoreInput) error {
 	return nil
 }
 
-// ValidateCreateOrderInput validates the input for creating an order
-func ValidateCreateOrderInput(input models.CreateOrderInput) error {
+// ValidateUpdateStoreInput validates the store profile fields being changed
+func ValidateUpdateStoreInput(input models.UpdateStoreInput) error {
+	if input.Name != nil {
+		if strings.TrimSpace(*input.Name) == "" {
+			return apperrors.NewValidationError("name", "store name cannot be empty")
+		}
+		if len(strings.TrimSpace(*input.Name)) > 100 {
+			return apperrors.NewValidationError("name", "store name cannot exceed 100 characters")
+		}
+	}
+
+	if input.Slug != nil {
+		slug := strings.TrimSpace(*input.Slug)
+		if len(slug) > 100 || !slugRegex.MatchString(slug) {
+			return apperrors.NewValidationError("slug", "slug may only contain lowercase letters, digits and single dashes")
+		}
+	}
+
+	if input.Description != nil && len(*input.Description) > 2000 {
+		return apperrors.NewValidationError("description", "description cannot exceed 2000 characters")
+	}
+
+	if input.Address != nil && len(strings.TrimSpace(*input.Address)) > 500 {
+		return apperrors.NewValidationError("address", "address cannot exceed 500 characters")
+	}
+
+	if input.Phone != nil && strings.TrimSpace(*input.Phone) != "" && !phoneRegex.MatchString(strings.TrimSpace(*input.Phone)) {
+		return apperrors.NewValidationError("phone", "invalid phone number")
+	}
+
+	if input.LogoURL != nil && strings.TrimSpace(*input.LogoURL) != "" {
+		logo := strings.TrimSpace(*input.LogoURL)
+		if len(logo) > 500 || !(strings.HasPrefix(logo, "https://") || strings.HasPrefix(logo, "http://") || strings.HasPrefix(logo, "/uploads/")) {
+			return apperrors.NewValidationError("logoURL", "logo must be an http(s) URL or an uploaded file")
+		}
+	}
+
+	for _, hours := range input.OpeningHours {
+		if !IsValidWeekday(string(hours.Day)) {
+			return apperrors.NewValidationError("openingHours", fmt.Sprintf("invalid day: %s", hours.Day))
+		}
+		opens, err := ParseTimeOfDay(hours.Opens)
+		if err != nil {
+			return apperrors.NewValidationError("openingHours", "opening times must use the HH:MM format")
+		}
+		closes, err := ParseTimeOfDay(hours.Closes)
+		if err != nil {
+			return apperrors.NewValidationError("openingHours", "closing times must use the HH:MM format")
+		}
+		if closes <= opens {
+			return apperrors.NewValidationError("openingHours", fmt.Sprintf("%s closes before it opens", hours.Day))
+		}
+	}
+
+	if (input.Latitude == nil) != (input.Longitude == nil) {
+		return apperrors.NewValidationError("location", "latitude and longitude must be set together")
+	}
+	if input.Latitude != nil {
+		if err := validateCoordinates(*input.Latitude, *input.Longitude); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateGeoRadius validates the center and radius of a proximity search
+func ValidateGeoRadius(near models.GeoRadius) error {
+	if err := validateCoordinates(near.Latitude, near.Longitude); err != nil {
+		return err
+	}
+
+	if near.RadiusKm <= 0 {
+		return apperrors.NewValidationError("radiusKm", "radius must be greater than 0")
+	}
+	if near.RadiusKm > MaxSearchRadiusKm {
+		return apperrors.NewValidationError("radiusKm", fmt.Sprintf("radius cannot exceed %d km", MaxSearchRadiusKm))
+	}
+
+	return nil
+}
+
+func validateCoordinates(lat, lng float64) error {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return apperrors.NewValidationError("latitude", "latitude must be between -90 and 90")
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return apperrors.NewValidationError("longitude", "longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// ValidateUpdateStoreSettingsInput validates the store settings being changed
+func ValidateUpdateStoreSettingsInput(input models.UpdateStoreSettingsInput) error {
+	if input.MaxPetsPerOrder != nil && (*input.MaxPetsPerOrder < 1 || *input.MaxPetsPerOrder > 50) {
+		return apperrors.NewValidationError("maxPetsPerOrder", "maximum pets per order must be between 1 and 50")
+	}
+
+	if input.MaxPetAge != nil && (*input.MaxPetAge < 1 || *input.MaxPetAge > 100) {
+		return apperrors.NewValidationError("maxPetAge", "maximum pet age must be between 1 and 100")
+	}
+
+	if input.MaxPageSize != nil && (*input.MaxPageSize < 1 || *input.MaxPageSize > 500) {
+		return apperrors.NewValidationError("maxPageSize", "maximum page size must be between 1 and 500")
+	}
+
+	if input.MinCustomerAge != nil && (*input.MinCustomerAge < 0 || *input.MinCustomerAge > 120) {
+		return apperrors.NewValidationError("minCustomerAge", "minimum customer age must be between 0 and 120")
+	}
+
+	if input.ReservationHoldHours != nil && (*input.ReservationHoldHours < 1 || *input.ReservationHoldHours > 720) {
+		return apperrors.NewValidationError("reservationHoldHours", "reservation hold must be between 1 and 720 hours")
+	}
+
+	if input.StaleAfterDays != nil && (*input.StaleAfterDays < 1 || *input.StaleAfterDays > 365) {
+		return apperrors.NewValidationError("staleAfterDays", "stale listing threshold must be between 1 and 365 days")
+	}
+
+	if input.Timezone != nil {
+		// LoadLocation maps "" to UTC and "Local" to the server's zone; neither names a store's zone
+		if _, err := time.LoadLocation(*input.Timezone); err != nil || *input.Timezone == "" || *input.Timezone == "Local" {
+			return apperrors.NewValidationError("timezone", "timezone must be an IANA time zone name such as Europe/Madrid")
+		}
+	}
+
+	return nil
+}
+
+// ValidateSalesReportInput validates the range and grouping of a sales report
+func ValidateSalesReportInput(input models.SalesReportInput) error {
+	switch input.GroupBy {
+	case models.SalesGroupByDay, models.SalesGroupByWeek, models.SalesGroupByMonth, models.SalesGroupBySpecies:
+	default:
+		return apperrors.NewValidationError("groupBy", "sales can be grouped by day, week, month or species")
+	}
+
+	if input.From.IsZero() || input.To.IsZero() {
+		return apperrors.NewValidationError("range", "report range needs a start and an end")
+	}
+
+	if !input.To.After(input.From) {
+		return apperrors.NewValidationError("range", "report range must end after it starts")
+	}
+
+	if input.To.Sub(input.From) > 5*366*24*time.Hour {
+		return apperrors.NewValidationError("range", "report range cannot exceed 5 years")
+	}
+
+	return nil
+}
+
+// IsValidWeekday checks if a day name is a valid weekday
+func IsValidWeekday(day string) bool {
+	switch models.Weekday(day) {
+	case models.WeekdayMonday, models.WeekdayTuesday, models.WeekdayWednesday, models.WeekdayThursday,
+		models.WeekdayFriday, models.WeekdaySaturday, models.WeekdaySunday:
+		return true
+	}
+	return false
+}
+
+// Slugify converts a name into a URL slug
+func Slugify(name string) string {
+	slug := strings.Trim(nonSlugCharsRegex.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(slug) > 100 {
+		slug = strings.TrimRight(slug[:100], "-")
+	}
+	if slug == "" {
+		return "store"
+	}
+	return slug
+}
+
+// ValidateCreateOrderInput validates the input for creating an order at a store with the given settings
+func ValidateCreateOrderInput(input models.CreateOrderInput, settings *models.StoreSettings) error {
 	if strings.TrimSpace(input.CustomerID) == "" {
 		return apperrors.NewValidationError("customerID", "customer ID is required and cannot be empty")
 	}
@@ -122,8 +316,12 @@ func ValidateCreateOrderInput(input models.CreateOrderInput) error {
 		return apperrors.NewValidationError("petIDs", "at least one pet ID is required")
 	}
 
-	if len(input.PetIDs) > 10 {
-		return apperrors.NewValidationError("petIDs", "cannot purchase more than 10 pets in a single order")
+	if len(input.PetIDs) > settings.MaxPetsPerOrder {
+		return apperrors.NewValidationError("petIDs", fmt.Sprintf("cannot purchase more than %d pets in a single order", settings.MaxPetsPerOrder))
+	}
+
+	if settings.MinCustomerAge > 0 && (input.CustomerAge == nil || *input.CustomerAge < settings.MinCustomerAge) {
+		return apperrors.NewValidationError("customerAge", fmt.Sprintf("customers must be at least %d years old to buy from this store", settings.MinCustomerAge))
 	}
 
 	// Check for duplicate pet IDs
@@ -141,3 +339,155 @@ func ValidateCreateOrderInput(input models.CreateOrderInput) error {
 
 	return nil
 }
+
+// ValidateCreatePromotionInput validates the input for creating a promotion
+func ValidateCreatePromotionInput(input models.CreatePromotionInput) error {
+	code := strings.TrimSpace(input.Code)
+	if code == "" {
+		return apperrors.NewValidationError("code", "promotion code is required and cannot be empty")
+	}
+
+	if len(code) > 50 {
+		return apperrors.NewValidationError("code", "promotion code cannot exceed 50 characters")
+	}
+
+	if !promotionCodeRegex.MatchString(code) {
+		return apperrors.NewValidationError("code", "promotion code may only contain letters, digits, dashes and underscores")
+	}
+
+	switch input.DiscountType {
+	case models.DiscountTypePercentage, models.DiscountTypeNthItemPercentage:
+		if input.DiscountValue <= 0 || input.DiscountValue > 100 {
+			return apperrors.NewValidationError("discountValue", "percentage discounts must be between 1 and 100")
+		}
+	case models.DiscountTypeFixedAmount:
+		if input.DiscountValue <= 0 {
+			return apperrors.NewValidationError("discountValue", "fixed discounts must be greater than zero")
+		}
+	default:
+		return apperrors.NewValidationError("discountType", "discount type must be percentage, fixed_amount or nth_item_percentage")
+	}
+
+	if input.DiscountType == models.DiscountTypeNthItemPercentage && input.MinQuantity < 2 {
+		return apperrors.NewValidationError("minQuantity", "nth item discounts require a minimum quantity of at least 2")
+	}
+
+	if input.MinQuantity < 0 {
+		return apperrors.NewValidationError("minQuantity", "minimum quantity cannot be negative")
+	}
+
+	if input.Species != nil && !IsValidSpecies(string(*input.Species)) {
+		return apperrors.NewValidationError("species", "species must be Cat, Dog, or Frog")
+	}
+
+	if input.MinAge != nil && *input.MinAge < 0 {
+		return apperrors.NewValidationError("minAge", "minimum age cannot be negative")
+	}
+
+	if input.MaxAge != nil && *input.MaxAge < 0 {
+		return apperrors.NewValidationError("maxAge", "maximum age cannot be negative")
+	}
+
+	if input.MinAge != nil && input.MaxAge != nil && *input.MinAge > *input.MaxAge {
+		return apperrors.NewValidationError("maxAge", "maximum age cannot be lower than minimum age")
+	}
+
+	if input.StartsAt != nil && input.EndsAt != nil && !input.EndsAt.After(*input.StartsAt) {
+		return apperrors.NewValidationError("endsAt", "promotion must end after it starts")
+	}
+
+	if input.UsageLimit != nil && *input.UsageLimit <= 0 {
+		return apperrors.NewValidationError("usageLimit", "usage limit must be greater than zero")
+	}
+
+	if input.PerCustomerLimit != nil && *input.PerCustomerLimit <= 0 {
+		return apperrors.NewValidationError("perCustomerLimit", "per-customer limit must be greater than zero")
+	}
+
+	if input.Description != nil && len(*input.Description) > 500 {
+		return apperrors.NewValidationError("description", "description cannot exceed 500 characters")
+	}
+
+	return nil
+}
+
+// ValidateCreateWebhookEndpointInput validates the input for registering a webhook endpoint.
+// Unless allowPrivate is set, as in development, the URL must use https and must not name
+// a local host; the webhook client re-checks the resolved address when it connects.
+func ValidateCreateWebhookEndpointInput(input models.CreateWebhookEndpointInput, allowPrivate bool) error {
+	if len(input.URL) > 500 {
+		return apperrors.NewValidationError("url", "URL cannot exceed 500 characters")
+	}
+
+	endpoint, err := url.Parse(input.URL)
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return apperrors.NewValidationError("url", "URL must be an absolute http(s) URL")
+	}
+	if endpoint.User != nil {
+		return apperrors.NewValidationError("url", "URL cannot contain credentials")
+	}
+	if !allowPrivate {
+		if endpoint.Scheme != "https" {
+			return apperrors.NewValidationError("url", "URL must use https")
+		}
+		host := strings.ToLower(endpoint.Hostname())
+		if ip := net.ParseIP(host); host == "localhost" || strings.HasSuffix(host, ".localhost") || (ip != nil && !webhook.IsPublicIP(ip)) {
+			return apperrors.NewValidationError("url", "URL must point to a public host")
+		}
+	}
+
+	if len(input.EventTypes) == 0 {
+		return apperrors.NewValidationError("eventTypes", "at least one event type is required")
+	}
+	for _, eventType := range input.EventTypes {
+		if !eventType.IsValid() {
+			return apperrors.NewValidationError("eventTypes", fmt.Sprintf("unknown event type: %s", eventType))
+		}
+	}
+
+	return nil
+}
+
+// ParseTimeOfDay parses an HH:MM clock time into an offset from midnight
+func ParseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// ValidateCreatePickupSlotsInput validates the opening hours used to generate pickup slots
+func ValidateCreatePickupSlotsInput(input models.CreatePickupSlotsInput) error {
+	if input.Date.IsZero() {
+		return apperrors.NewValidationError("date", "date is required")
+	}
+
+	opensAt, err := ParseTimeOfDay(input.OpensAt)
+	if err != nil {
+		return apperrors.NewValidationError("opensAt", "opening time must use the HH:MM format")
+	}
+
+	closesAt, err := ParseTimeOfDay(input.ClosesAt)
+	if err != nil {
+		return apperrors.NewValidationError("closesAt", "closing time must use the HH:MM format")
+	}
+
+	if closesAt <= opensAt {
+		return apperrors.NewValidationError("closesAt", "closing time must be after opening time")
+	}
+
+	if input.SlotMinutes < 5 || input.SlotMinutes > 240 {
+		return apperrors.NewValidationError("slotMinutes", "slot length must be between 5 and 240 minutes")
+	}
+
+	if time.Duration(input.SlotMinutes)*time.Minute > closesAt-opensAt {
+		return apperrors.NewValidationError("slotMinutes", "slot length cannot exceed the opening hours")
+	}
+
+	if input.Capacity <= 0 || input.Capacity > 100 {
+		return apperrors.NewValidationError("capacity", "capacity must be between 1 and 100")
+	}
+
+	return nil
+}
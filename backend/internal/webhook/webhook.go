@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Headers set on every delivery
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// DefaultTimeout bounds a single delivery attempt
+const DefaultTimeout = 10 * time.Second
+
+// ErrDisallowedAddress is returned when an endpoint resolves to a loopback, private,
+// link-local or unspecified address
+var ErrDisallowedAddress = errors.New("webhook endpoint resolves to a non-public address")
+
+// IsPublicIP reports whether ip may receive webhook deliveries
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<payload>". Including the timestamp
+// lets receivers reject replayed deliveries.
+func Sign(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature matches the timestamp and payload for the given secret
+func Verify(secret string, timestamp int64, payload []byte, signature string) bool {
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	actual, _ := hex.DecodeString(Sign(secret, timestamp, payload))
+	return hmac.Equal(actual, expected)
+}
+
+// Request is a single signed delivery
+type Request struct {
+	URL        string
+	Secret     string
+	DeliveryID string
+	EventType  string
+	Payload    []byte
+}
+
+// Sender delivers signed webhook requests
+type Sender interface {
+	// Send posts the request and returns the response status; non-2xx responses are errors
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+// Ensure Client implements Sender interface
+var _ Sender = (*Client)(nil)
+
+// Client sends webhook requests over HTTP
+type Client struct {
+	http *http.Client
+	now  func() time.Time
+}
+
+// NewClient creates a webhook client whose attempts time out after timeout. Unless
+// allowPrivate is set, connections to non-public addresses are refused when they are
+// dialled, so a hostname cannot be rebound to an internal address after validation.
+func NewClient(timeout time.Duration, allowPrivate bool) *Client {
+	dialer := &net.Dialer{Timeout: timeout, KeepAlive: 30 * time.Second}
+	if !allowPrivate {
+		dialer.Control = refuseNonPublic
+	}
+
+	return &Client{
+		http: &http.Client{
+			Timeout: timeout,
+			// No proxy: the dial check must see the endpoint's own address
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			// Redirects are not followed so a delivery always goes to the registered URL
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// refuseNonPublic is a net.Dialer control hook that rejects connections to non-public addresses
+func refuseNonPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, host)
+	}
+	return nil
+}
+
+// Send posts the payload as JSON with the signature headers
+func (c *Client) Send(ctx context.Context, req Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := c.now().Unix()
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "pet-store-webhooks/1.0")
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderDelivery, req.DeliveryID)
+	httpReq.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook endpoint responded with %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
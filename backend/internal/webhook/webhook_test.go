@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	payload := []byte(`{"type":"pet.created"}`)
+	signature := Sign("whsec_test", 1700000000, payload)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		payload   []byte
+		signature string
+		want      bool
+	}{
+		{name: "valid", secret: "whsec_test", timestamp: 1700000000, payload: payload, signature: signature, want: true},
+		{name: "wrong secret", secret: "whsec_other", timestamp: 1700000000, payload: payload, signature: signature},
+		{name: "replayed timestamp", secret: "whsec_test", timestamp: 1700000001, payload: payload, signature: signature},
+		{name: "tampered payload", secret: "whsec_test", timestamp: 1700000000, payload: []byte(`{"type":"pet.sold"}`), signature: signature},
+		{name: "not hex", secret: "whsec_test", timestamp: 1700000000, payload: payload, signature: "zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Verify(tt.secret, tt.timestamp, tt.payload, tt.signature))
+		})
+	}
+}
+
+func TestClient_Send(t *testing.T) {
+	payload := []byte(`{"id":"evt","type":"order.created"}`)
+
+	tests := []struct {
+		name       string
+		respond    int
+		wantStatus int
+		wantErr    bool
+	}{
+		{name: "accepted", respond: http.StatusNoContent, wantStatus: http.StatusNoContent},
+		{name: "server error", respond: http.StatusInternalServerError, wantStatus: http.StatusInternalServerError, wantErr: true},
+		{name: "redirect is not followed", respond: http.StatusFound, wantStatus: http.StatusFound, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var body []byte
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				if tt.respond == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+				w.WriteHeader(tt.respond)
+			}))
+			defer receiver.Close()
+
+			client := NewClient(time.Second, true)
+			status, err := client.Send(context.Background(), Request{
+				URL:        receiver.URL,
+				Secret:     "whsec_test",
+				DeliveryID: "delivery-1",
+				EventType:  "order.created",
+				Payload:    payload,
+			})
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NotNil(t, received)
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, "/", received.URL.Path)
+			assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+			assert.Equal(t, "order.created", received.Header.Get(HeaderEvent))
+			assert.Equal(t, "delivery-1", received.Header.Get(HeaderDelivery))
+			assert.Equal(t, payload, body)
+
+			timestamp, err := strconv.ParseInt(received.Header.Get(HeaderTimestamp), 10, 64)
+			require.NoError(t, err)
+			assert.True(t, Verify("whsec_test", timestamp, body, received.Header.Get(HeaderSignature)))
+		})
+	}
+}
+
+func TestClient_SendUnreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	url := receiver.URL
+	receiver.Close()
+
+	status, err := NewClient(time.Second, true).Send(context.Background(), Request{URL: url, Payload: []byte("{}")})
+	assert.Equal(t, 0, status)
+	assert.Error(t, err)
+}
+
+func TestClient_SendRefusesNonPublicAddresses(t *testing.T) {
+	var called bool
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer receiver.Close()
+
+	status, err := NewClient(time.Second, false).Send(context.Background(), Request{URL: receiver.URL, Payload: []byte("{}")})
+
+	assert.Equal(t, 0, status)
+	assert.ErrorIs(t, err, ErrDisallowedAddress)
+	assert.False(t, called)
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "fd00::1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "0.0.0.0"},
+		{ip: "::"},
+		{ip: "::ffff:127.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPublicIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
@@ -111,7 +111,7 @@ export const PetList: React.FC = () => {
     );
   }
 
-  const pets = data?.availablePets.edges || [];
+  const pets = (data?.availablePets.edges || []).map((edge: any) => edge.node);
   const hasMore = data?.availablePets.pageInfo.hasNextPage || false;
 
   return (
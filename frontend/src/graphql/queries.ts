@@ -20,7 +20,10 @@ export const GET_AVAILABLE_PETS = gql`
   query GetAvailablePets($storeID: UUID!, $pagination: PaginationInput) {
     availablePets(storeID: $storeID, pagination: $pagination) {
       edges {
-        ...PetFields
+        cursor
+        node {
+          ...PetFields
+        }
       }
       pageInfo {
         hasNextPage